@@ -5,6 +5,8 @@ import (
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/groupHandler"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/scanner"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/scheduler"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
 	"github.com/jacksonbarreto/WebGateScanner-kafka/consumer"
 	"github.com/jacksonbarreto/WebGateScanner-kafka/producer"
@@ -16,7 +18,13 @@ func main() {
 	config.InitConfig(configFilePath)
 	logger := logservice.NewLogServiceDefault()
 	logger.Info("Starting DNSSEC Analyzer")
-	dnsScanner := scanner.NewScannerDefault()
+	repository := storage.NewRepositoryDefault()
+	if repository != nil {
+		defer repository.Close()
+		logger.Info("Storing assessments in %s", config.Storage().Path)
+	}
+	dnsScanner := scanner.NewScannerDefault(repository)
+	defer dnsScanner.Close()
 
 	kafkaProducer, producerErr := producer.NewProducer(config.Kafka().TopicProducer, config.Kafka().Brokers,
 		config.Kafka().MaxRetry)
@@ -25,12 +33,41 @@ func main() {
 	}
 	defer kafkaProducer.Close()
 	logger.Info("Producer to topic %s created", config.Kafka().TopicProducer)
-	handler := groupHandler.NewAnalysisConsumerGroupHandlerDefault(dnsScanner, kafkaProducer)
+	var errorProducer producer.IProducer
+	if config.Kafka().TopicError != "" {
+		errorProducer, producerErr = producer.NewProducer(config.Kafka().TopicError, config.Kafka().Brokers,
+			config.Kafka().MaxRetry)
+		if producerErr != nil {
+			panic(producerErr)
+		}
+		defer errorProducer.Close()
+		logger.Info("Producer to topic %s created", config.Kafka().TopicError)
+	}
+	var changeProducer producer.IProducer
+	if config.Kafka().TopicChanges != "" {
+		changeProducer, producerErr = producer.NewProducer(config.Kafka().TopicChanges, config.Kafka().Brokers,
+			config.Kafka().MaxRetry)
+		if producerErr != nil {
+			panic(producerErr)
+		}
+		defer changeProducer.Close()
+		logger.Info("Producer to topic %s created", config.Kafka().TopicChanges)
+	}
+	handler := groupHandler.NewAnalysisConsumerGroupHandlerDefault(dnsScanner, kafkaProducer, errorProducer, changeProducer,
+		repository)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if config.Scheduler().Enabled {
+		rescanScheduler := scheduler.NewSchedulerDefault(repository, handler)
+		go rescanScheduler.Run(ctx)
+		logger.Info("Rescanning assessed domains every %d hours", config.Scheduler().IntervalHours)
+	}
 
 	kafkaConfig := config.Kafka()
 	logger.Info("Starting consumer for topics: %v", kafkaConfig.TopicsConsumer)
 	kafkaConsumer, consumerErr := consumer.NewConsumer(kafkaConfig.Brokers, kafkaConfig.GroupID,
-		kafkaConfig.TopicsConsumer, handler, context.Background())
+		kafkaConfig.TopicsConsumer, handler, ctx)
 	if consumerErr != nil {
 		panic(consumerErr)
 	}
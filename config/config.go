@@ -2,20 +2,36 @@ package config
 
 import (
 	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/resolver"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 	"log"
+	"net"
+	"os"
 )
 
 type Config struct {
-	App   AppConfig   `mapstructure:"App"`
-	Kafka KafkaConfig `mapstructure:"kafka"`
+	App           AppConfig           `mapstructure:"App"`
+	Kafka         KafkaConfig         `mapstructure:"kafka"`
+	Scoring       ScoringConfig       `mapstructure:"scoring"`
+	ZoneWalk      ZoneWalkConfig      `mapstructure:"zonewalk"`
+	Authoritative AuthoritativeConfig `mapstructure:"authoritative"`
+	Delegation    DelegationConfig    `mapstructure:"delegation"`
+	TrustAnchors  TrustAnchorConfig   `mapstructure:"trustanchors"`
+	KeyHistory    KeyHistoryConfig    `mapstructure:"keyhistory"`
+	Storage       StorageConfig       `mapstructure:"storage"`
+	Scheduler     SchedulerConfig     `mapstructure:"scheduler"`
+	Hostnames     HostnameConfig      `mapstructure:"hostnames"`
+	Chain         ChainConfig         `mapstructure:"chain"`
+	DANE          DANEConfig          `mapstructure:"dane"`
+	ZONEMD        ZONEMDConfig        `mapstructure:"zonemd"`
 }
 
 type AppConfig struct {
 	Environment string
 	Id          string
 	DNSServer   string
+	Resolvers   []string
 }
 
 type KafkaConfig struct {
@@ -23,16 +39,116 @@ type KafkaConfig struct {
 	TopicsConsumer []string
 	TopicProducer  string
 	TopicError     string
+	TopicChanges   string
 	GroupID        string
 	MaxRetry       int
 }
 
+type ScoringConfig struct {
+	Deployment        float64
+	AlgorithmStrength float64
+	DigestStrength    float64
+	DenialOfExistence float64
+	SignatureHygiene  float64
+	KeyManagement     float64
+}
+
+type ZoneWalkConfig struct {
+	Enabled          bool
+	MaxSteps         int
+	QueriesPerSecond float64
+}
+
+type AuthoritativeConfig struct {
+	Enabled bool
+}
+
+type DelegationConfig struct {
+	Enabled bool
+}
+
+// TrustAnchorConfig replaces the built-in root trust anchor and disables validation below the
+// negative anchors. Both are applied by delv, so they cannot be combined with an encrypted
+// (DoT or DoH) DNSServer, whose answers are validated by the resolver itself.
+type TrustAnchorConfig struct {
+	File            string
+	NegativeAnchors []string
+}
+
+type KeyHistoryConfig struct {
+	Enabled        bool
+	StuckAfterDays int
+}
+
+type StorageConfig struct {
+	Enabled bool
+	Driver  string
+	Path    string
+}
+
+type SchedulerConfig struct {
+	Enabled            bool
+	IntervalHours      int
+	MinIntervalMinutes int
+	ExpiryMarginHours  int
+	Jitter             float64
+	TickSeconds        int
+}
+
+type HostnameConfig struct {
+	AllowPrivateNames bool
+}
+
+type ChainConfig struct {
+	Enabled bool
+}
+
+type DANEConfig struct {
+	Enabled             bool
+	CompareCertificates bool
+	TimeoutSeconds      int
+}
+
+type ZONEMDConfig struct {
+	Verify         bool
+	TransferServer string
+	ZoneDirectory  string
+	TimeoutSeconds int
+}
+
 type configValidator func(*Config) error
 
 var validators = []configValidator{
 	func(cfg *Config) error {
 		return validateEnvironment(cfg.App.Environment)
 	},
+	func(cfg *Config) error {
+		return validateResolvers(append([]string{cfg.App.DNSServer}, cfg.App.Resolvers...))
+	},
+	func(cfg *Config) error {
+		return validateScoringWeights(cfg.Scoring)
+	},
+	func(cfg *Config) error {
+		return validateZoneWalk(cfg.ZoneWalk)
+	},
+	func(cfg *Config) error {
+		return validateTrustAnchors(cfg.TrustAnchors, cfg.App.DNSServer)
+	},
+	func(cfg *Config) error {
+		return validateKeyHistory(cfg.KeyHistory, cfg.Storage)
+	},
+	func(cfg *Config) error {
+		return validateStorage(cfg.Storage)
+	},
+	func(cfg *Config) error {
+		return validateScheduler(cfg.Scheduler, cfg.Storage)
+	},
+	func(cfg *Config) error {
+		return validateDANE(cfg.DANE)
+	},
+	func(cfg *Config) error {
+		return validateZONEMD(cfg.ZONEMD)
+	},
 }
 
 var internalConfig = &Config{}
@@ -47,6 +163,36 @@ func InitConfig(configPath string) {
 	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
 	viper.SetDefault("app.environment", "prod")
+	viper.SetDefault("app.resolvers", []string{})
+	viper.SetDefault("scoring.deployment", 30)
+	viper.SetDefault("scoring.algorithmstrength", 20)
+	viper.SetDefault("scoring.digeststrength", 10)
+	viper.SetDefault("scoring.denialofexistence", 15)
+	viper.SetDefault("scoring.signaturehygiene", 15)
+	viper.SetDefault("scoring.keymanagement", 10)
+	viper.SetDefault("zonewalk.enabled", false)
+	viper.SetDefault("zonewalk.maxsteps", 50)
+	viper.SetDefault("zonewalk.queriespersecond", 2)
+	viper.SetDefault("authoritative.enabled", false)
+	viper.SetDefault("delegation.enabled", false)
+	viper.SetDefault("keyhistory.enabled", false)
+	viper.SetDefault("keyhistory.stuckafterdays", 60)
+	viper.SetDefault("storage.enabled", false)
+	viper.SetDefault("storage.driver", "sqlite")
+	viper.SetDefault("storage.path", "assessments.db")
+	viper.SetDefault("scheduler.enabled", false)
+	viper.SetDefault("scheduler.intervalhours", 24)
+	viper.SetDefault("scheduler.minintervalminutes", 60)
+	viper.SetDefault("scheduler.expirymarginhours", 48)
+	viper.SetDefault("scheduler.jitter", 0.1)
+	viper.SetDefault("scheduler.tickseconds", 60)
+	viper.SetDefault("hostnames.allowprivatenames", false)
+	viper.SetDefault("chain.enabled", false)
+	viper.SetDefault("dane.enabled", false)
+	viper.SetDefault("dane.comparecertificates", false)
+	viper.SetDefault("dane.timeoutseconds", 5)
+	viper.SetDefault("zonemd.verify", false)
+	viper.SetDefault("zonemd.timeoutseconds", 30)
 
 	err := viper.ReadInConfig()
 	if err != nil {
@@ -79,6 +225,54 @@ func App() *AppConfig {
 	return &internalConfig.App
 }
 
+func Scoring() *ScoringConfig {
+	return &internalConfig.Scoring
+}
+
+func ZoneWalk() *ZoneWalkConfig {
+	return &internalConfig.ZoneWalk
+}
+
+func Authoritative() *AuthoritativeConfig {
+	return &internalConfig.Authoritative
+}
+
+func Delegation() *DelegationConfig {
+	return &internalConfig.Delegation
+}
+
+func TrustAnchors() *TrustAnchorConfig {
+	return &internalConfig.TrustAnchors
+}
+
+func KeyHistory() *KeyHistoryConfig {
+	return &internalConfig.KeyHistory
+}
+
+func Storage() *StorageConfig {
+	return &internalConfig.Storage
+}
+
+func Scheduler() *SchedulerConfig {
+	return &internalConfig.Scheduler
+}
+
+func Hostnames() *HostnameConfig {
+	return &internalConfig.Hostnames
+}
+
+func Chain() *ChainConfig {
+	return &internalConfig.Chain
+}
+
+func DANE() *DANEConfig {
+	return &internalConfig.DANE
+}
+
+func ZONEMD() *ZONEMDConfig {
+	return &internalConfig.ZONEMD
+}
+
 // Validators
 func validateEnvironment(env string) error {
 	validEnvironments := map[string]bool{"dev": true, "prod": true}
@@ -88,9 +282,149 @@ func validateEnvironment(env string) error {
 	return nil
 }
 
+func validateResolvers(addresses []string) error {
+	for _, address := range addresses {
+		if _, err := resolver.ParseEndpoint(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateScoringWeights(weights ScoringConfig) error {
+	all := []float64{weights.Deployment, weights.AlgorithmStrength, weights.DigestStrength,
+		weights.DenialOfExistence, weights.SignatureHygiene, weights.KeyManagement}
+	total := 0.0
+	for _, weight := range all {
+		if weight < 0 {
+			return fmt.Errorf("invalid scoring weight %v: weights must not be negative", weight)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("invalid scoring weights: at least one weight must be greater than zero")
+	}
+	return nil
+}
+
+func validateZoneWalk(zoneWalk ZoneWalkConfig) error {
+	if !zoneWalk.Enabled {
+		return nil
+	}
+	if zoneWalk.MaxSteps < 1 {
+		return fmt.Errorf("invalid zone walk step limit %d: it must be at least 1", zoneWalk.MaxSteps)
+	}
+	if zoneWalk.QueriesPerSecond <= 0 {
+		return fmt.Errorf("invalid zone walk rate %v: queries per second must be greater than zero", zoneWalk.QueriesPerSecond)
+	}
+	return nil
+}
+
+func validateTrustAnchors(trustAnchors TrustAnchorConfig, dnsServer string) error {
+	if trustAnchors.File == "" && len(trustAnchors.NegativeAnchors) == 0 {
+		return nil
+	}
+	if endpoint, err := resolver.ParseEndpoint(dnsServer); err == nil && endpoint.Encrypted() {
+		return fmt.Errorf("invalid trust anchor configuration: trust anchors and negative anchors are not applied "+
+			"by the encrypted resolver %s", dnsServer)
+	}
+	if trustAnchors.File == "" {
+		return nil
+	}
+	if _, err := os.Stat(trustAnchors.File); err != nil {
+		return fmt.Errorf("invalid trust anchor file '%s': %v", trustAnchors.File, err)
+	}
+	return nil
+}
+
+func validateKeyHistory(keyHistory KeyHistoryConfig, storage StorageConfig) error {
+	if !keyHistory.Enabled {
+		return nil
+	}
+	if !storage.Enabled {
+		return fmt.Errorf("invalid key history configuration: the history is read from the assessment storage, which must be enabled")
+	}
+	if keyHistory.StuckAfterDays < 0 {
+		return fmt.Errorf("invalid stuck rollover limit %d: it must not be negative", keyHistory.StuckAfterDays)
+	}
+	return nil
+}
+
+func validateStorage(storage StorageConfig) error {
+	if !storage.Enabled {
+		return nil
+	}
+	if storage.Driver != "sqlite" {
+		return fmt.Errorf("invalid storage driver '%s': the driver must be 'sqlite'", storage.Driver)
+	}
+	if storage.Path == "" {
+		return fmt.Errorf("invalid storage path: it must not be empty")
+	}
+	return nil
+}
+
 func validatePort(port int) error {
 	if port < 1 || port > 65535 {
 		return fmt.Errorf("invalid port number %d: port must be between 1 and 65535", port)
 	}
 	return nil
 }
+
+func validateScheduler(scheduler SchedulerConfig, storage StorageConfig) error {
+	if !scheduler.Enabled {
+		return nil
+	}
+	if !storage.Enabled {
+		return fmt.Errorf("invalid scheduler configuration: rescans need the assessment storage to be enabled")
+	}
+	if scheduler.IntervalHours < 1 {
+		return fmt.Errorf("invalid rescan interval %d: it must be at least 1 hour", scheduler.IntervalHours)
+	}
+	if scheduler.MinIntervalMinutes < 1 || scheduler.MinIntervalMinutes > scheduler.IntervalHours*60 {
+		return fmt.Errorf("invalid minimum rescan interval %d: it must be between 1 minute and the rescan interval",
+			scheduler.MinIntervalMinutes)
+	}
+	if scheduler.ExpiryMarginHours < 0 {
+		return fmt.Errorf("invalid signature expiry margin %d: it must not be negative", scheduler.ExpiryMarginHours)
+	}
+	if scheduler.Jitter < 0 || scheduler.Jitter > 1 {
+		return fmt.Errorf("invalid rescan jitter %v: it must be between 0 and 1", scheduler.Jitter)
+	}
+	if scheduler.TickSeconds < 1 {
+		return fmt.Errorf("invalid scheduler tick %d: it must be at least 1 second", scheduler.TickSeconds)
+	}
+	return nil
+}
+
+func validateDANE(dane DANEConfig) error {
+	if !dane.Enabled || !dane.CompareCertificates {
+		return nil
+	}
+	if dane.TimeoutSeconds < 1 {
+		return fmt.Errorf("invalid certificate retrieval timeout %d: it must be at least 1 second", dane.TimeoutSeconds)
+	}
+	return nil
+}
+
+func validateZONEMD(zonemd ZONEMDConfig) error {
+	if !zonemd.Verify {
+		return nil
+	}
+	if zonemd.TransferServer == "" && zonemd.ZoneDirectory == "" {
+		return fmt.Errorf("invalid ZONEMD verification: a transfer server or a zone directory is required")
+	}
+	if zonemd.TransferServer != "" {
+		if _, _, err := net.SplitHostPort(zonemd.TransferServer); err != nil {
+			return fmt.Errorf("invalid zone transfer server '%s': %v", zonemd.TransferServer, err)
+		}
+	}
+	if zonemd.ZoneDirectory != "" {
+		if info, err := os.Stat(zonemd.ZoneDirectory); err != nil || !info.IsDir() {
+			return fmt.Errorf("invalid zone directory '%s': it must be an existing directory", zonemd.ZoneDirectory)
+		}
+	}
+	if zonemd.TimeoutSeconds < 1 {
+		return fmt.Errorf("invalid zone transfer timeout %d: it must be at least 1 second", zonemd.TimeoutSeconds)
+	}
+	return nil
+}
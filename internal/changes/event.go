@@ -0,0 +1,36 @@
+package changes
+
+import (
+	"encoding/json"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+)
+
+// Event is the message published when a rescan of a domain differs from its previous assessment.
+type Event struct {
+	InstitutionID string                 `json:"institution_id"`
+	Origin        string                 `json:"origin"`
+	Domain        string                 `json:"domain"`
+	Url           string                 `json:"url"`
+	PreviousTime  int64                  `json:"previous_time"`
+	CurrentTime   int64                  `json:"current_time"`
+	Changes       *models.AssessmentDiff `json:"changes"`
+}
+
+// CreateEventMessage encodes the change event of an assessment as a Kafka message.
+func CreateEventMessage(institutionID string, origin string, assessment *models.Assessment,
+	diff *models.AssessmentDiff) (string, error) {
+	event := Event{
+		InstitutionID: institutionID,
+		Origin:        origin,
+		Domain:        assessment.Domain,
+		Url:           assessment.Url,
+		PreviousTime:  diff.PreviousStart.Unix(),
+		CurrentTime:   diff.CurrentStart.Unix(),
+		Changes:       diff,
+	}
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
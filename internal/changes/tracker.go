@@ -0,0 +1,76 @@
+package changes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"strings"
+	"sync"
+)
+
+// Tracker remembers the last assessment of every domain and compares each new assessment with it.
+// When a repository is given, the last assessment is the latest one it stores, so the history
+// survives restarts; otherwise the assessments are kept in memory.
+type Tracker struct {
+	repository storage.Repository
+	latest     map[string]*models.Assessment
+	mu         sync.Mutex
+}
+
+// NewTracker creates a Tracker. repository may be nil, in which case the last assessment of each
+// domain is kept in memory.
+func NewTracker(repository storage.Repository) *Tracker {
+	return &Tracker{repository: repository, latest: make(map[string]*models.Assessment)}
+}
+
+// Track compares an assessment with the last one of its domain and returns the differences, or
+// nil when the domain was not assessed before. With a repository, Track must be called before
+// the assessment is saved.
+func (t *Tracker) Track(assessment *models.Assessment) (*models.AssessmentDiff, error) {
+	previous, err := t.previous(assessment)
+	if err != nil || previous == nil {
+		return nil, err
+	}
+	return analysis.DiffAssessments(previous, assessment), nil
+}
+
+func (t *Tracker) previous(assessment *models.Assessment) (*models.Assessment, error) {
+	if t.repository != nil {
+		record, err := t.repository.Latest(assessment.Domain)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return record.Assessment, nil
+	}
+	// The assessment is kept as a copy, as the repository does, so that later changes to the
+	// records it points to cannot alter it.
+	kept, err := copyAssessment(assessment)
+	if err != nil {
+		return nil, err
+	}
+	domain := strings.ToLower(strings.TrimSuffix(assessment.Domain, "."))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.latest[domain]
+	t.latest[domain] = kept
+	return previous, nil
+}
+
+// copyAssessment returns a deep copy of an assessment, made by encoding and decoding it.
+func copyAssessment(assessment *models.Assessment) (*models.Assessment, error) {
+	data, err := json.Marshal(assessment)
+	if err != nil {
+		return nil, fmt.Errorf("encode assessment of '%s' failed: %v", assessment.Domain, err)
+	}
+	kept := &models.Assessment{}
+	if err := json.Unmarshal(data, kept); err != nil {
+		return nil, fmt.Errorf("decode assessment of '%s' failed: %v", assessment.Domain, err)
+	}
+	return kept, nil
+}
@@ -0,0 +1,93 @@
+package changes
+
+import (
+	"encoding/json"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"path/filepath"
+	"testing"
+)
+
+func gradedAssessment(domain, grade string) *models.Assessment {
+	assessment := models.NewAssessment("https://"+domain, domain)
+	assessment.Score = &models.SecurityScore{Grade: grade}
+	return assessment
+}
+
+func TestTrackerInMemory(t *testing.T) {
+	tracker := NewTracker(nil)
+
+	if diff, err := tracker.Track(gradedAssessment("example.com", "B")); err != nil || diff != nil {
+		t.Fatalf("Expected no diff for the first assessment, got %+v, %v", diff, err)
+	}
+	diff, err := tracker.Track(gradedAssessment("Example.com.", "F"))
+	if err != nil {
+		t.Fatalf("Track: unexpected error: %v", err)
+	}
+	if diff == nil || !diff.HasChanges() || diff.PreviousGrade != "B" || diff.CurrentGrade != "F" {
+		t.Errorf("Expected a grade change, got %+v", diff)
+	}
+	if diff, _ := tracker.Track(gradedAssessment("example.com", "F")); diff == nil || diff.HasChanges() {
+		t.Errorf("Expected an unchanged rescan, got %+v", diff)
+	}
+}
+
+func TestTrackerWithRepository(t *testing.T) {
+	repository, err := storage.NewSQLiteRepository(filepath.Join(t.TempDir(), "assessments.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: unexpected error: %v", err)
+	}
+	defer repository.Close()
+	tracker := NewTracker(repository)
+
+	first := gradedAssessment("example.com", "A")
+	if diff, err := tracker.Track(first); err != nil || diff != nil {
+		t.Fatalf("Expected no diff for the first assessment, got %+v, %v", diff, err)
+	}
+	if _, err := repository.Save("institution", first); err != nil {
+		t.Fatalf("Save: unexpected error: %v", err)
+	}
+	second := gradedAssessment("example.com", "C")
+	diff, err := tracker.Track(second)
+	if err != nil || diff == nil || diff.PreviousGrade != "A" || diff.CurrentGrade != "C" {
+		t.Fatalf("Expected a grade change, got %+v, %v", diff, err)
+	}
+
+	message, err := CreateEventMessage("institution", "DNS-ASSESSMENT", second, diff)
+	if err != nil {
+		t.Fatalf("CreateEventMessage: unexpected error: %v", err)
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(message), &event); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+	if event.Domain != "example.com" || event.InstitutionID != "institution" || event.Changes.CurrentGrade != "C" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func TestTrackerInMemoryKeepsPreviousRecords(t *testing.T) {
+	tracker := NewTracker(nil)
+	// Both assessments point to the same DNSKEY response, which is filled again by the second
+	// scan, so the tracker must not keep a reference to the first one.
+	dnskey := &dnsrecords.DNSKEYResponse{}
+	first := models.NewAssessment("https://example.com", "example.com")
+	first.Records["DNSKEY"] = dnskey
+	dnskey.Records = []dnsrecords.DNSKEYRecord{{Flags: 257, Protocol: 3, Algorithm: 8, PublicKey: "AwEAAag="}}
+	if diff, err := tracker.Track(first); err != nil || diff != nil {
+		t.Fatalf("Expected no diff for the first assessment, got %+v, %v", diff, err)
+	}
+
+	second := models.NewAssessment("https://example.com", "example.com")
+	second.Records["DNSKEY"] = dnskey
+	dnskey.Records = []dnsrecords.DNSKEYRecord{{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "mdsswUyr"}}
+	diff, err := tracker.Track(second)
+	if err != nil {
+		t.Fatalf("Track: unexpected error: %v", err)
+	}
+	if diff == nil || len(diff.KeysAdded) != 1 || len(diff.KeysRemoved) != 1 ||
+		len(diff.AlgorithmsAdded) != 1 || diff.AlgorithmsAdded[0] != 13 || len(diff.AlgorithmsRemoved) != 1 || diff.AlgorithmsRemoved[0] != 8 {
+		t.Errorf("Expected the DNSKEY change to be reported, got %+v", diff)
+	}
+}
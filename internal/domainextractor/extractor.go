@@ -1,27 +1,14 @@
 package domainextractor
 
-import (
-	"errors"
-	"net/url"
-	"strings"
-)
+import "strings"
 
+// ExtractDomain returns the host name of a URL in A-label form, without a "www." prefix.
 func ExtractDomain(urlStr string) (string, error) {
-	if !strings.Contains(urlStr, "://") {
-		urlStr = "https://" + urlStr
-	}
-
-	parsedURL, err := url.Parse(urlStr)
+	hostname, err := ExtractHost(urlStr)
 	if err != nil {
 		return "", err
 	}
 
-	hostname := parsedURL.Hostname()
-
-	if !strings.Contains(hostname, ".") {
-		return "", errors.New("invalid hostname or domain missing")
-	}
-
 	if strings.HasPrefix(hostname, "www.") {
 		hostname = strings.TrimPrefix(hostname, "www.")
 	}
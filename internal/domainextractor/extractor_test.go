@@ -18,6 +18,8 @@ func TestExtractDomain(t *testing.T) {
 		{"ftp://example.com/resource", "example.com", false},
 		{"http://www.example.com:8080", "example.com", false},
 		{"https://www.example.com/path?query=string", "example.com", false},
+		{"https://www.Bücher.com", "xn--bcher-kva.com", false},
+		{"https://exa..mple.com", "", true},
 		{"http://invalid-url", "", true},
 		{"invalid-url", "", true},
 	}
@@ -0,0 +1,85 @@
+package domainextractor
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/net/idna"
+	"strings"
+)
+
+const (
+	// maxLabelLength is the longest label allowed by RFC 1035, Section 2.3.4.
+	maxLabelLength = 63
+	// maxNameLength is the longest name in presentation format, without the trailing dot, whose
+	// wire format fits in the 255 octets allowed by RFC 1035, Section 2.3.4.
+	maxNameLength = 253
+)
+
+var (
+	// ErrEmptyLabel is returned for a host name with an empty label, such as "example..com".
+	ErrEmptyLabel = errors.New("empty label")
+	// ErrLabelTooLong is returned for a host name with a label longer than 63 octets.
+	ErrLabelTooLong = errors.New("label longer than 63 octets")
+	// ErrNameTooLong is returned for a host name longer than 253 octets.
+	ErrNameTooLong = errors.New("name longer than 253 octets")
+	// ErrInvalidIDN is returned for a host name that is not valid under IDNA 2008 and UTS #46.
+	ErrInvalidIDN = errors.New("invalid internationalized domain name")
+)
+
+// HostnameError reports a host name that cannot be assessed. Err is one of the errors of this
+// package, so the reason can be checked with errors.Is.
+type HostnameError struct {
+	Hostname string
+	Err      error
+	Detail   string
+}
+
+func (e *HostnameError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("invalid hostname '%s': %v: %s", e.Hostname, e.Err, e.Detail)
+	}
+	return fmt.Sprintf("invalid hostname '%s': %v", e.Hostname, e.Err)
+}
+
+func (e *HostnameError) Unwrap() error {
+	return e.Err
+}
+
+// lookupProfile maps host names for lookup as specified by UTS #46, with the non-transitional
+// processing of IDNA 2008, so that "ß" and "ς" are kept rather than mapped to "ss" and "σ".
+var lookupProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.Transitional(false))
+
+// NormalizeHostname converts a host name to its A-label form (e.g. "xn--mnchen-3ya.de") for
+// querying, and to its U-label form (e.g. "münchen.de") for display. Both are lower-cased and
+// without a trailing dot. The A-label form must pass ValidateHostname.
+func NormalizeHostname(hostname string) (aLabel string, uLabel string, err error) {
+	name := strings.TrimSuffix(hostname, ".")
+	// ASCII names are validated first, so that they are rejected with the precise reason rather
+	// than as invalid IDNs.
+	if isASCII(name) {
+		if err := ValidateHostname(name); err != nil {
+			return "", "", err
+		}
+	}
+	aLabel, err = lookupProfile.ToASCII(name)
+	if err != nil {
+		return "", "", &HostnameError{Hostname: hostname, Err: ErrInvalidIDN, Detail: err.Error()}
+	}
+	if err := ValidateHostname(aLabel); err != nil {
+		return "", "", err
+	}
+	uLabel, err = lookupProfile.ToUnicode(aLabel)
+	if err != nil {
+		return "", "", &HostnameError{Hostname: hostname, Err: ErrInvalidIDN, Detail: err.Error()}
+	}
+	return aLabel, uLabel, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
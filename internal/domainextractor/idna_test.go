@@ -0,0 +1,58 @@
+package domainextractor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	testCases := []struct {
+		hostname string
+		aLabel   string
+		uLabel   string
+	}{
+		{"example.com", "example.com", "example.com"},
+		{"WWW.Example.COM.", "www.example.com", "www.example.com"},
+		{"uni-münchen.de", "xn--uni-mnchen-eeb.de", "uni-münchen.de"},
+		{"UNI-MÜNCHEN.de", "xn--uni-mnchen-eeb.de", "uni-münchen.de"},
+		{"xn--uni-mnchen-eeb.de", "xn--uni-mnchen-eeb.de", "uni-münchen.de"},
+		{"straße.de", "xn--strae-oqa.de", "straße.de"},
+		{"πανεπιστήμιο.gr", "xn--jxafmqbrdvbd8am.gr", "πανεπιστήμιο.gr"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hostname, func(t *testing.T) {
+			aLabel, uLabel, err := NormalizeHostname(tc.hostname)
+			if err != nil {
+				t.Fatalf("NormalizeHostname(%s): unexpected error: %v", tc.hostname, err)
+			}
+			if aLabel != tc.aLabel || uLabel != tc.uLabel {
+				t.Errorf("NormalizeHostname(%s): expected %s / %s, got %s / %s", tc.hostname, tc.aLabel, tc.uLabel, aLabel, uLabel)
+			}
+		})
+	}
+}
+
+func TestNormalizeHostnameErrors(t *testing.T) {
+	testCases := []struct {
+		hostname string
+		expected error
+	}{
+		{"example..com", ErrEmptyLabel},
+		{strings.Repeat("a", 64) + ".com", ErrLabelTooLong},
+		{strings.Repeat(strings.Repeat("a", 60)+".", 5) + "com", ErrNameTooLong},
+		{"xn--a.com", ErrInvalidIDN},
+		{"exa mple.com", ErrInvalidCharacter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hostname, func(t *testing.T) {
+			_, _, err := NormalizeHostname(tc.hostname)
+			var hostnameErr *HostnameError
+			if !errors.Is(err, tc.expected) || !errors.As(err, &hostnameErr) {
+				t.Errorf("NormalizeHostname(%s): expected %v, got %v", tc.hostname, tc.expected, err)
+			}
+		})
+	}
+}
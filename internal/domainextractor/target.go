@@ -0,0 +1,132 @@
+package domainextractor
+
+import (
+	"fmt"
+	"golang.org/x/net/publicsuffix"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ApexSourceSOA marks a zone apex found from the SOA record of the zone enclosing the host.
+	ApexSourceSOA = "soa"
+	// ApexSourcePSL marks a zone apex taken from the registrable domain of the Public Suffix List.
+	ApexSourcePSL = "psl"
+)
+
+// Target holds the names under which a URL is assessed.
+//
+// Fields:
+//
+//	Host: The host name of the URL in A-label form, lower-cased and without a trailing dot.
+//	      Address records are queried at this name.
+//
+//	UnicodeHost: The host name in U-label form, for display.
+//
+//	Apex: The apex of the zone enclosing the host, in A-label form. Zone-level records (SOA, NS,
+//	      DNSKEY, DS, ...) are queried at this name.
+//
+//	UnicodeApex: The apex in U-label form, for display.
+//
+//	RegistrableDomain: The registrable domain of the host (the public suffix plus one label),
+//	                   or empty when the host is itself a public suffix.
+//
+//	ApexSource: How the apex was found, ApexSourceSOA or ApexSourcePSL.
+type Target struct {
+	Host              string
+	UnicodeHost       string
+	Apex              string
+	UnicodeApex       string
+	RegistrableDomain string
+	ApexSource        string
+}
+
+// ZoneApexFinder finds, in the DNS, the apex of the zone that contains a name.
+type ZoneApexFinder interface {
+	ZoneApex(name string) (string, error)
+}
+
+// ExtractHost returns the host name of a URL in A-label form, lower-cased and without a trailing
+// dot. Unlike ExtractDomain, a "www." prefix is kept. A URL without a valid host name yields a
+// *HostnameError.
+func ExtractHost(urlStr string) (string, error) {
+	host, _, err := extractHost(urlStr)
+	return host, err
+}
+
+// extractHost returns the host name of a URL in A-label and U-label form.
+func extractHost(urlStr string) (string, string, error) {
+	if !strings.Contains(urlStr, "://") {
+		urlStr = "https://" + urlStr
+	}
+
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return "", "", &HostnameError{Hostname: urlStr, Err: ErrMissingDomain, Detail: err.Error()}
+	}
+
+	hostname := strings.TrimSuffix(parsedURL.Hostname(), ".")
+	if net.ParseIP(hostname) != nil {
+		return "", "", &HostnameError{Hostname: hostname, Err: ErrIPLiteral}
+	}
+	if !strings.Contains(hostname, ".") {
+		// A single label is never a domain that can be assessed; "localhost" and the other
+		// special-use names are reported as such.
+		if IsPrivateName(hostname) {
+			return "", "", &HostnameError{Hostname: hostname, Err: ErrPrivateName}
+		}
+		return "", "", &HostnameError{Hostname: hostname, Err: ErrMissingDomain}
+	}
+	return NormalizeHostname(hostname)
+}
+
+// RegistrableDomain returns the registrable domain of a host name according to the Public
+// Suffix List embedded in the program, such as "uni-example.de" for "portal.cs.uni-example.de".
+func RegistrableDomain(host string) (string, error) {
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host '%s': %v", host, err)
+	}
+	return domain, nil
+}
+
+// ExtractTarget returns the host of a URL and the apex of the zone enclosing it. The host must be
+// a valid host name, and must not be a private name unless the policy allows it; otherwise a
+// *HostnameError is returned before any query is sent. The apex is
+// looked up with the finder, which may be nil; an apex above the registrable domain, such as a
+// public suffix returned for a name that does not exist, is not trusted. When the finder gives no
+// usable apex, the registrable domain is used instead.
+func ExtractTarget(urlStr string, policy Policy, finder ZoneApexFinder) (*Target, error) {
+	host, unicodeHost, err := extractHost(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.AllowPrivateNames && IsPrivateName(host) {
+		return nil, &HostnameError{Hostname: host, Err: ErrPrivateName}
+	}
+	target := &Target{Host: host, UnicodeHost: unicodeHost}
+	target.RegistrableDomain, _ = RegistrableDomain(host)
+
+	if finder != nil {
+		if apex, err := finder.ZoneApex(host); err == nil && apex != "" {
+			apex = strings.ToLower(strings.TrimSuffix(apex, "."))
+			if isSubdomain(host, apex) && target.RegistrableDomain != "" && isSubdomain(apex, target.RegistrableDomain) {
+				target.Apex, target.ApexSource = apex, ApexSourceSOA
+				target.UnicodeApex, _ = lookupProfile.ToUnicode(apex)
+				return target, nil
+			}
+		}
+	}
+	if target.RegistrableDomain == "" {
+		return nil, &HostnameError{Hostname: host, Err: ErrMissingDomain, Detail: "no zone apex found and the host is a public suffix"}
+	}
+	target.Apex, target.ApexSource = target.RegistrableDomain, ApexSourcePSL
+	target.UnicodeApex, _ = lookupProfile.ToUnicode(target.Apex)
+	return target, nil
+}
+
+// isSubdomain reports whether name is equal to or below zone.
+func isSubdomain(name, zone string) bool {
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
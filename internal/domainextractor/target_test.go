@@ -0,0 +1,69 @@
+package domainextractor
+
+import (
+	"errors"
+	"testing"
+)
+
+type staticFinder map[string]string
+
+func (f staticFinder) ZoneApex(name string) (string, error) {
+	if apex, ok := f[name]; ok {
+		return apex, nil
+	}
+	return "", errors.New("no SOA record found")
+}
+
+func TestExtractTarget(t *testing.T) {
+	finder := staticFinder{
+		"portal.cs.uni-example.de": "cs.uni-example.de.",
+		"www.example.com":          "example.com",
+		"missing.example.org":      "org.",
+	}
+	testCases := []struct {
+		url      string
+		finder   ZoneApexFinder
+		expected Target
+	}{
+		{"https://portal.cs.uni-example.de/login", finder,
+			Target{Host: "portal.cs.uni-example.de", UnicodeHost: "portal.cs.uni-example.de", Apex: "cs.uni-example.de", UnicodeApex: "cs.uni-example.de", RegistrableDomain: "uni-example.de", ApexSource: ApexSourceSOA}},
+		{"http://WWW.Example.com.", finder,
+			Target{Host: "www.example.com", UnicodeHost: "www.example.com", Apex: "example.com", UnicodeApex: "example.com", RegistrableDomain: "example.com", ApexSource: ApexSourceSOA}},
+		{"https://missing.example.org", finder,
+			Target{Host: "missing.example.org", UnicodeHost: "missing.example.org", Apex: "example.org", UnicodeApex: "example.org", RegistrableDomain: "example.org", ApexSource: ApexSourcePSL}},
+		{"https://portal.example.co.uk", nil,
+			Target{Host: "portal.example.co.uk", UnicodeHost: "portal.example.co.uk", Apex: "example.co.uk", UnicodeApex: "example.co.uk", RegistrableDomain: "example.co.uk", ApexSource: ApexSourcePSL}},
+		{"https://www.Uni-München.de/studium", nil,
+			Target{Host: "www.xn--uni-mnchen-eeb.de", UnicodeHost: "www.uni-münchen.de", Apex: "xn--uni-mnchen-eeb.de", UnicodeApex: "uni-münchen.de", RegistrableDomain: "xn--uni-mnchen-eeb.de", ApexSource: ApexSourcePSL}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			target, err := ExtractTarget(tc.url, Policy{}, tc.finder)
+			if err != nil {
+				t.Fatalf("ExtractTarget(%s): unexpected error: %v", tc.url, err)
+			}
+			if *target != tc.expected {
+				t.Errorf("ExtractTarget(%s): expected %+v, got %+v", tc.url, tc.expected, *target)
+			}
+		})
+	}
+}
+
+func TestExtractTargetPublicSuffix(t *testing.T) {
+	_, err := ExtractTarget("https://co.uk", Policy{}, nil)
+	var hostnameErr *HostnameError
+	if !errors.As(err, &hostnameErr) || !errors.Is(err, ErrMissingDomain) {
+		t.Errorf("Expected %v for a public suffix, got %v", ErrMissingDomain, err)
+	}
+}
+
+func TestExtractTargetPrivateNames(t *testing.T) {
+	if _, err := ExtractTarget("https://intranet.uni.internal", Policy{}, nil); !errors.Is(err, ErrPrivateName) {
+		t.Errorf("Expected %v, got %v", ErrPrivateName, err)
+	}
+	target, err := ExtractTarget("https://intranet.uni.internal", Policy{AllowPrivateNames: true}, nil)
+	if err != nil || target.Apex != "uni.internal" {
+		t.Errorf("Expected the private name to be allowed, got %+v, %v", target, err)
+	}
+}
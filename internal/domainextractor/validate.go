@@ -0,0 +1,87 @@
+package domainextractor
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+var (
+	// ErrInvalidCharacter is returned for a host name with a character other than a letter, a
+	// digit or a hyphen.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrHyphen is returned for a host name with a label that begins or ends with a hyphen.
+	ErrHyphen = errors.New("label begins or ends with a hyphen")
+	// ErrNumericTLD is returned for a host name whose top-level label is all digits.
+	ErrNumericTLD = errors.New("all-numeric top-level label")
+	// ErrIPLiteral is returned for an IP address given instead of a host name.
+	ErrIPLiteral = errors.New("IP address instead of a host name")
+	// ErrPrivateName is returned for a host name under a private or special-use domain.
+	ErrPrivateName = errors.New("private or special-use name")
+	// ErrMissingDomain is returned for a URL without a host name that has a domain to assess: a
+	// URL that cannot be parsed, a single-label host or a host that is a public suffix.
+	ErrMissingDomain = errors.New("domain missing")
+)
+
+// privateDomains are the special-use domains of RFC 6761, RFC 6762, RFC 7686, RFC 8375 and
+// RFC 9476, the domain reserved by ICANN for private use, and the suffixes commonly used on
+// internal networks. Names under them cannot be assessed from the public DNS.
+var privateDomains = []string{
+	"localhost", "local", "test", "invalid", "example", "onion", "alt", "home.arpa", "internal",
+	"lan", "home", "corp", "intranet", "private",
+}
+
+// Policy relaxes the checks applied to host names.
+//
+// Fields:
+//
+//	AllowPrivateNames: Accept host names under private and special-use domains, for deployments
+//	                   whose resolver serves internal zones.
+type Policy struct {
+	AllowPrivateNames bool
+}
+
+// ValidateHostname checks that a host name follows the LDH rule of RFC 1123, Section 2.1: labels
+// of letters, digits and hyphens, neither beginning nor ending with a hyphen, at most 63 octets
+// long, in a name of at most 253 octets whose top-level label is not all digits. IP addresses are
+// rejected. Internationalized names must be given in A-label form. A trailing dot is ignored.
+func ValidateHostname(hostname string) error {
+	name := strings.TrimSuffix(hostname, ".")
+	if net.ParseIP(strings.Trim(name, "[]")) != nil {
+		return &HostnameError{Hostname: hostname, Err: ErrIPLiteral}
+	}
+	if len(name) > maxNameLength {
+		return &HostnameError{Hostname: hostname, Err: ErrNameTooLong}
+	}
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		switch {
+		case label == "":
+			return &HostnameError{Hostname: hostname, Err: ErrEmptyLabel}
+		case len(label) > maxLabelLength:
+			return &HostnameError{Hostname: hostname, Err: ErrLabelTooLong, Detail: label}
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return &HostnameError{Hostname: hostname, Err: ErrHyphen, Detail: label}
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return &HostnameError{Hostname: hostname, Err: ErrInvalidCharacter, Detail: label}
+			}
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return &HostnameError{Hostname: hostname, Err: ErrNumericTLD}
+	}
+	return nil
+}
+
+// IsPrivateName reports whether a host name is, or is under, a private or special-use domain.
+func IsPrivateName(hostname string) bool {
+	name := strings.ToLower(strings.TrimSuffix(hostname, "."))
+	for _, domain := range privateDomains {
+		if isSubdomain(name, domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package domainextractor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateHostname(t *testing.T) {
+	testCases := []struct {
+		hostname string
+		expected error
+	}{
+		{"example.com", nil},
+		{"WWW.Example.COM.", nil},
+		{"xn--uni-mnchen-eeb.de", nil},
+		{"3com.com", nil},
+		{"-v.example.com", ErrHyphen},
+		{"host-.example.com", ErrHyphen},
+		{"under_score.example.com", ErrInvalidCharacter},
+		{"semi;colon.example.com", ErrInvalidCharacter},
+		{"uni-münchen.de", ErrInvalidCharacter},
+		{"example..com", ErrEmptyLabel},
+		{strings.Repeat("a", 64) + ".com", ErrLabelTooLong},
+		{strings.Repeat(strings.Repeat("a", 60)+".", 5) + "com", ErrNameTooLong},
+		{"192.0.2.1", ErrIPLiteral},
+		{"[2001:db8::1]", ErrIPLiteral},
+		{"host.123", ErrNumericTLD},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hostname, func(t *testing.T) {
+			err := ValidateHostname(tc.hostname)
+			if !errors.Is(err, tc.expected) || (err == nil) != (tc.expected == nil) {
+				t.Errorf("ValidateHostname(%s): expected %v, got %v", tc.hostname, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestIsPrivateName(t *testing.T) {
+	for hostname, expected := range map[string]bool{
+		"localhost":            true,
+		"app.localhost.":       true,
+		"printer.local":        true,
+		"router.home.arpa":     true,
+		"intranet.uni.corp":    true,
+		"example.com":          false,
+		"localhost.example.de": false,
+		"internal.example.com": false,
+	} {
+		if IsPrivateName(hostname) != expected {
+			t.Errorf("IsPrivateName(%s): expected %v", hostname, expected)
+		}
+	}
+}
+
+func TestExtractHostRejectsInvalidHosts(t *testing.T) {
+	for url, expected := range map[string]error{
+		"https://192.0.2.1/login": ErrIPLiteral,
+		"https://[2001:db8::1]/":  ErrIPLiteral,
+		"-v.example.com":          ErrHyphen,
+		"https://a_b.example.com": ErrInvalidCharacter,
+		"http://localhost:8080/":  ErrPrivateName,
+		"https://portal":          ErrMissingDomain,
+		"https://%zz.example.com": ErrMissingDomain,
+	} {
+		if _, err := ExtractHost(url); !errors.Is(err, expected) {
+			t.Errorf("ExtractHost(%s): expected %v, got %v", url, expected, err)
+		}
+	}
+}
@@ -2,39 +2,65 @@ package groupHandler
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/IBM/sarama"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/changes"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/domainextractor"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/scanner"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
 	kmodels "github.com/jacksonbarreto/WebGateScanner-kafka/models"
 	"github.com/jacksonbarreto/WebGateScanner-kafka/producer"
+	"sync"
 	"time"
 )
 
 type AnalysisConsumerGroupHandler struct {
-	scanner     *scanner.Scanner
-	producer    producer.IProducer
-	topicResult string
-	topicError  string
-	log         logservice.Logger
+	scanner        *scanner.Scanner
+	producer       producer.IProducer
+	errorProducer  producer.IProducer
+	changeProducer producer.IProducer
+	repository     storage.Repository
+	tracker        *changes.Tracker
+	topicResult    string
+	topicError     string
+	log            logservice.Logger
+	mu             sync.Mutex
 }
 
-func NewAnalysisConsumerGroupHandler(scanner *scanner.Scanner, producer producer.IProducer, topicResult, topicError string, logService logservice.Logger) *AnalysisConsumerGroupHandler {
+// NewAnalysisConsumerGroupHandler creates the handler. repository may be nil, in which case
+// assessments are only sent to Kafka. errorProducer may be nil, in which case rejected requests
+// are only logged. changeProducer and tracker may be nil, in which case no change events are published.
+func NewAnalysisConsumerGroupHandler(scanner *scanner.Scanner, producer producer.IProducer, errorProducer producer.IProducer,
+	changeProducer producer.IProducer, repository storage.Repository, tracker *changes.Tracker, topicResult, topicError string,
+	logService logservice.Logger) *AnalysisConsumerGroupHandler {
 	return &AnalysisConsumerGroupHandler{
-		scanner:     scanner,
-		producer:    producer,
-		topicResult: topicResult,
-		topicError:  topicError,
-		log:         logService,
+		scanner:        scanner,
+		producer:       producer,
+		errorProducer:  errorProducer,
+		changeProducer: changeProducer,
+		repository:     repository,
+		tracker:        tracker,
+		topicResult:    topicResult,
+		topicError:     topicError,
+		log:            logService,
 	}
 }
 
-func NewAnalysisConsumerGroupHandlerDefault(scanner *scanner.Scanner, producer producer.IProducer) *AnalysisConsumerGroupHandler {
+func NewAnalysisConsumerGroupHandlerDefault(scanner *scanner.Scanner, producer producer.IProducer, errorProducer producer.IProducer,
+	changeProducer producer.IProducer, repository storage.Repository) *AnalysisConsumerGroupHandler {
 	kafkaConfig := config.Kafka()
 	topic := kafkaConfig.TopicProducer
 	topicError := kafkaConfig.TopicError
 	logger := logservice.NewLogServiceDefault()
-	return NewAnalysisConsumerGroupHandler(scanner, producer, topic, topicError, logger)
+	var tracker *changes.Tracker
+	if changeProducer != nil {
+		tracker = changes.NewTracker(repository)
+	}
+	return NewAnalysisConsumerGroupHandler(scanner, producer, errorProducer, changeProducer, repository, tracker, topic, topicError,
+		logger)
 }
 
 func (h *AnalysisConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
@@ -55,31 +81,108 @@ func (h *AnalysisConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroup
 			h.log.Error("Error unmarshalling message: %v", err)
 			continue
 		}
-		startTime := time.Now().Unix()
-		h.log.Info("Starting evaluation for Institution ID %s with URL %s at timestamp %d", evalRequest.InstitutionID, evalRequest.URL, startTime)
-
-		result, ScanErr := h.scanner.Scan(evalRequest.URL)
-		if ScanErr != nil {
-			h.handleError(evalRequest.URL, ScanErr)
-			continue
-		}
-		kafkaMessage, msgErr := kmodels.CreateKafkaEvaluationResponseMessage(evalRequest.InstitutionID, config.App().Id,
-			result.Start.Unix(), result.End.Unix(), result)
-		if msgErr != nil {
-			h.handleError(evalRequest.URL, ScanErr)
-			continue
-		}
-		partition, offset, producerErr := h.producer.SendMessage(kafkaMessage)
-		if producerErr != nil {
-			h.handleError(evalRequest.URL, ScanErr)
-			continue
+		if err := h.Evaluate(evalRequest); err != nil {
+			h.handleError(evalRequest.URL, err)
+			if !isPermanent(err) {
+				continue
+			}
+			// Retrying a rejected host name cannot succeed, so the request is answered with an
+			// error message and consumed.
+			h.reportError(evalRequest.URL, err)
 		}
-		h.log.Info("Message successfully sent to partition %d at offset %d", partition, offset)
 		session.MarkMessage(message, "")
 	}
 	return nil
 }
 
+// Evaluate scans the URL of a request and sends the result to the result topic, storing it and
+// publishing its changes on the way. It serves Kafka requests and scheduled rescans alike, one
+// at a time, so that the change tracker sees the assessments of a domain in the order they are stored.
+func (h *AnalysisConsumerGroupHandler) Evaluate(evalRequest kmodels.EvaluationRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	startTime := time.Now().Unix()
+	h.log.Info("Starting evaluation for Institution ID %s with URL %s at timestamp %d", evalRequest.InstitutionID, evalRequest.URL, startTime)
+
+	result, scanErr := h.scanner.Scan(evalRequest.URL)
+	if scanErr != nil {
+		return scanErr
+	}
+	h.publishChanges(evalRequest.InstitutionID, result)
+	h.store(evalRequest.InstitutionID, result)
+	kafkaMessage, msgErr := kmodels.CreateKafkaEvaluationResponseMessage(evalRequest.InstitutionID, config.App().Id,
+		result.Start.Unix(), result.End.Unix(), result)
+	if msgErr != nil {
+		return msgErr
+	}
+	partition, offset, producerErr := h.producer.SendMessage(kafkaMessage)
+	if producerErr != nil {
+		return producerErr
+	}
+	h.log.Info("Message successfully sent to partition %d at offset %d", partition, offset)
+	return nil
+}
+
+// store saves the assessment when a repository is configured. A storage failure is logged and
+// does not prevent the result from being sent.
+func (h *AnalysisConsumerGroupHandler) store(institutionID string, assessment *models.Assessment) {
+	if h.repository == nil {
+		return
+	}
+	if _, err := h.repository.Save(institutionID, assessment); err != nil {
+		h.log.Error("Error storing assessment of domain %s: %v", assessment.Domain, err)
+	}
+}
+
+// publishChanges compares the assessment with the previous one of its domain and publishes a
+// change event when they differ. Failures are logged and do not prevent the result from being sent.
+func (h *AnalysisConsumerGroupHandler) publishChanges(institutionID string, assessment *models.Assessment) {
+	if h.tracker == nil || h.changeProducer == nil {
+		return
+	}
+	diff, err := h.tracker.Track(assessment)
+	if err != nil {
+		h.log.Error("Error comparing assessment of domain %s: %v", assessment.Domain, err)
+		return
+	}
+	if diff == nil || !diff.HasChanges() {
+		return
+	}
+	message, err := changes.CreateEventMessage(institutionID, config.App().Id, assessment, diff)
+	if err != nil {
+		h.log.Error("Error encoding change event of domain %s: %v", assessment.Domain, err)
+		return
+	}
+	partition, offset, err := h.changeProducer.SendMessage(message)
+	if err != nil {
+		h.log.Error("Error sending change event of domain %s: %v", assessment.Domain, err)
+		return
+	}
+	h.log.Info("Change event of domain %s sent to partition %d at offset %d", assessment.Domain, partition, offset)
+}
+
+// reportError sends an error message for the URL to the error topic, when an error producer is configured.
+func (h *AnalysisConsumerGroupHandler) reportError(url string, err error) {
+	if h.errorProducer == nil {
+		return
+	}
+	message, msgErr := json.Marshal(kmodels.KafkaErrorMessage{Origin: config.App().Id, Url: url, Error: err.Error()})
+	if msgErr != nil {
+		h.log.Error("Error encoding error message for URL '%s': %v", url, msgErr)
+		return
+	}
+	if _, _, producerErr := h.errorProducer.SendMessage(string(message)); producerErr != nil {
+		h.log.Error("Error sending error message for URL '%s' (topic: %s): %v", url, h.topicError, producerErr)
+	}
+}
+
+// isPermanent reports whether an evaluation failed because of the request itself, such as an
+// invalid or private host name, so that retrying it cannot succeed.
+func isPermanent(err error) bool {
+	var hostnameErr *domainextractor.HostnameError
+	return errors.As(err, &hostnameErr)
+}
+
 func (h *AnalysisConsumerGroupHandler) handleError(url string, err error) {
 	h.log.Error("Error encountered for URL '%s' (topic: %s): %v", url, config.Kafka().TopicProducer, err)
 }
@@ -0,0 +1,49 @@
+package keyhistory
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+)
+
+// maxAssessments bounds the number of stored assessments a history is derived from. Domains are
+// rescanned at most a few times a day, so this covers the months a rollover takes.
+const maxAssessments = 200
+
+// Store keeps the key set history of zones between assessments.
+type Store interface {
+	// History returns the snapshots of a zone in chronological order.
+	History(zone string) ([]models.KeySetSnapshot, error)
+}
+
+// RepositoryStore is a Store that derives the key set history of a zone from the assessments of
+// a storage.Repository. Nothing is recorded besides the assessments themselves: a scan becomes
+// part of the history once its assessment is saved.
+type RepositoryStore struct {
+	repository storage.Repository
+}
+
+// NewRepositoryStore creates a RepositoryStore reading the assessments of the given repository.
+func NewRepositoryStore(repository storage.Repository) *RepositoryStore {
+	return &RepositoryStore{repository: repository}
+}
+
+// History returns the snapshots of a zone in chronological order, or none when the zone was not
+// assessed before. Consecutive assessments with the same key set state make up a single snapshot.
+func (s *RepositoryStore) History(zone string) ([]models.KeySetSnapshot, error) {
+	records, err := s.repository.History(zone, storage.Filter{Limit: maxAssessments})
+	if err != nil {
+		return nil, fmt.Errorf("read key history of '%s' failed: %v", zone, err)
+	}
+	var history []models.KeySetSnapshot
+	for i := len(records) - 1; i >= 0; i-- {
+		snapshot := analysis.NewKeySetSnapshot(records[i].Assessment, records[i].Start)
+		if last := len(history) - 1; last >= 0 && history[last].SameState(snapshot) {
+			history[last].LastSeen = snapshot.LastSeen
+			continue
+		}
+		history = append(history, snapshot)
+	}
+	return history, nil
+}
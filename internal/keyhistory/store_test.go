@@ -0,0 +1,63 @@
+package keyhistory
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) (*RepositoryStore, storage.Repository) {
+	t.Helper()
+	repository, err := storage.NewSQLiteRepository(filepath.Join(t.TempDir(), "assessments.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: unexpected error: %v", err)
+	}
+	t.Cleanup(func() { repository.Close() })
+	return NewRepositoryStore(repository), repository
+}
+
+func TestRepositoryStoreHistory(t *testing.T) {
+	store, repository := newTestStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl"}
+	for i, published := range []bool{false, false, true} {
+		assessment := models.NewAssessment("https://example.com", "example.com")
+		assessment.Start = start.Add(time.Duration(i) * time.Hour)
+		assessment.End = assessment.Start.Add(time.Second)
+		assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{key}, Validated: true}
+		if published {
+			assessment.Records["DS"] = &dnsrecords.DSResponse{
+				Records: []dnsrecords.DSRecord{{KeyTag: 2371, Algorithm: 13, DigestType: 2, Digest: "00"}}}
+		}
+		if _, err := repository.Save("example", assessment); err != nil {
+			t.Fatalf("Save: unexpected error: %v", err)
+		}
+	}
+
+	history, err := store.History("EXAMPLE.com.")
+	if err != nil {
+		t.Fatalf("History: unexpected error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 key set states, got %+v", history)
+	}
+	if !history[0].FirstSeen.Equal(start) || !history[0].LastSeen.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected the first state to span the first two assessments, got %v - %v",
+			history[0].FirstSeen, history[0].LastSeen)
+	}
+	if len(history[1].DSKeyTags) != 1 || history[1].Keys[0].PublicKey != key.PublicKey ||
+		!history[1].FirstSeen.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Unexpected second state %+v", history[1])
+	}
+}
+
+func TestRepositoryStoreUnknownZone(t *testing.T) {
+	store, _ := newTestStore(t)
+	history, err := store.History("unknown.example")
+	if err != nil || len(history) != 0 {
+		t.Errorf("Expected an empty history, got %v, %v", history, err)
+	}
+}
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"time"
+)
+
+// Client sends queries to an encrypted resolver endpoint. Implementations keep their
+// connections open between queries and are safe for concurrent use.
+type Client interface {
+	Exchange(name string, recordType string, checkingDisabled bool) (*dnsrecords.DigResponse, error)
+	Close() error
+}
+
+// NewClient creates a client for a DNS over TLS or DNS over HTTPS endpoint. The certificate of
+// the resolver is verified against the system roots and the host name of the endpoint.
+func NewClient(endpoint *Endpoint, timeout time.Duration) (Client, error) {
+	return newClient(endpoint, timeout, nil)
+}
+
+func newClient(endpoint *Endpoint, timeout time.Duration, roots *x509.CertPool) (Client, error) {
+	tlsConfig := &tls.Config{
+		ServerName: endpoint.Host,
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	}
+	switch endpoint.Scheme {
+	case SchemeTLS:
+		return newTLSClient(endpoint, timeout, tlsConfig), nil
+	case SchemeHTTPS:
+		return newHTTPSClient(endpoint, timeout, tlsConfig), nil
+	default:
+		return nil, fmt.Errorf("resolver %s does not use an encrypted transport", endpoint)
+	}
+}
+
+// newQuery builds a recursive query with the DNSSEC OK bit set, so that the resolver returns
+// signatures and the authenticated data flag.
+func newQuery(name string, recordType string, checkingDisabled bool) (*dns.Msg, error) {
+	qtype, ok := dns.StringToType[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	msg.RecursionDesired = true
+	msg.CheckingDisabled = checkingDisabled
+	msg.SetEdns0(1232, true)
+	return msg, nil
+}
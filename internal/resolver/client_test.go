@@ -0,0 +1,141 @@
+package resolver
+
+import (
+	"crypto/x509"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newDoHServer(t *testing.T, connections *int32) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		query := new(dns.Msg)
+		if err := query.Unpack(body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response := new(dns.Msg)
+		response.SetReply(query)
+		response.RecursionAvailable = true
+		response.AuthenticatedData = !query.CheckingDisabled
+		rr, _ := dns.NewRR("example.com. 3600 IN A 192.0.2.1")
+		response.Answer = append(response.Answer, rr)
+		packed, _ := response.Pack()
+		w.Header().Set("Content-Type", dnsMessageType)
+		w.Write(packed)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(connections, 1)
+		}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPSClientReusesConnection(t *testing.T) {
+	var connections int32
+	server := newDoHServer(t, &connections)
+	endpoint, err := ParseEndpoint(server.URL + "/dns-query")
+	if err != nil {
+		t.Fatalf("Failed to parse endpoint: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	client, err := newClient(endpoint, time.Second, roots)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		response, err := client.Exchange("example.com", "A", false)
+		if err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+		if response.Status != "NOERROR" || !response.HasFlag("ad") || len(response.Answer) != 1 {
+			t.Fatalf("Unexpected response %s", response)
+		}
+	}
+	if connections != 1 {
+		t.Errorf("Expected a single connection to be reused, got %d", connections)
+	}
+}
+
+func TestHTTPSClientVerifiesCertificate(t *testing.T) {
+	var connections int32
+	server := newDoHServer(t, &connections)
+	endpoint, err := ParseEndpoint(server.URL + "/dns-query")
+	if err != nil {
+		t.Fatalf("Failed to parse endpoint: %v", err)
+	}
+	client, err := newClient(endpoint, time.Second, x509.NewCertPool())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Exchange("example.com", "A", false); err == nil {
+		t.Errorf("Expected an untrusted certificate to be rejected")
+	}
+}
+
+func TestDelvOutput(t *testing.T) {
+	positive := DelvOutput("example.com", "A", &dnsrecords.DigResponse{
+		Status: "NOERROR",
+		Flags:  []string{"qr", "rd", "ra", "ad"},
+		Answer: []string{"example.com.\t3600\tIN\tA\t192.0.2.1"},
+	})
+	result, err := (&dnsrecords.AResponse{}).Parse(positive)
+	if err != nil {
+		t.Fatalf("Failed to parse rendered answer: %v", err)
+	}
+	if a := result.(*dnsrecords.AResponse); !a.Validated || len(a.Records) != 1 || a.Records[0].IPv4 != "192.0.2.1" {
+		t.Errorf("Unexpected parsed answer %+v", a)
+	}
+
+	negative := DelvOutput("missing.example.com", "A", &dnsrecords.DigResponse{
+		Status:    "NXDOMAIN",
+		Flags:     []string{"qr", "rd", "ra", "ad"},
+		Authority: []string{"example.com.\t3600\tIN\tNSEC\texample.com. A NS SOA RRSIG NSEC DNSKEY"},
+	})
+	denial, err := (&dnsrecords.DenialResponse{}).Parse(negative)
+	if err != nil {
+		t.Fatalf("Failed to parse rendered denial: %v", err)
+	}
+	if d := denial.(*dnsrecords.DenialResponse); !d.NXDomain || !d.Validated || d.QueryName != "missing.example.com" || len(d.NSECRecords) != 1 {
+		t.Errorf("Unexpected parsed denial %+v", d)
+	}
+
+	if failed := DelvOutput("example.com", "A", &dnsrecords.DigResponse{Status: "SERVFAIL"}); !strings.HasPrefix(failed, ";; resolution failed: SERVFAIL") {
+		t.Errorf("Unexpected rendered failure %q", failed)
+	}
+}
+
+func TestToDigResponseExtendedErrors(t *testing.T) {
+	msg := new(dns.Msg)
+	msg.SetQuestion("dnssec-failed.org.", dns.TypeA)
+	msg.Response = true
+	msg.Rcode = dns.RcodeServerFailure
+	msg.SetEdns0(1232, true)
+	opt := msg.IsEdns0()
+	opt.Option = append(opt.Option, &dns.EDNS0_EDE{InfoCode: dns.ExtendedErrorCodeDNSBogus, ExtraText: "validation failure"})
+
+	response := toDigResponse(msg)
+	if response.Status != "SERVFAIL" || len(response.Additional) != 0 {
+		t.Fatalf("Unexpected response %s", response)
+	}
+	if len(response.ExtendedErrors) != 1 || response.ExtendedErrors[0].Name != "DNSSEC Bogus" ||
+		response.ExtendedErrors[0].Text != "validation failure" {
+		t.Errorf("Unexpected extended errors %+v", response.ExtendedErrors)
+	}
+}
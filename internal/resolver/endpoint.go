@@ -0,0 +1,82 @@
+package resolver
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	SchemePlain = "udp"
+	SchemeTLS   = "tls"
+	SchemeHTTPS = "https"
+)
+
+// Endpoint is the parsed address of a resolver. Plain addresses (e.g. "1.1.1.1") are queried
+// with delv and dig over port 53; "tls://host:853" endpoints use DNS over TLS (RFC 7858) and
+// "https://host/dns-query" endpoints use DNS over HTTPS (RFC 8484).
+type Endpoint struct {
+	Scheme string
+	Host   string
+	Port   string
+	URL    string
+}
+
+func ParseEndpoint(address string) (*Endpoint, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, fmt.Errorf("invalid resolver address: the address is empty")
+	}
+	if !strings.Contains(address, "://") {
+		return &Endpoint{Scheme: SchemePlain, Host: address, Port: "53"}, nil
+	}
+
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolver address '%s': %v", address, err)
+	}
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("invalid resolver address '%s': the host is missing", address)
+	}
+	switch parsed.Scheme {
+	case SchemeTLS:
+		port := parsed.Port()
+		if port == "" {
+			port = "853"
+		}
+		return &Endpoint{Scheme: SchemeTLS, Host: parsed.Hostname(), Port: port}, nil
+	case SchemeHTTPS:
+		if parsed.Path == "" || parsed.Path == "/" {
+			parsed.Path = "/dns-query"
+		}
+		port := parsed.Port()
+		if port == "" {
+			port = "443"
+		}
+		return &Endpoint{Scheme: SchemeHTTPS, Host: parsed.Hostname(), Port: port, URL: parsed.String()}, nil
+	default:
+		return nil, fmt.Errorf("invalid resolver address '%s': the scheme must be either 'tls' or 'https'", address)
+	}
+}
+
+// Encrypted reports whether queries to the endpoint are sent over TLS or HTTPS.
+func (e *Endpoint) Encrypted() bool {
+	return e.Scheme == SchemeTLS || e.Scheme == SchemeHTTPS
+}
+
+// Address returns the host and port to connect to.
+func (e *Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, e.Port)
+}
+
+func (e *Endpoint) String() string {
+	switch e.Scheme {
+	case SchemeTLS:
+		return "tls://" + e.Address()
+	case SchemeHTTPS:
+		return e.URL
+	default:
+		return e.Host
+	}
+}
@@ -0,0 +1,38 @@
+package resolver
+
+import (
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	testCases := []struct {
+		address     string
+		expected    string
+		scheme      string
+		expectError bool
+	}{
+		{"1.1.1.1", "1.1.1.1", SchemePlain, false},
+		{"tls://dns.quad9.net", "tls://dns.quad9.net:853", SchemeTLS, false},
+		{"tls://1.1.1.1:8853", "tls://1.1.1.1:8853", SchemeTLS, false},
+		{"https://cloudflare-dns.com/dns-query", "https://cloudflare-dns.com/dns-query", SchemeHTTPS, false},
+		{"https://dns.google", "https://dns.google/dns-query", SchemeHTTPS, false},
+		{"quic://dns.adguard.com", "", "", true},
+		{"tls://", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			endpoint, err := ParseEndpoint(tc.address)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("ParseEndpoint(%s): unexpected error status: %v", tc.address, err)
+			}
+			if err != nil {
+				return
+			}
+			if endpoint.String() != tc.expected || endpoint.Scheme != tc.scheme {
+				t.Errorf("ParseEndpoint(%s) = %s (%s), expected %s (%s)", tc.address, endpoint, endpoint.Scheme, tc.expected, tc.scheme)
+			}
+		})
+	}
+}
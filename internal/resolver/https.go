@@ -0,0 +1,79 @@
+package resolver
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"io"
+	"net/http"
+	"time"
+)
+
+const dnsMessageType = "application/dns-message"
+
+// httpsClient sends queries as DNS over HTTPS POST requests. The underlying HTTP transport
+// keeps the connection to the resolver alive between queries.
+type httpsClient struct {
+	endpoint *Endpoint
+	client   *http.Client
+}
+
+func newHTTPSClient(endpoint *Endpoint, timeout time.Duration, tlsConfig *tls.Config) *httpsClient {
+	return &httpsClient{
+		endpoint: endpoint,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig:     tlsConfig,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConnsPerHost: 2,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}
+}
+
+func (c *httpsClient) Exchange(name string, recordType string, checkingDisabled bool) (*dnsrecords.DigResponse, error) {
+	query, err := newQuery(name, recordType, checkingDisabled)
+	if err != nil {
+		return nil, err
+	}
+	// RFC 8484 recommends a zero message ID so that responses can be cached by HTTP caches.
+	query.Id = 0
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, c.endpoint.URL, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", dnsMessageType)
+	request.Header.Set("Accept", dnsMessageType)
+	httpResponse, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver %s answered HTTP status %d", c.endpoint, httpResponse.StatusCode)
+	}
+	response := new(dns.Msg)
+	if err := response.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid DNS message from resolver %s: %v", c.endpoint, err)
+	}
+	return toDigResponse(response), nil
+}
+
+func (c *httpsClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,103 @@
+package resolver
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"strings"
+)
+
+// toDigResponse converts a DNS message into the DigResponse form used for the responses of dig,
+// so that encrypted and plain queries are handled alike. DNSKEY records carry the same key type,
+// algorithm and key id comments that dig and delv print, and Extended DNS Error options are kept.
+func toDigResponse(msg *dns.Msg) *dnsrecords.DigResponse {
+	response := &dnsrecords.DigResponse{
+		Status:      dns.RcodeToString[msg.Rcode],
+		RawResponse: msg.String(),
+	}
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"qr", msg.Response},
+		{"aa", msg.Authoritative},
+		{"tc", msg.Truncated},
+		{"rd", msg.RecursionDesired},
+		{"ra", msg.RecursionAvailable},
+		{"ad", msg.AuthenticatedData},
+		{"cd", msg.CheckingDisabled},
+	} {
+		if flag.set {
+			response.Flags = append(response.Flags, flag.name)
+		}
+	}
+	response.Answer = recordLines(msg.Answer)
+	response.Authority = recordLines(msg.Ns)
+	for _, rr := range msg.Extra {
+		if rr.Header().Rrtype != dns.TypeOPT {
+			response.Additional = append(response.Additional, recordLine(rr))
+		}
+	}
+	if opt := msg.IsEdns0(); opt != nil {
+		for _, option := range opt.Option {
+			if ede, ok := option.(*dns.EDNS0_EDE); ok {
+				response.ExtendedErrors = append(response.ExtendedErrors, dnsrecords.NewExtendedDNSError(ede.InfoCode, ede.ExtraText))
+			}
+		}
+	}
+	return response
+}
+
+func recordLines(rrs []dns.RR) []string {
+	var lines []string
+	for _, rr := range rrs {
+		lines = append(lines, recordLine(rr))
+	}
+	return lines
+}
+
+func recordLine(rr dns.RR) string {
+	key, ok := rr.(*dns.DNSKEY)
+	if !ok {
+		return rr.String()
+	}
+	keyType := "ZSK"
+	if key.Flags&dns.SEP != 0 {
+		keyType = "KSK"
+	}
+	return fmt.Sprintf("%s ; %s; alg = %s ; key id = %d", rr.String(), keyType, dns.AlgorithmToString[key.Algorithm], key.KeyTag())
+}
+
+// DelvOutput renders the response of an encrypted resolver in the format printed by delv, so
+// that the record parsers can be used unchanged. delv validates answers itself, whereas here
+// the validation status is taken from the authenticated data flag set by the resolver; this
+// flag can be trusted because the channel to the resolver is authenticated by its certificate
+// (RFC 4035, Section 4.9.3).
+func DelvOutput(name string, recordType string, response *dnsrecords.DigResponse) string {
+	validation := "unsigned answer"
+	if response.HasFlag("ad") {
+		validation = "fully validated"
+	}
+
+	var lines []string
+	switch {
+	case response.Status == "NXDOMAIN" || (response.Status == "NOERROR" && len(response.Answer) == 0):
+		reason := "nxrrset"
+		marker := ";-$NXRRSET"
+		if response.Status == "NXDOMAIN" {
+			reason, marker = "nxdomain", ";-$NXDOMAIN"
+		}
+		lines = append(lines, ";; resolution failed: ncache "+reason,
+			"; negative response, "+validation,
+			fmt.Sprintf("; %s 0 IN \\-%s %s", dns.Fqdn(name), recordType, marker))
+		for _, line := range response.Authority {
+			lines = append(lines, "; "+line)
+		}
+	case response.Status != "NOERROR":
+		lines = append(lines, ";; resolution failed: "+response.Status)
+	default:
+		lines = append(lines, "; "+validation)
+		lines = append(lines, response.Answer...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"crypto/tls"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"sync"
+	"time"
+)
+
+// tlsClient sends queries over a single DNS over TLS connection, which is dialled on the first
+// query and dialled again only after it fails.
+type tlsClient struct {
+	mu       sync.Mutex
+	endpoint *Endpoint
+	client   *dns.Client
+	conn     *dns.Conn
+}
+
+func newTLSClient(endpoint *Endpoint, timeout time.Duration, tlsConfig *tls.Config) *tlsClient {
+	return &tlsClient{
+		endpoint: endpoint,
+		client:   &dns.Client{Net: "tcp-tls", Timeout: timeout, TLSConfig: tlsConfig},
+	}
+}
+
+func (c *tlsClient) Exchange(name string, recordType string, checkingDisabled bool) (*dnsrecords.DigResponse, error) {
+	query, err := newQuery(name, recordType, checkingDisabled)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reused := c.conn != nil
+	response, err := c.exchange(query)
+	if err != nil && reused {
+		// The resolver may have closed an idle connection; retry once on a new one.
+		response, err = c.exchange(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDigResponse(response), nil
+}
+
+func (c *tlsClient) exchange(query *dns.Msg) (*dns.Msg, error) {
+	if c.conn == nil {
+		conn, err := c.client.Dial(c.endpoint.Address())
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+	}
+	response, _, err := c.client.ExchangeWithConn(query, c.conn)
+	if err != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+	return response, err
+}
+
+func (c *tlsClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
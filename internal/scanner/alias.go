@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// scanAliasChain follows the CNAME and DNAME records met while resolving the addresses of the
+// host, and classifies every hop on its own, since each may belong to a different zone. It
+// returns nil when the host is not an alias.
+func (s *Scanner) scanAliasChain(assessment *models.Assessment, logger logservice.Logger) *models.AliasChainReport {
+	var aliases []dnsrecords.AliasRecord
+	var last models.AliasHop
+	if a, ok := assessment.Records["A"].(*dnsrecords.AResponse); ok && len(a.Aliases) > 0 {
+		aliases, last = a.Aliases, models.AliasHop{Type: "A", Signed: a.RRSIG != nil}
+	} else if aaaa, ok := assessment.Records["AAAA"].(*dnsrecords.AAAAResponse); ok && len(aaaa.Aliases) > 0 {
+		aliases, last = aaaa.Aliases, models.AliasHop{Type: "AAAA", Signed: aaaa.RRSIG != nil}
+	} else {
+		return nil
+	}
+
+	var hops []models.AliasHop
+	for _, alias := range aliases {
+		hops = append(hops, models.AliasHop{Name: alias.Owner, Type: alias.Type, Target: alias.Target, Signed: alias.RRSIG != nil})
+	}
+	last.Name = aliases[len(aliases)-1].Target
+	hops = append(hops, last)
+
+	for i := range hops {
+		logger.Info("Scanning alias chain hop %s %s of host %s", hops[i].Name, hops[i].Type, assessment.Host)
+		if zone, err := s.ZoneApex(hops[i].Name); err == nil {
+			hops[i].Zone = zone
+		} else {
+			logger.Warn("Zone of alias %s not found: %v", hops[i].Name, err)
+		}
+		answer, _ := s.query(hops[i].Name, hops[i].Type)
+		hops[i].Status = s.validationVerdict(hops[i].Name, hops[i].Type, answer)
+	}
+	return analysis.AnalyzeAliasChain(assessment.Host, hops)
+}
+
+// validationVerdict classifies the answer of the configured validator for a record set, as delv
+// printed it, so that the verdict follows the configured trust anchors. Only a failed answer is
+// queried again, with checking disabled, to tell bogus data apart from resolution failures.
+func (s *Scanner) validationVerdict(name string, recordType string, answer string) string {
+	if !analysis.ValidatorFailed(answer) {
+		return analysis.ClassifyValidatorAnswer(answer, nil)
+	}
+	checkingDisabled, err := s.resolve(s.dnsServer, name, recordType, true)
+	if err != nil {
+		checkingDisabled = nil
+	}
+	return analysis.ClassifyValidatorAnswer(answer, checkingDisabled)
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"sort"
+	"strings"
+)
+
+// scanAuthoritativeServers queries every address of every name server of the domain directly
+// for the SOA and DNSKEY records and their signatures. The name servers are taken from the apex
+// NS records, or from the parent referral when the apex could not be resolved.
+func (s *Scanner) scanAuthoritativeServers(assessment *models.Assessment, logger logservice.Logger) *models.AuthoritativeReport {
+	if !s.authoritative.Enabled {
+		return nil
+	}
+	var nameServers []string
+	if ns, ok := assessment.Records["NS"].(*dnsrecords.NSResponse); ok {
+		nameServers = ns.NameServers()
+	}
+	if len(nameServers) == 0 && assessment.Delegation != nil {
+		nameServers = assessment.Delegation.ParentNS
+	}
+
+	var servers []models.AuthoritativeServer
+	for _, ns := range nameServers {
+		for _, address := range s.resolveAddresses(ns) {
+			logger.Info("Querying authoritative server %s (%s) for domain %s", ns, address, assessment.Domain)
+			servers = append(servers, s.queryAuthoritativeServer(assessment.Domain, ns, address))
+		}
+	}
+	return analysis.AnalyzeAuthoritativeServers(servers)
+}
+
+func (s *Scanner) queryAuthoritativeServer(domain, nameServer, address string) models.AuthoritativeServer {
+	server := models.AuthoritativeServer{NameServer: nameServer, Address: address}
+
+	soaAnswer, err := s.authoritativeAnswer(address, domain, "SOA")
+	if err != nil {
+		server.Error = err.Error()
+		return server
+	}
+	soaResult, err := (&dnsrecords.SOARecord{}).Parse(soaAnswer)
+	if err != nil {
+		server.Error = err.Error()
+		return server
+	}
+	soa := soaResult.(*dnsrecords.SOARecord)
+	server.SOASerial = soa.Serial
+	if len(soa.RRSIGs) > 0 {
+		server.SOASigned = true
+		server.SOASignatures = analysis.SignatureInceptions(soa.RRSIGs)
+	}
+
+	dnskeyAnswer, err := s.authoritativeAnswer(address, domain, "DNSKEY")
+	if err != nil {
+		server.Error = err.Error()
+		return server
+	}
+	if len(strings.TrimSpace(dnskeyAnswer)) > 0 {
+		dnskeyResult, err := (&dnsrecords.DNSKEYResponse{}).Parse(dnskeyAnswer)
+		if err != nil {
+			server.Error = err.Error()
+			return server
+		}
+		dnskey := dnskeyResult.(*dnsrecords.DNSKEYResponse)
+		for _, key := range dnskey.Records {
+			server.DNSKEYKeyIDs = append(server.DNSKEYKeyIDs, key.KeyID)
+		}
+		sort.Slice(server.DNSKEYKeyIDs, func(i, j int) bool { return server.DNSKEYKeyIDs[i] < server.DNSKEYKeyIDs[j] })
+		if len(dnskey.RRSIGs) > 0 {
+			server.DNSKEYSigned = true
+			server.DNSKEYSignatures = analysis.SignatureInceptions(dnskey.RRSIGs)
+		}
+	}
+
+	server.Responded = true
+	return server
+}
+
+// authoritativeAnswer queries a server for the record type with the DNSSEC OK bit set and
+// returns its answer section, in the same line format delv prints, so that the regular record
+// parsers can be used. Answers that are not authoritative are rejected.
+func (s *Scanner) authoritativeAnswer(address, domain, recordType string) (string, error) {
+	response, err := s.dig(address, domain, recordType, "+norec", "+dnssec", "+rrcomments")
+	if err != nil {
+		return "", err
+	}
+	if response.Status != "NOERROR" {
+		return "", fmt.Errorf("%s query answered %s", recordType, response.Status)
+	}
+	if !response.HasFlag("aa") {
+		return "", fmt.Errorf("%s answer is not authoritative", recordType)
+	}
+	return strings.Join(response.Answer, "\n"), nil
+}
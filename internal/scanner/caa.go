@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// scanCAA queries the CAA records of the host and climbs towards the top-level domain as RFC 8659
+// prescribes, until a name with CAA records is found. It returns nil when the CAA records of the
+// host could not be collected.
+func (s *Scanner) scanCAA(assessment *models.Assessment, logger logservice.Logger) *models.CAAReport {
+	result, ok := s.collectRecord(assessment, assessment.Host, "CAA", logger)
+	if !ok {
+		return nil
+	}
+	hostCAA := result.(*dnsrecords.CAAResponse)
+	observations := []analysis.CAAObservation{{Name: assessment.Host, CAA: hostCAA}}
+	relevant, answer := assessment.Host, hostCAA.RawResponse
+	for name := parentName(assessment.Host); len(hostCAA.Records) == 0 && name != ""; name = parentName(name) {
+		logger.Info("Scanning CAA records of %s for host %s", name, assessment.Host)
+		out, cmdErr := s.query(name, "CAA")
+		if out == "" {
+			logger.Warn("CAA query for %s failed: %v", name, cmdErr)
+			break
+		}
+		result, err := (&dnsrecords.CAAResponse{}).Parse(out)
+		if err != nil {
+			logger.Warn("CAA response for %s could not be parsed: %v", name, err)
+			break
+		}
+		caa := result.(*dnsrecords.CAAResponse)
+		observations = append(observations, analysis.CAAObservation{Name: name, CAA: caa})
+		if len(caa.Records) > 0 {
+			relevant, answer = name, out
+			break
+		}
+	}
+	return analysis.AnalyzeCAA(assessment.Host, observations, s.validationVerdict(relevant, "CAA", answer))
+}
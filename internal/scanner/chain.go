@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// scanChain evaluates every zone cut from the top-level domain down to the host: names that own
+// a SOA record are zone apexes, and for each of them the DS and DNSKEY record sets are queried
+// through the validating resolver.
+func (s *Scanner) scanChain(assessment *models.Assessment, logger logservice.Logger) *models.ChainReport {
+	if !s.chain.Enabled {
+		return nil
+	}
+	labels := strings.Split(assessment.Host, ".")
+	var observations []analysis.ChainObservation
+	for i := len(labels) - 1; i >= 0; i-- {
+		zone := strings.Join(labels[i:], ".")
+		apex, err := s.isZoneApex(zone)
+		if err != nil {
+			logger.Warn("SOA query for %s failed: %v", zone, err)
+			continue
+		}
+		if !apex {
+			continue
+		}
+		logger.Info("Scanning chain of trust link %s", zone)
+		observation := analysis.ChainObservation{Zone: zone}
+		if out, cmdErr := s.query(zone, "DS"); out != "" {
+			if result, err := (&dnsrecords.DSResponse{}).Parse(out); err == nil {
+				observation.DS = result.(*dnsrecords.DSResponse)
+			}
+		} else {
+			logger.Warn("DS query for %s failed: %v", zone, cmdErr)
+		}
+		if out, cmdErr := s.query(zone, "DNSKEY"); out != "" {
+			if result, err := (&dnsrecords.DNSKEYResponse{}).Parse(out); err == nil {
+				observation.DNSKEY = result.(*dnsrecords.DNSKEYResponse)
+			}
+		} else {
+			logger.Warn("DNSKEY query for %s failed: %v", zone, cmdErr)
+		}
+		observations = append(observations, observation)
+	}
+	return analysis.AnalyzeChain(assessment.Host, assessment.Domain, observations)
+}
@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"testing"
+)
+
+func TestScanChainFindsBogusZoneCuts(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"com SOA":             {"com. 900 IN SOA a.gtld-servers.net. nstld.verisign-grs.com. 1 1800 900 604800 86400"},
+		"example.com SOA":     {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"sub.example.com SOA": {"sub.example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"sub.example.com DS":  {"sub.example.com. 3600 IN DS 2371 13 2 C988EC423E3880EB8DD8A46E2A2E33C8F7C4B5A0E5EE7B7A9F7C5E0C3E1A2B3C"},
+	})
+	scanner.chain = config.ChainConfig{Enabled: true}
+	scanner.clients[testResolver].(*zoneClient).status = map[string]string{
+		"sub.example.com SOA":    "SERVFAIL",
+		"sub.example.com DNSKEY": "SERVFAIL",
+	}
+	assessment := models.NewAssessment("https://www.sub.example.com", "sub.example.com")
+	assessment.Host = "www.sub.example.com"
+
+	report := scanner.scanChain(assessment, logservice.NewLogServiceDefault())
+
+	var zones []string
+	for _, link := range report.Links {
+		zones = append(zones, link.Zone)
+	}
+	if len(zones) != 3 || zones[2] != "sub.example.com" {
+		t.Fatalf("Expected the zone cuts of com, example.com and the bogus sub.example.com, got %v", zones)
+	}
+	if link := report.Links[2]; !link.DSPresent || link.DNSKEYPresent {
+		t.Errorf("Expected the DS records of the bogus zone without a validated DNSKEY set, got %+v", link)
+	}
+}
@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// scanDANE queries the TLSA records of the HTTPS endpoint of the host and of the SMTP endpoint of
+// every mail exchanger of the domain, and optionally compares them with the certificates the
+// services present.
+func (s *Scanner) scanDANE(assessment *models.Assessment, logger logservice.Logger) *models.DANEReport {
+	if !s.dane.Enabled {
+		return nil
+	}
+	endpoints := []models.TLSAEndpoint{s.scanTLSA(assessment.Host, 443, "https", logger)}
+	for _, exchange := range s.mailExchangers(assessment, logger) {
+		endpoints = append(endpoints, s.scanTLSA(exchange, 25, "smtp", logger))
+	}
+	return analysis.AnalyzeDANE(endpoints)
+}
+
+// scanTLSA queries the TLSA records of one service and classifies the answer.
+func (s *Scanner) scanTLSA(host string, port int, service string, logger logservice.Logger) models.TLSAEndpoint {
+	name := fmt.Sprintf("_%d._tcp.%s", port, host)
+	endpoint := models.TLSAEndpoint{Name: name, Host: host, Port: port, Service: service}
+	logger.Info("Scanning TLSA records of %s", name)
+	out, cmdErr := s.query(name, "TLSA")
+	if out != "" {
+		if result, err := (&dnsrecords.TLSAResponse{}).Parse(out); err == nil {
+			endpoint.Records = result.(*dnsrecords.TLSAResponse).Records
+		} else {
+			logger.Warn("TLSA response for %s could not be parsed: %v", name, err)
+		}
+	} else {
+		logger.Warn("TLSA query for %s failed: %v", name, cmdErr)
+	}
+	endpoint.Status = s.validationVerdict(name, "TLSA", out)
+	if s.dane.CompareCertificates && len(endpoint.Records) > 0 {
+		endpoint.Certificate = s.checkCertificate(endpoint)
+	}
+	return endpoint
+}
+
+// mailExchangers returns the host names of the mail exchangers of a domain, leaving out the null
+// MX record of domains that accept no mail. The MX records are collected for the mail report as
+// well, so the domain is queried once.
+func (s *Scanner) mailExchangers(assessment *models.Assessment, logger logservice.Logger) []string {
+	result, ok := s.collectRecord(assessment, assessment.Domain, "MX", logger)
+	if !ok {
+		return nil
+	}
+	return result.(*dnsrecords.MXResponse).Exchangers()
+}
+
+// checkCertificate retrieves the certificate chain presented by a service and matches the TLSA
+// records of the endpoint against it.
+func (s *Scanner) checkCertificate(endpoint models.TLSAEndpoint) *models.TLSACertificateCheck {
+	chain, err := s.presentedChain(endpoint)
+	if err != nil {
+		return &models.TLSACertificateCheck{Error: err.Error()}
+	}
+	for _, record := range endpoint.Records {
+		if matched, err := analysis.MatchTLSA(record, chain); err == nil && matched {
+			return &models.TLSACertificateCheck{Matched: true}
+		}
+	}
+	return &models.TLSACertificateCheck{}
+}
+
+// presentedChain connects to a service and returns the certificate chain it presents, using
+// STARTTLS for SMTP. The chain is not verified, since matching it is the purpose of DANE.
+func (s *Scanner) presentedChain(endpoint models.TLSAEndpoint) ([]*x509.Certificate, error) {
+	timeout := time.Duration(s.dane.TimeoutSeconds) * time.Second
+	address := net.JoinHostPort(endpoint.Host, strconv.Itoa(endpoint.Port))
+	tlsConfig := &tls.Config{ServerName: endpoint.Host, InsecureSkipVerify: true}
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if endpoint.Service != "smtp" {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+		return tlsConn.ConnectionState().PeerCertificates, nil
+	}
+	client, err := smtp.NewClient(conn, endpoint.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	if err := client.StartTLS(tlsConfig); err != nil {
+		return nil, err
+	}
+	state, _ := client.TLSConnectionState()
+	return state.PeerCertificates, nil
+}
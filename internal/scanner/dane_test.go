@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"testing"
+)
+
+func TestScanDANEMailExchangers(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com MX": {"example.com. 3600 IN MX 10 mail.example.com.", "example.com. 3600 IN MX 20 backup.example.net."},
+		"_25._tcp.mail.example.com TLSA": {"_25._tcp.mail.example.com. 3600 IN TLSA 3 1 1 " +
+			"0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6"},
+	})
+	scanner.dane = config.DANEConfig{Enabled: true}
+	assessment := models.NewAssessment("https://example.com", "example.com")
+
+	report := scanner.scanDANE(assessment, logservice.NewLogServiceDefault())
+
+	if len(report.Endpoints) != 3 {
+		t.Fatalf("Expected the HTTPS endpoint and one SMTP endpoint per exchanger, got %+v", report.Endpoints)
+	}
+	if smtp := report.Endpoints[1]; smtp.Host != "mail.example.com" || smtp.Service != "smtp" || len(smtp.Records) != 1 {
+		t.Errorf("Unexpected SMTP endpoint %+v", smtp)
+	}
+	if _, found := assessment.Records["MX"]; !found {
+		t.Errorf("Expected the MX records to be kept for the mail report")
+	}
+	if queries := scanner.clients[testResolver].(*zoneClient).queries["example.com MX"]; queries != 1 {
+		t.Errorf("Expected a single MX query, got %d", queries)
+	}
+	scanner.scanMail(assessment, logservice.NewLogServiceDefault())
+	if queries := scanner.clients[testResolver].(*zoneClient).queries["example.com MX"]; queries != 1 {
+		t.Errorf("Expected the mail report to reuse the MX records, got %d queries", queries)
+	}
+}
@@ -0,0 +1,188 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"sort"
+	"strings"
+)
+
+// scanDelegation compares the referral returned by the servers of the parent zone with the
+// NS records at the apex of the domain, and probes every address of every name server to find
+// lame delegations. The parent and the name servers are queried directly, without recursion. It
+// returns nil unless the delegation scan is enabled.
+func (s *Scanner) scanDelegation(assessment *models.Assessment, logger logservice.Logger) *models.DelegationReport {
+	if !s.delegation.Enabled {
+		return nil
+	}
+	domain := assessment.Domain
+	observation := analysis.DelegationObservation{
+		Zone:      domain,
+		Addresses: make(map[string][]string),
+	}
+	if ns, ok := assessment.Records["NS"].(*dnsrecords.NSResponse); ok {
+		observation.ChildNS = ns.NameServers()
+	}
+
+	parent, err := s.parentZone(domain)
+	if err != nil {
+		logger.Warn("Could not find the parent zone of %s: %v", domain, err)
+	} else {
+		observation.ParentZone = parent
+		logger.Info("Scanning delegation of %s at parent zone %s", domain, parent)
+		observation.ParentNS, observation.Glue = s.parentReferral(domain, parent, logger)
+	}
+
+	for _, ns := range append(append([]string{}, observation.ParentNS...), observation.ChildNS...) {
+		if _, resolved := observation.Addresses[ns]; resolved {
+			continue
+		}
+		var addresses []string
+		if inBailiwick(ns, domain) && len(observation.Glue) > 0 {
+			addresses = s.childAddresses(ns, observation.Glue, logger)
+		} else {
+			addresses = s.resolveAddresses(ns)
+		}
+		observation.Addresses[ns] = addresses
+		for _, address := range addresses {
+			observation.Probes = append(observation.Probes, s.probeNameServer(domain, ns, address))
+		}
+	}
+	return analysis.AnalyzeDelegation(observation)
+}
+
+// parentReferral asks the servers of the parent zone for the NS records of the domain and
+// returns the delegated name servers together with the glue addresses of the referral.
+func (s *Scanner) parentReferral(domain, parent string, logger logservice.Logger) ([]string, map[string][]string) {
+	out, cmdErr := s.query(parent, "NS")
+	if out == "" {
+		logger.Warn("NS query for parent zone %s failed: %v", parent, cmdErr)
+		return nil, nil
+	}
+	result, parseErr := (&dnsrecords.NSResponse{}).Parse(out)
+	if parseErr != nil {
+		logger.Warn("Could not parse NS response for parent zone %s: %v", parent, parseErr)
+		return nil, nil
+	}
+
+	for _, server := range result.(*dnsrecords.NSResponse).NameServers() {
+		for _, address := range s.resolveAddresses(server) {
+			response, err := s.dig(address, domain, "NS", "+norec")
+			if err != nil {
+				logger.Debug("Parent server %s (%s) did not answer for %s: %v", server, address, domain, err)
+				continue
+			}
+			records := append(dnsrecords.RecordsOfType(response.Authority, "NS"),
+				dnsrecords.RecordsOfType(response.Answer, "NS")...)
+			if len(records) == 0 {
+				continue
+			}
+			var nameServers []string
+			for _, record := range records {
+				nameServers = append(nameServers, hostName(record[4]))
+			}
+			glue := make(map[string][]string)
+			for _, recordType := range []string{"A", "AAAA"} {
+				for _, record := range dnsrecords.RecordsOfType(response.Additional, recordType) {
+					glue[hostName(record[0])] = append(glue[hostName(record[0])], record[4])
+				}
+			}
+			return nameServers, glue
+		}
+	}
+	logger.Warn("No server of parent zone %s returned a referral for %s", parent, domain)
+	return nil, nil
+}
+
+// resolveAddresses returns the IPv4 and IPv6 addresses of a host name.
+func (s *Scanner) resolveAddresses(name string) []string {
+	var addresses []string
+	if out, _ := s.query(name, "A"); out != "" {
+		if result, err := (&dnsrecords.AResponse{}).Parse(out); err == nil {
+			for _, record := range result.(*dnsrecords.AResponse).Records {
+				addresses = append(addresses, record.IPv4)
+			}
+		}
+	}
+	if out, _ := s.query(name, "AAAA"); out != "" {
+		if result, err := (&dnsrecords.AAAAResponse{}).Parse(out); err == nil {
+			for _, record := range result.(*dnsrecords.AAAAResponse).Records {
+				addresses = append(addresses, record.IPv6)
+			}
+		}
+	}
+	return addresses
+}
+
+// childAddresses asks the name servers of the zone, reached through the glue of the referral, for
+// the addresses of one of its own name servers. A recursive resolution of an in-bailiwick name
+// depends on the very delegation being checked, so only authoritative answers are taken.
+func (s *Scanner) childAddresses(nameServer string, glue map[string][]string, logger logservice.Logger) []string {
+	var servers []string
+	for _, addresses := range glue {
+		servers = append(servers, addresses...)
+	}
+	sort.Strings(servers)
+	for _, server := range servers {
+		var addresses []string
+		authoritative := true
+		for _, recordType := range []string{"A", "AAAA"} {
+			response, err := s.dig(server, nameServer, recordType, "+norec")
+			if err != nil || response.Status != "NOERROR" || !response.HasFlag("aa") {
+				logger.Debug("Name server %s did not answer authoritatively for %s %s", server, nameServer, recordType)
+				authoritative = false
+				break
+			}
+			for _, record := range dnsrecords.RecordsOfType(response.Answer, recordType) {
+				if hostName(record[0]) == hostName(nameServer) {
+					addresses = append(addresses, record[4])
+				}
+			}
+		}
+		if authoritative {
+			return addresses
+		}
+	}
+	return nil
+}
+
+// probeNameServer asks a single name server address for the SOA record of the domain. A server
+// that does not answer, refuses, or answers without authority is lame for the domain.
+func (s *Scanner) probeNameServer(domain, nameServer, address string) models.NameServerCheck {
+	check := models.NameServerCheck{NameServer: nameServer, Address: address}
+	response, err := s.dig(address, domain, "SOA", "+norec")
+	switch {
+	case err != nil:
+		check.Lame, check.Reason = true, err.Error()
+	case response.Status != "NOERROR":
+		check.Lame, check.Reason = true, "the server answered "+response.Status
+	case !response.HasFlag("aa"):
+		check.Lame, check.Reason = true, "the answer is not authoritative"
+	case len(dnsrecords.RecordsOfType(response.Answer, "SOA")) == 0:
+		check.Lame, check.Reason = true, "the answer does not contain the SOA record"
+	}
+	return check
+}
+
+// parentZone returns the zone that delegates the domain: the zone containing the name above it,
+// found with the SOA walk of ZoneApex, since a parent can be several labels up (example.ac.uk
+// is delegated by uk when ac.uk is not a zone of its own). The parent of a top-level domain is the root.
+func (s *Scanner) parentZone(domain string) (string, error) {
+	parent := parentName(hostName(domain))
+	if parent == "" {
+		return ".", nil
+	}
+	return s.ZoneApex(parent)
+}
+
+// inBailiwick reports whether a name is the apex of the zone or a name below it.
+func inBailiwick(name, zone string) bool {
+	name, zone = hostName(name), hostName(zone)
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+func hostName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"testing"
+)
+
+func TestParentZone(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"uk SOA":         {"uk. 3600 IN SOA nsa.nic.uk. hostmaster.nominet.org.uk. 1 900 300 2419200 10800"},
+		"example.co SOA": {"example.co. 3600 IN SOA ns1.example.co. hostmaster.example.co. 1 7200 3600 1209600 3600"},
+	})
+	scanner.clients[testResolver].(*zoneClient).authority = map[string][]string{
+		"ac.uk SOA": {"uk. 3600 IN SOA nsa.nic.uk. hostmaster.nominet.org.uk. 1 900 300 2419200 10800"},
+	}
+
+	for domain, expected := range map[string]string{
+		"example.ac.uk":      "uk",
+		"www.example.co":     "example.co",
+		"uk":                 ".",
+		"EXAMPLE.AC.UK.":     "uk",
+		"sub.www.example.co": "example.co",
+	} {
+		parent, err := scanner.parentZone(domain)
+		if err != nil {
+			t.Errorf("parentZone(%s): unexpected error: %v", domain, err)
+			continue
+		}
+		if parent != expected {
+			t.Errorf("parentZone(%s): expected %s, got %s", domain, expected, parent)
+		}
+	}
+}
+
+func TestScanDelegationDisabled(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{})
+	assessment := models.NewAssessment("https://example.com", "example.com")
+	if report := scanner.scanDelegation(assessment, logservice.NewLogServiceDefault()); report != nil {
+		t.Errorf("Expected no delegation report when the delegation scan is disabled, got %+v", report)
+	}
+	if queries := len(scanner.clients[testResolver].(*zoneClient).queries); queries != 0 {
+		t.Errorf("Expected no query, got %d", queries)
+	}
+}
+
+func TestInBailiwick(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"ns1.example.com.":   true,
+		"EXAMPLE.COM":        true,
+		"ns1.example.net":    false,
+		"ns1.badexample.com": false,
+	} {
+		if inBailiwick(name, "example.com") != expected {
+			t.Errorf("inBailiwick(%s, example.com): expected %v", name, expected)
+		}
+	}
+}
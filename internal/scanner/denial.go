@@ -0,0 +1,42 @@
+package scanner
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// scanDenialOfExistence queries a random name below the domain, which is expected not to exist,
+// and evaluates the negative proof returned by the zone. delv reports a failed resolution for
+// such names, so its exit status is ignored as long as it produced output.
+func (s *Scanner) scanDenialOfExistence(domain string, logger logservice.Logger) *models.DenialOfExistenceReport {
+	probe, err := probeName(domain)
+	if err != nil {
+		logger.Warn("Could not generate denial of existence probe for domain %s: %v", domain, err)
+		return analysis.AnalyzeDenialOfExistence(domain, nil)
+	}
+	logger.Info("Scanning denial of existence for domain %s with probe %s", domain, probe)
+	out, cmdErr := s.query(probe, "A")
+	if out == "" {
+		logger.Warn("Denial of existence query for %s failed: %v", probe, cmdErr)
+		return analysis.AnalyzeDenialOfExistence(probe, nil)
+	}
+	result, parseErr := (&dnsrecords.DenialResponse{}).Parse(out)
+	if parseErr != nil {
+		logger.Warn("Could not parse denial of existence response for %s: %v", probe, parseErr)
+		return analysis.AnalyzeDenialOfExistence(probe, nil)
+	}
+	return analysis.AnalyzeDenialOfExistence(probe, result.(*dnsrecords.DenialResponse))
+}
+
+func probeName(domain string) (string, error) {
+	random := make([]byte, 6)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("dnssec-probe-%s.%s", hex.EncodeToString(random), domain), nil
+}
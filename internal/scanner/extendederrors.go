@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/resolver"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// queryWithExtendedErrors sends a query like query and also returns the Extended DNS Errors
+// (RFC 8914) of its answer. An encrypted resolver is asked once and the errors are taken from its
+// response. delv does not print them, so the query is repeated with dig only when delv did not
+// get a clean NOERROR answer, as resolvers attach them to failed lookups.
+func (s *Scanner) queryWithExtendedErrors(domain string, recordType string) (string, []dnsrecords.ExtendedDNSError, error) {
+	client, err := s.client(s.dnsServer)
+	if err != nil {
+		return "", nil, err
+	}
+	if client != nil {
+		response, err := client.Exchange(domain, recordType, false)
+		if err != nil {
+			return "", nil, err
+		}
+		return resolver.DelvOutput(domain, recordType, response), response.ExtendedErrors, nil
+	}
+
+	out, cmdErr := s.query(domain, recordType)
+	if (cmdErr != nil && out == "") || isNoErrorAnswer(out) {
+		return out, nil, cmdErr
+	}
+	response, err := s.resolve(s.dnsServer, domain, recordType, false)
+	if err != nil {
+		return out, nil, cmdErr
+	}
+	return out, response.ExtendedErrors, cmdErr
+}
+
+// isNoErrorAnswer reports whether delv got a NOERROR answer: records, or a proof that the name
+// holds none of the queried type. delv reports NXDOMAIN and failed lookups as resolution failures.
+func isNoErrorAnswer(out string) bool {
+	return !strings.Contains(out, "resolution failed") || strings.Contains(out, "ncache nxrrset")
+}
+
+// withExtendedErrors adds the Extended DNS Errors of a failed query to its error, so that the
+// reason of the failure is kept with it.
+func withExtendedErrors(err error, extendedErrors []dnsrecords.ExtendedDNSError) error {
+	if len(extendedErrors) == 0 {
+		return err
+	}
+	descriptions := make([]string, len(extendedErrors))
+	for i, ede := range extendedErrors {
+		descriptions[i] = ede.String()
+	}
+	return fmt.Errorf("%w [%s]", err, strings.Join(descriptions, "; "))
+}
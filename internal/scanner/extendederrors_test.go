@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+func TestScanTakesExtendedErrorsFromTheAnswer(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com SOA":    {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"example.com A":      {"example.com. 3600 IN A 192.0.2.1"},
+		"example.com DNSKEY": {"example.com. 3600 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="},
+	})
+	client := scanner.clients[testResolver].(*zoneClient)
+	client.extendedErrors = map[string][]dnsrecords.ExtendedDNSError{
+		"example.com CDS": {dnsrecords.NewExtendedDNSError(9, "no matching key")},
+	}
+
+	assessment, err := scanner.Scan("https://example.com")
+	if err != nil {
+		t.Fatalf("Scan: unexpected error: %v", err)
+	}
+	for _, recordType := range []string{"CDS", "CDNSKEY"} {
+		if queries := client.queries["example.com "+recordType]; queries != 1 {
+			t.Errorf("Expected a single %s query, got %d", recordType, queries)
+		}
+	}
+	if errors := assessment.ExtendedErrors["CDS"]; len(errors) != 1 || errors[0].Code != 9 {
+		t.Errorf("Expected the extended error of the CDS answer, got %+v", assessment.ExtendedErrors)
+	}
+	if _, found := assessment.ExtendedErrors["CDNSKEY"]; found {
+		t.Errorf("Unexpected extended errors for CDNSKEY: %+v", assessment.ExtendedErrors["CDNSKEY"])
+	}
+}
+
+func TestScanRecordsFailedRecordTypes(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com SOA":    {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"example.com DNSKEY": {"example.com. 3600 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="},
+	})
+	client := scanner.clients[testResolver].(*zoneClient)
+	client.status = map[string]string{"example.com A": "SERVFAIL"}
+	client.extendedErrors = map[string][]dnsrecords.ExtendedDNSError{
+		"example.com A": {dnsrecords.NewExtendedDNSError(6, "validation failure")},
+	}
+
+	assessment, err := scanner.Scan("https://example.com")
+	if err != nil {
+		t.Fatalf("Scan: unexpected error: %v", err)
+	}
+	if _, found := assessment.Records["A"]; found {
+		t.Errorf("Expected no A record, got %+v", assessment.Records["A"])
+	}
+	if !strings.Contains(assessment.RecordErrors["A"], "DNSSEC Bogus") {
+		t.Errorf("Expected the A failure with its extended error, got %q", assessment.RecordErrors["A"])
+	}
+	if errors := assessment.ExtendedErrors["A"]; len(errors) != 1 || errors[0].Code != 6 {
+		t.Errorf("Expected the extended error of the A answer, got %+v", assessment.ExtendedErrors)
+	}
+	if _, found := assessment.Records["DNSKEY"]; !found || len(assessment.RecordErrors) != 1 {
+		t.Errorf("Expected the other record types to be scanned, got records %v and errors %v",
+			assessment.Records, assessment.RecordErrors)
+	}
+}
+
+func TestIsNoErrorAnswer(t *testing.T) {
+	for out, expected := range map[string]bool{
+		"; fully validated\nexample.com. 3600 IN A 192.0.2.1": true,
+		";; resolution failed: ncache nxrrset":                true,
+		";; resolution failed: ncache nxdomain":               false,
+		";; resolution failed: SERVFAIL":                      false,
+	} {
+		if isNoErrorAnswer(out) != expected {
+			t.Errorf("isNoErrorAnswer(%q): expected %v", out, expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// mailPolicies are the kinds of mail policies looked up for the domain, in report order.
+var mailPolicies = []string{analysis.MailPolicySPF, analysis.MailPolicyDMARC, analysis.MailPolicyMTASTS, analysis.MailPolicyTLSRPT}
+
+// scanMail queries the MX and TXT records of the domain and the names of the DMARC, MTA-STS and
+// TLS-RPT policies, classifying the DNSSEC status of every answer. It returns nil when the MX
+// records of the domain could not be collected.
+func (s *Scanner) scanMail(assessment *models.Assessment, logger logservice.Logger) *models.MailReport {
+	domain := assessment.Domain
+	result, ok := s.collectRecord(assessment, domain, "MX", logger)
+	if !ok {
+		return nil
+	}
+	mx := result.(*dnsrecords.MXResponse)
+	s.collectRecord(assessment, domain, "TXT", logger)
+	var observations []analysis.MailObservation
+	for _, kind := range mailPolicies {
+		name := analysis.MailPolicyName(kind, domain)
+		observation := analysis.MailObservation{Kind: kind, Name: name}
+		var answer string
+		if txt, ok := assessment.Records["TXT"].(*dnsrecords.TXTResponse); ok && name == domain {
+			observation.TXT, answer = txt, txt.RawResponse
+		} else {
+			logger.Info("Scanning %s policy of domain %s at %s", kind, domain, name)
+			var cmdErr error
+			if answer, cmdErr = s.query(name, "TXT"); answer != "" {
+				if result, err := (&dnsrecords.TXTResponse{}).Parse(answer); err == nil {
+					observation.TXT = result.(*dnsrecords.TXTResponse)
+				} else {
+					logger.Warn("TXT response for %s could not be parsed: %v", name, err)
+				}
+			} else {
+				logger.Warn("TXT query for %s failed: %v", name, cmdErr)
+			}
+		}
+		observation.Status = s.validationVerdict(name, "TXT", answer)
+		observations = append(observations, observation)
+	}
+	return analysis.AnalyzeMail(domain, mx, s.validationVerdict(domain, "MX", mx.RawResponse), observations)
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+var comparisonRecordTypes = []string{"SOA", "DNSKEY", "DS", "A"}
+
+// scanResolvers asks each configured resolver for the main record types of the domain and
+// compares the validation verdicts they return with the verdict of the configured validator.
+// Unlike delv, which validates locally, these queries rely on the validation performed by each
+// resolver. Resolvers may be plain addresses or DNS over TLS and DNS over HTTPS endpoints.
+func (s *Scanner) scanResolvers(domain string, logger logservice.Logger) *models.ResolverComparisonReport {
+	if len(s.resolvers) == 0 {
+		return nil
+	}
+	verdicts := []models.ResolverVerdict{s.validatorVerdict(domain, logger)}
+	for _, resolver := range s.resolvers {
+		logger.Info("Comparing validation of domain %s at resolver %s", domain, resolver)
+		verdict := newResolverVerdict(resolver)
+		for _, recordType := range comparisonRecordTypes {
+			response, err := s.resolve(resolver, domain, recordType, false)
+			if err != nil {
+				verdict.Errors[recordType] = err.Error()
+				verdict.RecordVerdicts[recordType] = analysis.ClassifyResolverAnswer(nil, nil)
+				continue
+			}
+			if len(response.ExtendedErrors) > 0 {
+				verdict.ExtendedErrors[recordType] = response.ExtendedErrors
+			}
+			if response.Status != "SERVFAIL" {
+				verdict.RecordVerdicts[recordType] = analysis.ClassifyResolverAnswer(response, nil)
+				continue
+			}
+			checkingDisabled, err := s.resolve(resolver, domain, recordType, true)
+			if err != nil {
+				verdict.Errors[recordType] = err.Error()
+				checkingDisabled = nil
+			}
+			verdict.RecordVerdicts[recordType] = analysis.ClassifyResolverAnswer(response, checkingDisabled)
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	report := analysis.CompareResolvers(verdicts)
+	for _, disagreement := range report.Disagreements {
+		logger.Warn("Resolvers disagree on domain %s: %s", domain, disagreement)
+	}
+	return report
+}
+
+// validatorVerdict classifies the answers of the configured validator for the compared record
+// types, so that the resolvers are compared with the verdict the assessment relies on.
+func (s *Scanner) validatorVerdict(domain string, logger logservice.Logger) models.ResolverVerdict {
+	logger.Info("Comparing validation of domain %s at validator %s", domain, s.dnsServer)
+	verdict := newResolverVerdict(s.dnsServer)
+	verdict.Validator = true
+	for _, recordType := range comparisonRecordTypes {
+		out, extendedErrors, err := s.queryWithExtendedErrors(domain, recordType)
+		if err != nil && out == "" {
+			verdict.Errors[recordType] = err.Error()
+		}
+		if len(extendedErrors) > 0 {
+			verdict.ExtendedErrors[recordType] = extendedErrors
+		}
+		verdict.RecordVerdicts[recordType] = s.validationVerdict(domain, recordType, out)
+	}
+	return verdict
+}
+
+func newResolverVerdict(resolver string) models.ResolverVerdict {
+	return models.ResolverVerdict{
+		Resolver:       resolver,
+		RecordVerdicts: make(map[string]string),
+		Errors:         make(map[string]string),
+		ExtendedErrors: make(map[string][]dnsrecords.ExtendedDNSError),
+	}
+}
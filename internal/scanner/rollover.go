@@ -0,0 +1,27 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+)
+
+// scanRollovers compares the key set of the assessment with the key sets of earlier assessments
+// of the domain. The assessment joins the history once it is stored. History errors are logged
+// and leave the report out, since they do not affect the rest of the assessment.
+func (s *Scanner) scanRollovers(assessment *models.Assessment, logger logservice.Logger) *models.RolloverReport {
+	if s.keyHistory == nil {
+		return nil
+	}
+	snapshot := analysis.NewKeySetSnapshot(assessment, assessment.Start)
+	history, err := s.keyHistory.History(snapshot.Zone)
+	if err != nil {
+		logger.Error("Reading key history of domain %s failed: %v", assessment.Domain, err)
+		return nil
+	}
+	report := analysis.AnalyzeRollovers(history, snapshot, s.stuckAfter)
+	for _, finding := range report.Findings {
+		logger.Warn("Key rollover of domain %s: %s", assessment.Domain, finding)
+	}
+	return report
+}
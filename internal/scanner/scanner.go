@@ -2,69 +2,245 @@ package scanner
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/domainextractor"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/keyhistory"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/resolver"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/trustanchor"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/zonemd"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 )
 
 type Scanner struct {
-	parsers     map[string]dnsrecords.DNSRecordParser
-	dnsServerIP string
+	recordTypes   []string
+	dnsServer     string
+	resolvers     []string
+	scorer        *analysis.Scorer
+	zoneWalk      config.ZoneWalkConfig
+	authoritative config.AuthoritativeConfig
+	delegation    config.DelegationConfig
+	trustAnchors  *trustanchor.Set
+	keyHistory    keyhistory.Store
+	stuckAfter    time.Duration
+	hostnames     domainextractor.Policy
+	chain         config.ChainConfig
+	dane          config.DANEConfig
+	zonemd        *zonemd.Verifier
+	clients       map[string]resolver.Client
+	clientsMu     sync.Mutex
 }
 
-func NewScannerDefault() *Scanner {
-	parsers := map[string]dnsrecords.DNSRecordParser{
-		"DNSKEY":     &dnsrecords.DNSKEYResponse{},
-		"DS":         &dnsrecords.DSResponse{},
-		"SOA":        &dnsrecords.SOARecord{},
-		"AAAA":       &dnsrecords.AAAAResponse{},
-		"A":          &dnsrecords.AResponse{},
-		"NSEC":       &dnsrecords.NSECRecord{},
-		"NSEC3PARAM": &dnsrecords.NSEC3PARAMRecord{},
-	}
+// NewScannerDefault creates the Scanner described by the configuration. repository may be nil
+// when storage is disabled; key rollovers are then not tracked.
+func NewScannerDefault(repository storage.Repository) *Scanner {
+	recordTypes := []string{"DNSKEY", "DS", "SOA", "AAAA", "A", "NSEC", "NSEC3PARAM", "CDS", "CDNSKEY", "NS"}
 	dnsServer := config.App().DNSServer
-	return NewScanner(dnsServer, parsers)
+	var history keyhistory.Store
+	if config.KeyHistory().Enabled && repository != nil {
+		history = keyhistory.NewRepositoryStore(repository)
+	}
+	stuckAfter := time.Duration(config.KeyHistory().StuckAfterDays) * 24 * time.Hour
+	hostnames := domainextractor.Policy{AllowPrivateNames: config.Hostnames().AllowPrivateNames}
+	var verifier *zonemd.Verifier
+	if config.ZONEMD().Verify {
+		verifier = zonemd.NewVerifier(*config.ZONEMD())
+	}
+	return NewScanner(dnsServer, config.App().Resolvers, recordTypes, analysis.NewScorerDefault(), *config.ZoneWalk(), *config.Authoritative(),
+		*config.Delegation(), trustanchor.NewSetDefault(), history, stuckAfter, hostnames, *config.Chain(), *config.DANE(), verifier)
 }
 
-func NewScanner(dnsServerIP string, parsers map[string]dnsrecords.DNSRecordParser) *Scanner {
+// NewScanner creates a Scanner that queries the given record types. A fresh parser is created for
+// every record type on every scan, so a Scanner can be used by several goroutines. keyHistory may be nil, in which case key rollovers are not tracked.
+// zonemdVerifier may be nil, in which case zone digests are not verified.
+// Host names are validated against the hostnames policy before any query is sent.
+func NewScanner(dnsServer string, resolvers []string, recordTypes []string, scorer *analysis.Scorer,
+	zoneWalk config.ZoneWalkConfig, authoritative config.AuthoritativeConfig, delegation config.DelegationConfig, trustAnchors *trustanchor.Set,
+	keyHistory keyhistory.Store, stuckAfter time.Duration, hostnames domainextractor.Policy, chain config.ChainConfig,
+	dane config.DANEConfig, zonemdVerifier *zonemd.Verifier) *Scanner {
 	return &Scanner{
-		parsers:     parsers,
-		dnsServerIP: fmt.Sprintf("@%s", dnsServerIP),
+		recordTypes:   recordTypes,
+		dnsServer:     dnsServer,
+		resolvers:     resolvers,
+		scorer:        scorer,
+		zoneWalk:      zoneWalk,
+		authoritative: authoritative,
+		delegation:    delegation,
+		trustAnchors:  trustAnchors,
+		keyHistory:    keyHistory,
+		stuckAfter:    stuckAfter,
+		hostnames:     hostnames,
+		chain:         chain,
+		dane:          dane,
+		zonemd:        zonemdVerifier,
+		clients:       make(map[string]resolver.Client),
 	}
 }
 
+// hostTypes are the record types queried at the host rather than at the zone apex.
+var hostTypes = map[string]bool{"A": true, "AAAA": true, "CAA": true, "HTTPS": true}
+
 func (s *Scanner) Scan(url string) (*models.Assessment, error) {
-	domain, err := domainextractor.ExtractDomain(url)
+	target, err := domainextractor.ExtractTarget(url, s.hostnames, s)
 	if err != nil {
 		return nil, err
 	}
+	domain := target.Apex
 	assessment := models.NewAssessment(url, domain)
+	assessment.UnicodeDomain = target.UnicodeApex
+	assessment.Host, assessment.UnicodeHost = target.Host, target.UnicodeHost
+	assessment.ApexSource = target.ApexSource
 	logger := logservice.NewLogServiceDefault()
 	assessment.Begin()
-	for recordType, parser := range s.parsers {
-		logger.Info("Scanning %s record for domain %s with DNS server %s", recordType, domain, s.dnsServerIP)
-		cmd := exec.Command("delv", s.dnsServerIP, domain, recordType)
-		var out bytes.Buffer
-		cmd.Stdout = &out
-		cmd.Stderr = os.Stderr
-		cmdErr := cmd.Run()
-		if cmdErr != nil {
+	for _, recordType := range s.recordTypes {
+		parser, ok := dnsrecords.NewDNSRecordParser(recordType)
+		if !ok {
+			return nil, fmt.Errorf("no parser for record type %s", recordType)
+		}
+		name := domain
+		if hostTypes[recordType] {
+			name = target.Host
+		}
+		logger.Info("Scanning %s record for domain %s with DNS server %s", recordType, name, s.dnsServer)
+		out, extendedErrors, cmdErr := s.queryWithExtendedErrors(name, recordType)
+		if cmdErr != nil && out == "" && !isResolutionFailure(cmdErr) {
 			return nil, cmdErr
 		}
+		if len(extendedErrors) > 0 {
+			assessment.ExtendedErrors[recordType] = extendedErrors
+		}
 
-		result, parseErr := parser.Parse(out.String())
+		result, parseErr := parser.Parse(out)
+		if cmdErr != nil && out == "" {
+			parseErr = fmt.Errorf("resolution failed: %v", cmdErr)
+		}
 		if parseErr != nil {
-			return nil, parseErr
+			// A failed answer, such as a validation failure, is part of the assessment: it is
+			// recorded with its Extended DNS Errors and the other record types are still scanned.
+			assessment.RecordErrors[recordType] = withExtendedErrors(parseErr, extendedErrors).Error()
+			logger.Warn("Could not use %s record of domain %s: %s", recordType, name, assessment.RecordErrors[recordType])
+			continue
 		}
 
 		assessment.Records[recordType] = result
 	}
+	assessment.TrustAnchor = s.scanTrustAnchor(domain, logger)
+	assessment.DenialOfExistence = s.scanDenialOfExistence(domain, logger)
+	assessment.ZoneWalk = s.scanZoneWalk(assessment, logger)
+	assessment.CDS = analysis.AnalyzeCDS(assessment)
+	assessment.KeyLinkage = analysis.AnalyzeKeyLinkage(assessment)
+	assessment.Rollover = s.scanRollovers(assessment, logger)
+	assessment.Delegation = s.scanDelegation(assessment, logger)
+	assessment.Chain = s.scanChain(assessment, logger)
+	assessment.Aliases = s.scanAliasChain(assessment, logger)
+	assessment.DANE = s.scanDANE(assessment, logger)
+	assessment.CAA = s.scanCAA(assessment, logger)
+	assessment.Mail = s.scanMail(assessment, logger)
+	assessment.ServiceBinding = s.scanServiceBinding(assessment, logger)
+	assessment.ZONEMD = s.scanZONEMD(assessment, logger)
+	assessment.Authoritative = s.scanAuthoritativeServers(assessment, logger)
+	assessment.Resolvers = s.scanResolvers(domain, logger)
 	assessment.Finish()
+	assessment.Score = s.scorer.Score(assessment)
 
 	return assessment, nil
 }
+
+// isResolutionFailure reports whether a query failed because delv could not resolve or validate
+// the answer, as for a zone that fails validation, rather than because the query could not be
+// sent. delv then exits with an error status and may print nothing.
+func isResolutionFailure(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr)
+}
+
+// collectRecord returns the records of a type needed by a single report, querying them when they
+// were not collected yet. Unlike the record types of the scanner, a failure does not fail the
+// scan: it is logged and recorded with its Extended DNS Errors, and the report is left out.
+func (s *Scanner) collectRecord(assessment *models.Assessment, name string, recordType string,
+	logger logservice.Logger) (dnsrecords.DNSRecordResult, bool) {
+	if result, ok := assessment.Records[recordType]; ok {
+		return result, true
+	}
+	parser, ok := dnsrecords.NewDNSRecordParser(recordType)
+	if !ok {
+		return nil, false
+	}
+	logger.Info("Scanning %s record for domain %s with DNS server %s", recordType, name, s.dnsServer)
+	out, extendedErrors, cmdErr := s.queryWithExtendedErrors(name, recordType)
+	if len(extendedErrors) > 0 {
+		assessment.ExtendedErrors[recordType] = extendedErrors
+	}
+	if out == "" {
+		logger.Warn("%s query for %s failed: %v", recordType, name, cmdErr)
+		return nil, false
+	}
+	result, err := parser.Parse(out)
+	if err != nil {
+		assessment.RecordErrors[recordType] = withExtendedErrors(err, extendedErrors).Error()
+		logger.Warn("Could not use %s record of domain %s: %s", recordType, name, assessment.RecordErrors[recordType])
+		return nil, false
+	}
+	assessment.Records[recordType] = result
+	return result, true
+}
+
+func (s *Scanner) query(domain string, recordType string) (string, error) {
+	client, err := s.client(s.dnsServer)
+	if err != nil {
+		return "", err
+	}
+	if client != nil {
+		response, err := client.Exchange(domain, recordType, false)
+		if err != nil {
+			return "", err
+		}
+		return resolver.DelvOutput(domain, recordType, response), nil
+	}
+
+	cmd := exec.Command("delv", delvArgs(s.dnsServer, s.trustAnchors.DelvArgs(domain), domain, recordType)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	return out.String(), err
+}
+
+// delvArgs returns the arguments of a delv query. The name and type are given with -q and -t, so
+// that names taken from DNS data are never read as options.
+func delvArgs(server string, anchorArgs []string, name string, recordType string) []string {
+	args := append([]string{"@" + server}, anchorArgs...)
+	return append(args, "-q", name, "-t", recordType)
+}
+
+// digArgs returns the arguments of a dig query, with the name and type given with -q and -t as
+// in delvArgs.
+func digArgs(server string, name string, recordType string, options ...string) []string {
+	return append([]string{"@" + server, "-q", name, "-t", recordType, "+time=2", "+tries=1"}, options...)
+}
+
+// dig sends a single query to the given server address, without validating the answer. dig
+// exits with a non-zero status when the server does not answer, so the result depends on its output.
+func (s *Scanner) dig(server string, name string, recordType string, options ...string) (*dnsrecords.DigResponse, error) {
+	cmd := exec.Command("dig", digArgs(server, name, recordType, options...)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && out.Len() == 0 {
+		return nil, err
+	}
+	result, err := (&dnsrecords.DigResponse{}).Parse(out.String())
+	if err != nil {
+		return nil, err
+	}
+	return result.(*dnsrecords.DigResponse), nil
+}
@@ -0,0 +1,182 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/domainextractor"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/trustanchor"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testResolver = "https://resolver.test/dns-query"
+
+// zoneClient answers queries from fixed record sets, as an encrypted resolver that validated them,
+// unless it is unsigned. Names and types it does not hold get an empty, validated answer, with the
+// status, authority records and Extended DNS Errors given for them, if any. A name and type with
+// the SERVFAIL status fail validation: they get their records, unvalidated, only when checking is
+// disabled. It counts the queries of every name and type.
+type zoneClient struct {
+	answers        map[string][]string
+	status         map[string]string
+	authority      map[string][]string
+	extendedErrors map[string][]dnsrecords.ExtendedDNSError
+	queries        map[string]int
+	unsigned       bool
+	mu             sync.Mutex
+}
+
+func (c *zoneClient) Exchange(name string, recordType string, checkingDisabled bool) (*dnsrecords.DigResponse, error) {
+	key := strings.ToLower(strings.TrimSuffix(name, ".")) + " " + recordType
+	c.mu.Lock()
+	c.queries[key]++
+	c.mu.Unlock()
+	status := "NOERROR"
+	if c.status[key] != "" {
+		status = c.status[key]
+	}
+	flags := []string{"qr", "rd", "ra", "ad"}
+	if c.unsigned {
+		flags = flags[:3]
+	}
+	if status == "SERVFAIL" {
+		if !checkingDisabled {
+			return &dnsrecords.DigResponse{Status: status, Flags: flags[:3], ExtendedErrors: c.extendedErrors[key]}, nil
+		}
+		status, flags = "NOERROR", flags[:3]
+	}
+	return &dnsrecords.DigResponse{
+		Status:         status,
+		Flags:          flags,
+		Answer:         c.answers[key],
+		Authority:      c.authority[key],
+		ExtendedErrors: c.extendedErrors[key],
+	}, nil
+}
+
+func (c *zoneClient) Close() error {
+	return nil
+}
+
+func newTestScanner(t *testing.T, answers map[string][]string) *Scanner {
+	t.Helper()
+	trustAnchors, err := trustanchor.NewSet(config.TrustAnchorConfig{})
+	if err != nil {
+		t.Fatalf("NewSet: unexpected error: %v", err)
+	}
+	scanner := NewScanner(testResolver, nil, []string{"DNSKEY", "SOA", "A", "CDS", "CDNSKEY"},
+		analysis.NewScorer(config.ScoringConfig{}), config.ZoneWalkConfig{}, config.AuthoritativeConfig{}, config.DelegationConfig{}, trustAnchors, nil, 0,
+		domainextractor.Policy{}, config.ChainConfig{}, config.DANEConfig{}, nil)
+	scanner.clients[testResolver] = &zoneClient{answers: answers, queries: make(map[string]int)}
+	return scanner
+}
+
+func TestScanConcurrentDomains(t *testing.T) {
+	keys := map[string]string{
+		"example.com": "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==",
+		"example.org": "oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA==",
+	}
+	addresses := map[string]string{"example.com": "192.0.2.1", "example.org": "198.51.100.1"}
+	answers := make(map[string][]string)
+	for domain, key := range keys {
+		answers[domain+" SOA"] = []string{domain + ". 3600 IN SOA ns1." + domain + ". hostmaster." + domain + ". 1 7200 3600 1209600 3600"}
+		answers[domain+" DNSKEY"] = []string{domain + ". 3600 IN DNSKEY 257 3 13 " + key}
+		answers[domain+" A"] = []string{domain + ". 3600 IN A " + addresses[domain]}
+	}
+	answers["example.com CDS"] = []string{"example.com. 3600 IN CDS 0 0 0 00"}
+	scanner := newTestScanner(t, answers)
+
+	const rounds = 5
+	assessments := make(map[string][]*models.Assessment)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		for domain := range keys {
+			wg.Add(1)
+			go func(domain string) {
+				defer wg.Done()
+				assessment, err := scanner.Scan("https://" + domain)
+				if err != nil {
+					t.Errorf("Scan %s: unexpected error: %v", domain, err)
+					return
+				}
+				mu.Lock()
+				assessments[domain] = append(assessments[domain], assessment)
+				mu.Unlock()
+			}(domain)
+		}
+	}
+	wg.Wait()
+
+	for domain, results := range assessments {
+		if len(results) != rounds {
+			t.Errorf("Expected %d assessments of %s, got %d", rounds, domain, len(results))
+		}
+		for _, assessment := range results {
+			dnskey := assessment.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse)
+			if len(dnskey.Records) != 1 || dnskey.Records[0].PublicKey != keys[domain] {
+				t.Errorf("Expected the DNSKEY record of %s only, got %+v", domain, dnskey.Records)
+			}
+			a := assessment.Records["A"].(*dnsrecords.AResponse)
+			if len(a.Records) != 1 || a.Records[0].IPv4 != addresses[domain] {
+				t.Errorf("Expected the A record of %s only, got %+v", domain, a.Records)
+			}
+			cds := assessment.Records["CDS"].(*dnsrecords.CDSResponse)
+			if deleteRequest := domain == "example.com"; cds.IsDeleteRequest() != deleteRequest || len(cds.Records) > 1 {
+				t.Errorf("Unexpected CDS records for %s: %+v", domain, cds.Records)
+			}
+		}
+	}
+}
+
+func TestScanResolversComparesValidator(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com SOA": {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+	})
+	const compared = "https://compared.test/dns-query"
+	scanner.resolvers = []string{compared}
+	scanner.clients[compared] = &zoneClient{unsigned: true, queries: make(map[string]int)}
+
+	report := scanner.scanResolvers("example.com", logservice.NewLogServiceDefault())
+
+	if len(report.Resolvers) != 2 || !report.Resolvers[0].Validator || report.Resolvers[0].Resolver != testResolver {
+		t.Fatalf("Expected the validator verdict first, got %+v", report.Resolvers)
+	}
+	if report.Resolvers[0].Verdict != analysis.VerdictSecure || report.Resolvers[1].Verdict != analysis.VerdictInsecure {
+		t.Errorf("Unexpected verdicts %+v", report.Resolvers)
+	}
+	if report.Agreement || len(report.Disagreements) != len(comparisonRecordTypes) {
+		t.Errorf("Expected the unsigned answers to disagree with the validator, got %v", report.Disagreements)
+	}
+}
+
+func TestScanCollectsReportRecords(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com SOA":    {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"example.com A":      {"example.com. 3600 IN A 192.0.2.1"},
+		"example.com CAA":    {"example.com. 3600 IN CAA 0 issue \"letsencrypt.org\""},
+		"example.com HTTPS":  {"example.com. 3600 IN HTTPS 1 . alpn=\"h2\""},
+		"example.com ZONEMD": {"example.com. 3600 IN ZONEMD 1 1 1 " + strings.Repeat("00", 48)},
+	})
+	scanner.clients[testResolver].(*zoneClient).status = map[string]string{"example.com MX": "SERVFAIL"}
+
+	assessment, err := scanner.Scan("https://example.com")
+	if err != nil {
+		t.Fatalf("Scan: unexpected error: %v", err)
+	}
+	if assessment.CAA == nil || assessment.ServiceBinding == nil || assessment.ZONEMD == nil {
+		t.Errorf("Expected the CAA, service binding and ZONEMD reports, got %+v, %+v, %+v",
+			assessment.CAA, assessment.ServiceBinding, assessment.ZONEMD)
+	}
+	if assessment.Mail != nil || assessment.RecordErrors["MX"] == "" {
+		t.Errorf("Expected the failed MX query to leave the mail report out, got %+v and errors %v",
+			assessment.Mail, assessment.RecordErrors)
+	}
+	if _, found := assessment.Records["CAA"]; !found {
+		t.Errorf("Expected the CAA records to be kept with the other records")
+	}
+}
@@ -0,0 +1,20 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// scanServiceBinding queries and classifies the HTTPS records of the host. It returns nil when the
+// HTTPS records of the host could not be collected.
+func (s *Scanner) scanServiceBinding(assessment *models.Assessment, logger logservice.Logger) *models.ServiceBindingReport {
+	result, ok := s.collectRecord(assessment, assessment.Host, "HTTPS", logger)
+	if !ok {
+		return nil
+	}
+	https := result.(*dnsrecords.SVCBResponse)
+	logger.Info("Classifying HTTPS records of host %s", assessment.Host)
+	return analysis.AnalyzeServiceBinding(assessment.Host, https, s.validationVerdict(assessment.Host, "HTTPS", https.RawResponse))
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/resolver"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"time"
+)
+
+const encryptedQueryTimeout = 5 * time.Second
+
+// client returns the client of an encrypted resolver endpoint, creating it on first use so that
+// its connection is reused by later queries and scans. It returns nil for plain endpoints, which
+// are queried with delv and dig.
+func (s *Scanner) client(address string) (resolver.Client, error) {
+	endpoint, err := resolver.ParseEndpoint(address)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Encrypted() {
+		return nil, nil
+	}
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	if client, ok := s.clients[address]; ok {
+		return client, nil
+	}
+	client, err := resolver.NewClient(endpoint, encryptedQueryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[address] = client
+	return client, nil
+}
+
+// resolve sends a recursive query with the DNSSEC OK bit set to a resolver, over its encrypted
+// transport when it has one, and returns the answer without validating it.
+func (s *Scanner) resolve(address string, name string, recordType string, checkingDisabled bool) (*dnsrecords.DigResponse, error) {
+	client, err := s.client(address)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		return client.Exchange(name, recordType, checkingDisabled)
+	}
+	options := []string{"+dnssec"}
+	if checkingDisabled {
+		options = append(options, "+cd")
+	}
+	return s.dig(address, name, recordType, options...)
+}
+
+// Close releases the connections kept open to encrypted resolvers and the trust anchor file.
+func (s *Scanner) Close() {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	for address, client := range s.clients {
+		client.Close()
+		delete(s.clients, address)
+	}
+	s.trustAnchors.Close()
+}
@@ -0,0 +1,17 @@
+package scanner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueryArgsKeepNamesApartFromOptions(t *testing.T) {
+	delv := delvArgs("192.0.2.53", []string{"-a", "anchors.conf"}, "-i.example.com", "A")
+	if expected := []string{"@192.0.2.53", "-a", "anchors.conf", "-q", "-i.example.com", "-t", "A"}; !slices.Equal(delv, expected) {
+		t.Errorf("Expected delv arguments %v, got %v", expected, delv)
+	}
+	dig := digArgs("192.0.2.53", "+short.example.com", "NS", "+norec")
+	if expected := []string{"@192.0.2.53", "-q", "+short.example.com", "-t", "NS", "+time=2", "+tries=1", "+norec"}; !slices.Equal(dig, expected) {
+		t.Errorf("Expected dig arguments %v, got %v", expected, dig)
+	}
+}
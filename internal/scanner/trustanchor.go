@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/resolver"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"time"
+)
+
+// scanTrustAnchor reports the trust anchor the validation of the domain depended on, by
+// matching the configured anchors against the DNSKEY set of the closest anchored zone.
+func (s *Scanner) scanTrustAnchor(domain string, logger logservice.Logger) *models.TrustAnchorReport {
+	if nta := s.trustAnchors.NegativeAnchor(domain); nta != "" {
+		logger.Warn("Validation of domain %s is disabled by negative trust anchor %s", domain, nta)
+		return &models.TrustAnchorReport{
+			Source:         s.trustAnchors.Source(),
+			NegativeAnchor: nta,
+			Findings:       []string{fmt.Sprintf("validation is disabled by negative trust anchor %s", nta)},
+		}
+	}
+
+	zone := s.trustAnchors.Zone(domain)
+	var dnskey *dnsrecords.DNSKEYResponse
+	if out, _ := s.query(zone, "DNSKEY"); out != "" {
+		if result, err := (&dnsrecords.DNSKEYResponse{}).Parse(out); err == nil {
+			dnskey = result.(*dnsrecords.DNSKEYResponse)
+		} else {
+			logger.Warn("Could not parse DNSKEY response for trust anchor zone %s: %v", zone, err)
+		}
+	}
+	report := analysis.AnalyzeTrustAnchors(s.trustAnchors.Source(), s.trustAnchors.Anchors(), zone, dnskey, time.Now())
+	if endpoint, err := resolver.ParseEndpoint(s.dnsServer); err == nil && endpoint.Encrypted() {
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("validation is performed by resolver %s; the configured trust anchors are not applied", endpoint))
+	}
+	return report
+}
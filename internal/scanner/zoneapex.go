@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// ZoneApex finds the apex of the zone that contains a name by asking the resolver for SOA
+// records, from the name upwards. The name is the apex when the answer holds its SOA record; a
+// negative answer carries the SOA record of the enclosing zone in its authority section. A name
+// that is an alias gets the SOA record of the alias target, which is ignored. Checking is disabled,
+// so that zones failing validation are found and assessed rather than their parent.
+func (s *Scanner) ZoneApex(name string) (string, error) {
+	for candidate := hostName(name); candidate != ""; candidate = parentName(candidate) {
+		response, err := s.resolve(s.dnsServer, candidate, "SOA", true)
+		if err != nil {
+			return "", err
+		}
+		if hasSOA(response, candidate) {
+			return candidate, nil
+		}
+		for _, record := range dnsrecords.RecordsOfType(response.Authority, "SOA") {
+			if owner := hostName(record[0]); owner != "" && strings.HasSuffix(candidate, "."+owner) {
+				return owner, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no SOA record found for '%s'", name)
+}
+
+// isZoneApex reports whether a name is the apex of a zone, that is whether it owns a SOA record.
+// Checking is disabled, so that the cuts of zones that fail validation are found as well.
+func (s *Scanner) isZoneApex(name string) (bool, error) {
+	response, err := s.resolve(s.dnsServer, name, "SOA", true)
+	if err != nil {
+		return false, err
+	}
+	return hasSOA(response, name), nil
+}
+
+// hasSOA reports whether the answer of a response holds the SOA record of the name.
+func hasSOA(response *dnsrecords.DigResponse, name string) bool {
+	for _, record := range dnsrecords.RecordsOfType(response.Answer, "SOA") {
+		if hostName(record[0]) == hostName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func parentName(name string) string {
+	_, parent, _ := strings.Cut(name, ".")
+	return parent
+}
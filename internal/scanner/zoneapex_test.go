@@ -0,0 +1,28 @@
+package scanner
+
+import (
+	"testing"
+)
+
+func TestScanFindsApexOfBogusZone(t *testing.T) {
+	scanner := newTestScanner(t, map[string][]string{
+		"example.com SOA":       {"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"sub.example.com SOA":   {"sub.example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		"www.sub.example.com A": {"www.sub.example.com. 3600 IN A 192.0.2.1"},
+	})
+	client := scanner.clients[testResolver].(*zoneClient)
+	client.status = map[string]string{"sub.example.com SOA": "SERVFAIL", "www.sub.example.com A": "SERVFAIL"}
+
+	apex, err := scanner.ZoneApex("www.sub.example.com")
+	if err != nil || apex != "sub.example.com" {
+		t.Fatalf("Expected the bogus zone sub.example.com, got %q, %v", apex, err)
+	}
+	assessment, err := scanner.Scan("https://www.sub.example.com")
+	if err != nil {
+		t.Fatalf("Scan: unexpected error: %v", err)
+	}
+	if assessment.Domain != "sub.example.com" || assessment.RecordErrors["SOA"] == "" {
+		t.Errorf("Expected the failed SOA answer of sub.example.com, got domain %s and errors %v",
+			assessment.Domain, assessment.RecordErrors)
+	}
+}
@@ -0,0 +1,29 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// scanZONEMD queries and classifies the ZONEMD records of the domain and, when a verifier is
+// configured and the zone publishes a digest, verifies it against a copy of the zone. It returns
+// nil when the ZONEMD records of the domain could not be collected.
+func (s *Scanner) scanZONEMD(assessment *models.Assessment, logger logservice.Logger) *models.ZONEMDReport {
+	result, ok := s.collectRecord(assessment, assessment.Domain, "ZONEMD", logger)
+	if !ok {
+		return nil
+	}
+	zonemd := result.(*dnsrecords.ZONEMDResponse)
+	var soaSerial uint32
+	if soa, ok := assessment.Records["SOA"].(*dnsrecords.SOARecord); ok {
+		soaSerial = soa.Serial
+	}
+	var verification *models.ZONEMDVerification
+	if s.zonemd != nil && len(zonemd.Records) > 0 {
+		logger.Info("Verifying zone digest of %s", assessment.Domain)
+		verification = s.zonemd.Verify(assessment.Domain)
+	}
+	return analysis.AnalyzeZONEMD(assessment.Domain, zonemd, soaSerial, s.validationVerdict(assessment.Domain, "ZONEMD", zonemd.RawResponse), verification)
+}
@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"time"
+)
+
+// scanZoneWalk follows the NSEC chain from the apex of the domain, issuing at most
+// MaxSteps queries at the configured rate. Zones that do not use plain NSEC are skipped.
+func (s *Scanner) scanZoneWalk(assessment *models.Assessment, logger logservice.Logger) *models.ZoneWalkReport {
+	if !s.zoneWalk.Enabled {
+		return nil
+	}
+	if !usesNSEC(assessment) {
+		return analysis.SkippedZoneWalk("the zone does not use plain NSEC")
+	}
+
+	limiter := time.NewTicker(time.Duration(float64(time.Second) / s.zoneWalk.QueriesPerSecond))
+	defer limiter.Stop()
+
+	walk := analysis.NewZoneWalk(assessment.Domain, s.zoneWalk.MaxSteps)
+	name, ok := walk.Start(), true
+	for ok {
+		logger.Debug("Walking NSEC chain of %s at %s", assessment.Domain, name)
+		var record *dnsrecords.NSECRecord
+		out, cmdErr := s.query(name, "NSEC")
+		if cmdErr == nil {
+			if result, parseErr := (&dnsrecords.NSECRecord{}).Parse(out); parseErr == nil {
+				record = result.(*dnsrecords.NSECRecord)
+			}
+		}
+		name, ok = walk.Step(name, record)
+		if ok {
+			<-limiter.C
+		}
+	}
+	report := walk.Report(assessment.DenialOfExistence)
+	logger.Info("Zone walk of %s stopped after %d steps: %s", assessment.Domain, report.Steps, report.StopReason)
+	return report
+}
+
+func usesNSEC(assessment *models.Assessment) bool {
+	if nsec, ok := assessment.Records["NSEC"].(*dnsrecords.NSECRecord); ok && nsec.NextDomainName != "" {
+		return true
+	}
+	denial := assessment.DenialOfExistence
+	return denial != nil && denial.Method == analysis.DenialMethodNSEC
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	kmodels "github.com/jacksonbarreto/WebGateScanner-kafka/models"
+	"hash/fnv"
+	"time"
+)
+
+// Evaluator assesses the URL of a request and publishes the result, as done for Kafka requests.
+type Evaluator interface {
+	Evaluate(request kmodels.EvaluationRequest) error
+}
+
+// Scheduler periodically rescans the domains kept in the repository. Every tick it looks up the
+// schedule of the latest assessment of each domain and rescans the domains whose rescan time has come.
+type Scheduler struct {
+	repository storage.Repository
+	evaluator  Evaluator
+	policy     analysis.RescanPolicy
+	jitter     float64
+	tick       time.Duration
+	attempts   map[string]time.Time
+	log        logservice.Logger
+}
+
+// NewScheduler creates a Scheduler. jitter is the largest fraction of the time until a rescan by
+// which the rescan is postponed, so that domains assessed together are not rescanned together.
+func NewScheduler(repository storage.Repository, evaluator Evaluator, policy analysis.RescanPolicy, jitter float64,
+	tick time.Duration, logService logservice.Logger) *Scheduler {
+	return &Scheduler{
+		repository: repository,
+		evaluator:  evaluator,
+		policy:     policy,
+		jitter:     jitter,
+		tick:       tick,
+		attempts:   make(map[string]time.Time),
+		log:        logService,
+	}
+}
+
+func NewSchedulerDefault(repository storage.Repository, evaluator Evaluator) *Scheduler {
+	schedulerConfig := config.Scheduler()
+	policy := analysis.RescanPolicy{
+		Interval:     time.Duration(schedulerConfig.IntervalHours) * time.Hour,
+		MinInterval:  time.Duration(schedulerConfig.MinIntervalMinutes) * time.Minute,
+		ExpiryMargin: time.Duration(schedulerConfig.ExpiryMarginHours) * time.Hour,
+	}
+	tick := time.Duration(schedulerConfig.TickSeconds) * time.Second
+	return NewScheduler(repository, evaluator, policy, schedulerConfig.Jitter, tick, logservice.NewLogServiceDefault())
+}
+
+// Run rescans due domains every tick until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+	for {
+		s.RunOnce(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce rescans, one after the other, the domains that are due at the given time.
+func (s *Scheduler) RunOnce(ctx context.Context, now time.Time) {
+	schedules, err := s.repository.Schedules()
+	if err != nil {
+		s.log.Error("Error listing assessed domains: %v", err)
+		return
+	}
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			return
+		}
+		if now.Before(s.due(schedule)) {
+			continue
+		}
+		// A failed rescan leaves the latest assessment unchanged, so it is retried no sooner than
+		// the minimum interval rather than on every tick.
+		if attempt, ok := s.attempts[schedule.Domain]; ok && now.Sub(attempt) < s.policy.MinInterval {
+			continue
+		}
+		s.attempts[schedule.Domain] = now
+		s.log.Info("Rescanning domain %s, last assessed at %v", schedule.Domain, schedule.Rescan.Start)
+		request := kmodels.EvaluationRequest{InstitutionID: schedule.InstitutionID, URL: schedule.Url}
+		if err := s.evaluator.Evaluate(request); err != nil {
+			s.log.Error("Error rescanning URL '%s': %v", schedule.Url, err)
+			continue
+		}
+		delete(s.attempts, schedule.Domain)
+	}
+}
+
+// due returns when the domain of a schedule should be rescanned. The jitter is derived from the
+// domain and the assessment time, so it stays the same from one tick to the next.
+func (s *Scheduler) due(schedule storage.Schedule) time.Time {
+	next := schedule.Rescan.Next(s.policy)
+	hash := fnv.New64a()
+	hash.Write([]byte(schedule.Domain + schedule.Rescan.Start.String()))
+	fraction := float64(hash.Sum64()%1000) / 1000
+	return next.Add(time.Duration(float64(next.Sub(schedule.Rescan.Start)) * s.jitter * fraction))
+}
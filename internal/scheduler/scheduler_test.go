@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/internal/storage"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/logservice"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	kmodels "github.com/jacksonbarreto/WebGateScanner-kafka/models"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type recordingEvaluator struct {
+	requests []kmodels.EvaluationRequest
+	err      error
+}
+
+func (e *recordingEvaluator) Evaluate(request kmodels.EvaluationRequest) error {
+	e.requests = append(e.requests, request)
+	return e.err
+}
+
+func newTestScheduler(t *testing.T, evaluator Evaluator, start time.Time, domains ...string) *Scheduler {
+	t.Helper()
+	repository, err := storage.NewSQLiteRepository(filepath.Join(t.TempDir(), "assessments.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: unexpected error: %v", err)
+	}
+	t.Cleanup(func() { repository.Close() })
+	for _, domain := range domains {
+		assessment := models.NewAssessment("https://"+domain, domain)
+		assessment.Start, assessment.End = start, start
+		if _, err := repository.Save("institution", assessment); err != nil {
+			t.Fatalf("Save: unexpected error: %v", err)
+		}
+	}
+	policy := analysis.RescanPolicy{Interval: 24 * time.Hour, MinInterval: time.Hour, ExpiryMargin: 48 * time.Hour}
+	return NewScheduler(repository, evaluator, policy, 0.1, time.Minute, logservice.NewLogService("test"))
+}
+
+func TestSchedulerRescansDueDomains(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	evaluator := &recordingEvaluator{}
+	scheduler := newTestScheduler(t, evaluator, start, "example.com", "example.org")
+
+	scheduler.RunOnce(context.Background(), start.Add(23*time.Hour))
+	if len(evaluator.requests) != 0 {
+		t.Fatalf("Expected no rescans before the interval, got %v", evaluator.requests)
+	}
+
+	scheduler.RunOnce(context.Background(), start.Add(27*time.Hour))
+	if len(evaluator.requests) != 2 || evaluator.requests[0].URL != "https://example.com" ||
+		evaluator.requests[0].InstitutionID != "institution" {
+		t.Errorf("Expected both domains to be rescanned, got %v", evaluator.requests)
+	}
+}
+
+func TestSchedulerRetriesFailedRescansAfterMinInterval(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	evaluator := &recordingEvaluator{err: errors.New("scan failed")}
+	scheduler := newTestScheduler(t, evaluator, start, "example.com")
+
+	now := start.Add(27 * time.Hour)
+	scheduler.RunOnce(context.Background(), now)
+	scheduler.RunOnce(context.Background(), now.Add(time.Minute))
+	if len(evaluator.requests) != 1 {
+		t.Fatalf("Expected a single attempt within the minimum interval, got %d", len(evaluator.requests))
+	}
+	scheduler.RunOnce(context.Background(), now.Add(time.Hour))
+	if len(evaluator.requests) != 2 {
+		t.Errorf("Expected a retry after the minimum interval, got %d attempts", len(evaluator.requests))
+	}
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"log"
+	"strings"
+	"time"
+)
+
+// DriverSQLite is the name of the default storage driver.
+const DriverSQLite = "sqlite"
+
+// ErrNotFound is returned when no stored assessment matches a query.
+var ErrNotFound = errors.New("assessment not found")
+
+// Record is an assessment as kept by a Repository, together with the columns it is indexed by.
+//
+// Fields:
+//
+//	ID: The identifier assigned by the repository.
+//
+//	InstitutionID: The institution the assessment was requested for.
+//
+//	Domain: The assessed domain, lower-cased and without a trailing dot.
+//
+//	Url: The URL the domain was extracted from.
+//
+//	Status: The DNSSEC status of the assessment ("secure", "insecure" or "bogus").
+//
+//	Grade: The letter grade of the assessment, empty when it was not scored.
+//
+//	Start: The time the assessment started.
+//
+//	End: The time the assessment finished.
+//
+//	Assessment: The complete assessment.
+type Record struct {
+	ID            int64
+	InstitutionID string
+	Domain        string
+	Url           string
+	Status        string
+	Grade         string
+	Start         time.Time
+	End           time.Time
+	Assessment    *models.Assessment
+}
+
+// Schedule is what a Scheduler needs to know about the most recent assessment of a domain to
+// decide when to rescan it.
+//
+// Fields:
+//
+//	InstitutionID: The institution the assessment was requested for.
+//
+//	Domain: The assessed domain, lower-cased and without a trailing dot.
+//
+//	Url: The URL the domain was extracted from.
+//
+//	Rescan: The parts of the assessment the time of the next assessment depends on.
+type Schedule struct {
+	InstitutionID string
+	Domain        string
+	Url           string
+	Rescan        analysis.RescanBasis
+}
+
+// Filter restricts the records returned by a query. Zero values do not restrict anything.
+//
+// Fields:
+//
+//	InstitutionID: Only records of this institution.
+//
+//	Status: Only records with this DNSSEC status.
+//
+//	Since: Only records of assessments started at or after this time.
+//
+//	Until: Only records of assessments started before this time.
+//
+//	Limit: The maximum number of records to return.
+type Filter struct {
+	InstitutionID string
+	Status        string
+	Since         time.Time
+	Until         time.Time
+	Limit         int
+}
+
+// Repository stores assessments and answers queries over them.
+type Repository interface {
+	// Save stores an assessment requested for an institution and returns the stored record.
+	Save(institutionID string, assessment *models.Assessment) (*Record, error)
+	// Latest returns the most recent assessment of a domain, or ErrNotFound.
+	Latest(domain string) (*Record, error)
+	// LatestPerDomain returns the most recent assessment of every domain, ordered by domain.
+	// The filter applies to those latest assessments, so filtering by status returns the
+	// domains whose current status matches.
+	LatestPerDomain(filter Filter) ([]Record, error)
+	// Schedules returns the schedule of the most recent assessment of every domain, ordered by
+	// domain, without decoding the assessments.
+	Schedules() ([]Schedule, error)
+	// History returns the assessments of a domain from the most recent to the oldest.
+	History(domain string, filter Filter) ([]Record, error)
+	// Close releases the resources of the repository.
+	Close() error
+}
+
+// NewRepositoryDefault creates the repository described by the storage configuration. It returns
+// nil when storage is disabled and stops the program when the repository cannot be opened.
+func NewRepositoryDefault() Repository {
+	if !config.Storage().Enabled {
+		return nil
+	}
+	repository, err := NewRepository(*config.Storage())
+	if err != nil {
+		log.Fatalf("assessment storage failed: %v", err)
+	}
+	return repository
+}
+
+// NewRepository creates the repository of the configured driver.
+func NewRepository(cfg config.StorageConfig) (Repository, error) {
+	switch cfg.Driver {
+	case DriverSQLite, "":
+		repository, err := NewSQLiteRepository(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return repository, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver '%s'", cfg.Driver)
+	}
+}
+
+// normalizeDomain lower-cases a domain name and removes the trailing dot, so that the
+// spellings of a domain share one history.
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
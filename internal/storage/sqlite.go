@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/analysis"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	_ "github.com/mattn/go-sqlite3"
+	"strings"
+	"time"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS assessments (
+	id             INTEGER PRIMARY KEY AUTOINCREMENT,
+	institution_id TEXT    NOT NULL,
+	domain         TEXT    NOT NULL,
+	url            TEXT    NOT NULL,
+	status         TEXT    NOT NULL,
+	grade          TEXT    NOT NULL,
+	started_at     INTEGER NOT NULL,
+	finished_at    INTEGER NOT NULL,
+	data           BLOB    NOT NULL,
+	expires_at     INTEGER NOT NULL,
+	shortest_ttl   INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS assessments_domain_started ON assessments (domain, started_at);
+CREATE INDEX IF NOT EXISTS assessments_institution_started ON assessments (institution_id, started_at);
+CREATE INDEX IF NOT EXISTS assessments_started ON assessments (started_at);
+CREATE INDEX IF NOT EXISTS assessments_status_started ON assessments (status, started_at);
+`
+
+const recordColumns = "id, institution_id, domain, url, status, grade, started_at, finished_at, data"
+
+// SQLiteRepository is a Repository that keeps assessments in a SQLite database file. Times are
+// stored as Unix nanoseconds so that they sort and compare as integers. The earliest signature
+// expiration and the shortest TTL of each assessment are kept in their own columns, so that the
+// rescan schedule is read without decoding the assessments.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository opens, and creates if needed, the SQLite database at the given path.
+func NewSQLiteRepository(path string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("open assessment database '%s' failed: %v", path, err)
+	}
+	// SQLite serializes writers; a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create assessment database schema failed: %v", err)
+	}
+	return &SQLiteRepository{db: db}, nil
+}
+
+// Save stores an assessment requested for an institution and returns the stored record.
+func (r *SQLiteRepository) Save(institutionID string, assessment *models.Assessment) (*Record, error) {
+	data, err := json.Marshal(assessment)
+	if err != nil {
+		return nil, fmt.Errorf("encode assessment of '%s' failed: %v", assessment.Domain, err)
+	}
+	record := &Record{
+		InstitutionID: institutionID,
+		Domain:        normalizeDomain(assessment.Domain),
+		Url:           assessment.Url,
+		Status:        analysis.AssessmentStatus(assessment),
+		Start:         assessment.Start,
+		End:           assessment.End,
+		Assessment:    assessment,
+	}
+	if assessment.Score != nil {
+		record.Grade = assessment.Score.Grade
+	}
+	rescan := analysis.NewRescanBasis(assessment)
+	var expiresAt int64
+	if !rescan.EarliestExpiration.IsZero() {
+		expiresAt = rescan.EarliestExpiration.UnixNano()
+	}
+	result, err := r.db.Exec("INSERT INTO assessments (institution_id, domain, url, status, grade, started_at, finished_at, data, "+
+		"expires_at, shortest_ttl) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		record.InstitutionID, record.Domain, record.Url, record.Status, record.Grade,
+		record.Start.UnixNano(), record.End.UnixNano(), data, expiresAt, int64(rescan.ShortestTTL))
+	if err != nil {
+		return nil, fmt.Errorf("save assessment of '%s' failed: %v", record.Domain, err)
+	}
+	if record.ID, err = result.LastInsertId(); err != nil {
+		return nil, fmt.Errorf("save assessment of '%s' failed: %v", record.Domain, err)
+	}
+	return record, nil
+}
+
+// Latest returns the most recent assessment of a domain, or ErrNotFound.
+func (r *SQLiteRepository) Latest(domain string) (*Record, error) {
+	records, err := r.History(domain, Filter{Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	return &records[0], nil
+}
+
+// LatestPerDomain returns the most recent assessment of every domain that matches the filter.
+func (r *SQLiteRepository) LatestPerDomain(filter Filter) ([]Record, error) {
+	conditions, args := filterConditions(filter)
+	conditions = append(conditions, "id = (SELECT latest.id FROM assessments latest WHERE latest.domain = assessments.domain "+
+		"ORDER BY latest.started_at DESC, latest.id DESC LIMIT 1)")
+	return r.query(conditions, args, "domain", filter.Limit)
+}
+
+// Schedules returns the schedule of the most recent assessment of every domain.
+func (r *SQLiteRepository) Schedules() ([]Schedule, error) {
+	rows, err := r.db.Query("SELECT institution_id, domain, url, started_at, expires_at, shortest_ttl FROM assessments " +
+		"WHERE id = (SELECT latest.id FROM assessments latest WHERE latest.domain = assessments.domain " +
+		"ORDER BY latest.started_at DESC, latest.id DESC LIMIT 1) ORDER BY domain")
+	if err != nil {
+		return nil, fmt.Errorf("query schedules failed: %v", err)
+	}
+	defer rows.Close()
+
+	var schedules []Schedule
+	for rows.Next() {
+		var schedule Schedule
+		var started, expiresAt, shortestTTL int64
+		if err := rows.Scan(&schedule.InstitutionID, &schedule.Domain, &schedule.Url, &started, &expiresAt,
+			&shortestTTL); err != nil {
+			return nil, fmt.Errorf("read schedule failed: %v", err)
+		}
+		schedule.Rescan.Start = time.Unix(0, started)
+		schedule.Rescan.ShortestTTL = time.Duration(shortestTTL)
+		if expiresAt != 0 {
+			schedule.Rescan.EarliestExpiration = time.Unix(0, expiresAt)
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query schedules failed: %v", err)
+	}
+	return schedules, nil
+}
+
+// History returns the assessments of a domain that match the filter, most recent first.
+func (r *SQLiteRepository) History(domain string, filter Filter) ([]Record, error) {
+	conditions, args := filterConditions(filter)
+	conditions = append(conditions, "domain = ?")
+	args = append(args, normalizeDomain(domain))
+	return r.query(conditions, args, "started_at DESC, id DESC", filter.Limit)
+}
+
+// Close closes the database.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *SQLiteRepository) query(conditions []string, args []interface{}, order string, limit int) ([]Record, error) {
+	statement := "SELECT " + recordColumns + " FROM assessments"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY " + order
+	if limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query assessments failed: %v", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		var started, finished int64
+		var data []byte
+		if err := rows.Scan(&record.ID, &record.InstitutionID, &record.Domain, &record.Url, &record.Status, &record.Grade,
+			&started, &finished, &data); err != nil {
+			return nil, fmt.Errorf("read assessment failed: %v", err)
+		}
+		record.Start, record.End = time.Unix(0, started), time.Unix(0, finished)
+		record.Assessment = &models.Assessment{}
+		if err := json.Unmarshal(data, record.Assessment); err != nil {
+			return nil, fmt.Errorf("decode assessment %d failed: %v", record.ID, err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query assessments failed: %v", err)
+	}
+	return records, nil
+}
+
+func filterConditions(filter Filter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if filter.InstitutionID != "" {
+		conditions = append(conditions, "institution_id = ?")
+		args = append(args, filter.InstitutionID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "started_at >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "started_at < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+	return conditions, args
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"errors"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repository, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "assessments.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: unexpected error: %v", err)
+	}
+	t.Cleanup(func() { repository.Close() })
+	return repository
+}
+
+func newStoredAssessment(domain string, start time.Time, secure bool) *models.Assessment {
+	assessment := models.NewAssessment("https://"+domain, domain)
+	assessment.Start, assessment.End = start, start.Add(time.Second)
+	if secure {
+		assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{
+			Records:   []dnsrecords.DNSKEYRecord{{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "AA=="}},
+			Validated: true,
+		}
+	}
+	assessment.Score = &models.SecurityScore{Score: 95, Grade: "A"}
+	return assessment
+}
+
+func TestSQLiteRepositoryQueries(t *testing.T) {
+	repository := newTestRepository(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved := []struct {
+		institution string
+		assessment  *models.Assessment
+	}{
+		{"ipb", newStoredAssessment("ipb.pt", start, false)},
+		{"ipb", newStoredAssessment("IPB.pt.", start.Add(24*time.Hour), true)},
+		{"ua", newStoredAssessment("ua.pt", start.Add(time.Hour), false)},
+	}
+	for _, s := range saved {
+		if _, err := repository.Save(s.institution, s.assessment); err != nil {
+			t.Fatalf("Save: unexpected error: %v", err)
+		}
+	}
+
+	latest, err := repository.Latest("ipb.pt")
+	if err != nil {
+		t.Fatalf("Latest: unexpected error: %v", err)
+	}
+	if latest.Status != "secure" || latest.Grade != "A" || !latest.Start.Equal(start.Add(24*time.Hour)) {
+		t.Errorf("Unexpected latest record %+v", latest)
+	}
+	if _, ok := latest.Assessment.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse); !ok {
+		t.Errorf("Expected the stored assessment to keep its records, got %+v", latest.Assessment.Records)
+	}
+
+	history, err := repository.History("ipb.pt", Filter{})
+	if err != nil || len(history) != 2 || history[0].ID <= history[1].ID {
+		t.Errorf("Expected two records, most recent first, got %+v, %v", history, err)
+	}
+	history, err = repository.History("ipb.pt", Filter{Until: start.Add(time.Hour)})
+	if err != nil || len(history) != 1 || history[0].Status != "insecure" {
+		t.Errorf("Expected the first record only, got %+v, %v", history, err)
+	}
+
+	perDomain, err := repository.LatestPerDomain(Filter{})
+	if err != nil || len(perDomain) != 2 || perDomain[0].Domain != "ipb.pt" || perDomain[1].Domain != "ua.pt" {
+		t.Errorf("Expected the latest record of each domain, got %+v, %v", perDomain, err)
+	}
+	insecure, err := repository.LatestPerDomain(Filter{Status: "insecure"})
+	if err != nil || len(insecure) != 1 || insecure[0].Domain != "ua.pt" {
+		t.Errorf("Expected only ua.pt to be insecure, got %+v, %v", insecure, err)
+	}
+	byInstitution, err := repository.LatestPerDomain(Filter{InstitutionID: "ipb"})
+	if err != nil || len(byInstitution) != 1 || byInstitution[0].InstitutionID != "ipb" {
+		t.Errorf("Expected only the domain of institution ipb, got %+v, %v", byInstitution, err)
+	}
+}
+
+func TestSQLiteRepositoryNotFound(t *testing.T) {
+	repository := newTestRepository(t)
+	if _, err := repository.Latest("unknown.example"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSQLiteRepositorySchedules(t *testing.T) {
+	repository := newTestRepository(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiration := start.Add(72 * time.Hour)
+	signed := newStoredAssessment("ipb.pt", start, true)
+	signed.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse).RRSIG = &dnsrecords.RRSIGRecord{
+		TypeCovered: "DNSKEY", OriginalTTL: 3600, Expiration: uint32(expiration.Unix()),
+	}
+	for _, assessment := range []*models.Assessment{newStoredAssessment("ipb.pt", start.Add(-time.Hour), false), signed,
+		newStoredAssessment("ua.pt", start, false)} {
+		if _, err := repository.Save("ipb", assessment); err != nil {
+			t.Fatalf("Save: unexpected error: %v", err)
+		}
+	}
+
+	schedules, err := repository.Schedules()
+	if err != nil || len(schedules) != 2 {
+		t.Fatalf("Expected the schedule of each domain, got %+v, %v", schedules, err)
+	}
+	ipb := schedules[0]
+	if ipb.Domain != "ipb.pt" || ipb.Url != "https://ipb.pt" || !ipb.Rescan.Start.Equal(start) ||
+		!ipb.Rescan.EarliestExpiration.Equal(expiration) || ipb.Rescan.ShortestTTL != time.Hour {
+		t.Errorf("Unexpected schedule of the latest ipb.pt assessment %+v", ipb)
+	}
+	if ua := schedules[1]; ua.Domain != "ua.pt" || !ua.Rescan.EarliestExpiration.IsZero() || ua.Rescan.ShortestTTL != 0 {
+		t.Errorf("Unexpected schedule of an unsigned assessment %+v", ua)
+	}
+}
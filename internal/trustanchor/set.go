@@ -0,0 +1,103 @@
+package trustanchor
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"log"
+	"os"
+	"time"
+)
+
+// BuiltInSource is the source reported when no trust anchor file is configured and the
+// anchors shipped with delv are used.
+const BuiltInSource = "built-in"
+
+// Set holds the trust anchors and negative trust anchors the scanner validates with. The
+// anchors that are valid when the set is created are written to a file that delv reads.
+type Set struct {
+	source          string
+	anchors         []models.TrustAnchor
+	negativeAnchors []string
+	delvFile        string
+}
+
+func NewSetDefault() *Set {
+	set, err := NewSet(*config.TrustAnchors())
+	if err != nil {
+		log.Fatalf("load trust anchors failed: %v", err)
+	}
+	return set
+}
+
+func NewSet(cfg config.TrustAnchorConfig) (*Set, error) {
+	set := &Set{source: BuiltInSource, negativeAnchors: cfg.NegativeAnchors}
+	if cfg.File == "" {
+		return set, nil
+	}
+
+	anchors, err := Load(cfg.File)
+	if err != nil {
+		return nil, err
+	}
+	valid := ValidAt(anchors, time.Now())
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("no trust anchor in '%s' is currently valid", cfg.File)
+	}
+	file, err := os.CreateTemp("", "trust-anchors-*.conf")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(DelvConfig(valid)); err != nil {
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	set.source = cfg.File
+	set.anchors = anchors
+	set.delvFile = file.Name()
+	return set, nil
+}
+
+// DelvArgs returns the delv options that apply the set to a query for the domain: the anchor
+// file, and the option that disables validation when a negative trust anchor covers the domain.
+func (s *Set) DelvArgs(domain string) []string {
+	var args []string
+	if s.delvFile != "" {
+		args = append(args, "-a", s.delvFile)
+	}
+	if s.NegativeAnchor(domain) != "" {
+		args = append(args, "-i")
+	}
+	return args
+}
+
+func (s *Set) Source() string {
+	return s.source
+}
+
+func (s *Set) Anchors() []models.TrustAnchor {
+	return s.anchors
+}
+
+// Zone returns the zone of the closest anchor enclosing the domain. The built-in anchors of
+// delv are anchored at the root zone.
+func (s *Set) Zone(domain string) string {
+	if zone := ClosestZone(s.anchors, domain); zone != "" {
+		return zone
+	}
+	return "."
+}
+
+func (s *Set) NegativeAnchor(domain string) string {
+	return NegativeAnchor(s.negativeAnchors, domain)
+}
+
+// Close removes the anchor file written for delv.
+func (s *Set) Close() error {
+	if s.delvFile == "" {
+		return nil
+	}
+	return os.Remove(s.delvFile)
+}
@@ -0,0 +1,245 @@
+package trustanchor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Load reads the trust anchors of a file. The file is either the IANA root-anchors.xml
+// document (RFC 9718) or a list of DS and DNSKEY records in zone file format, one per line.
+func Load(path string) ([]models.TrustAnchor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read trust anchor file failed: %v", err)
+	}
+	var anchors []models.TrustAnchor
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		anchors, err = ParseXML(data)
+	} else {
+		anchors, err = Parse(string(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust anchor file '%s': %v", path, err)
+	}
+	if len(anchors) == 0 {
+		return nil, fmt.Errorf("invalid trust anchor file '%s': no trust anchors found", path)
+	}
+	return anchors, nil
+}
+
+// Parse reads trust anchors given as DS or DNSKEY records in zone file format
+// (e.g. ". IN DS 20326 8 2 E06D44B8..."). Empty lines and comments are ignored.
+func Parse(text string) ([]models.TrustAnchor, error) {
+	var anchors []models.TrustAnchor
+	for number, line := range strings.Split(text, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		typeIndex := -1
+		for i, field := range fields[1:] {
+			if field == "DS" || field == "DNSKEY" {
+				typeIndex = i + 1
+				break
+			}
+		}
+		if typeIndex == -1 {
+			return nil, fmt.Errorf("line %d: expected a DS or DNSKEY record", number+1)
+		}
+
+		anchor := models.TrustAnchor{Zone: canonicalZone(fields[0])}
+		rdata := fields[typeIndex+1:]
+		if len(rdata) < 4 {
+			return nil, fmt.Errorf("line %d: incomplete %s record", number+1, fields[typeIndex])
+		}
+		numbers, err := parseUints(rdata[:3], []int{16, 8, 8})
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number+1, err)
+		}
+		if fields[typeIndex] == "DS" {
+			anchor.DS = &dnsrecords.DSRecord{
+				KeyTag:     uint16(numbers[0]),
+				Algorithm:  uint8(numbers[1]),
+				DigestType: uint8(numbers[2]),
+				Digest:     strings.ToUpper(strings.Join(rdata[3:], "")),
+			}
+		} else {
+			anchor.DNSKEY = dnskeyAnchor(uint16(numbers[0]), uint8(numbers[1]), uint8(numbers[2]), strings.Join(rdata[3:], ""))
+		}
+		anchors = append(anchors, anchor)
+	}
+	return anchors, nil
+}
+
+type xmlTrustAnchor struct {
+	Zone       string         `xml:"Zone"`
+	KeyDigests []xmlKeyDigest `xml:"KeyDigest"`
+}
+
+type xmlKeyDigest struct {
+	ValidFrom  string `xml:"validFrom,attr"`
+	ValidUntil string `xml:"validUntil,attr"`
+	KeyTag     uint16 `xml:"KeyTag"`
+	Algorithm  uint8  `xml:"Algorithm"`
+	DigestType uint8  `xml:"DigestType"`
+	Digest     string `xml:"Digest"`
+	PublicKey  string `xml:"PublicKey"`
+	Flags      uint16 `xml:"Flags"`
+}
+
+// ParseXML reads trust anchors in the format of the IANA root-anchors.xml document. Each key
+// digest becomes a DS anchor; when the document also carries the public key (RFC 9718), a DNSKEY
+// anchor is added as well. The validFrom and validUntil attributes are kept on the anchors.
+func ParseXML(data []byte) ([]models.TrustAnchor, error) {
+	var document xmlTrustAnchor
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	zone := canonicalZone(document.Zone)
+	var anchors []models.TrustAnchor
+	for _, digest := range document.KeyDigests {
+		validFrom, err := parseXMLTime(digest.ValidFrom)
+		if err != nil {
+			return nil, err
+		}
+		validUntil, err := parseXMLTime(digest.ValidUntil)
+		if err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, models.TrustAnchor{
+			Zone: zone,
+			DS: &dnsrecords.DSRecord{
+				KeyTag:     digest.KeyTag,
+				Algorithm:  digest.Algorithm,
+				DigestType: digest.DigestType,
+				Digest:     strings.ToUpper(strings.TrimSpace(digest.Digest)),
+			},
+			ValidFrom:  validFrom,
+			ValidUntil: validUntil,
+		})
+		if publicKey := strings.Join(strings.Fields(digest.PublicKey), ""); publicKey != "" {
+			key := dnskeyAnchor(digest.Flags, 3, digest.Algorithm, publicKey)
+			key.KeyID = digest.KeyTag
+			anchors = append(anchors, models.TrustAnchor{
+				Zone:       zone,
+				DNSKEY:     key,
+				ValidFrom:  validFrom,
+				ValidUntil: validUntil,
+			})
+		}
+	}
+	return anchors, nil
+}
+
+// ValidAt returns the anchors whose validity period includes the given time.
+func ValidAt(anchors []models.TrustAnchor, t time.Time) []models.TrustAnchor {
+	var valid []models.TrustAnchor
+	for _, anchor := range anchors {
+		if anchor.IsValidAt(t) {
+			valid = append(valid, anchor)
+		}
+	}
+	return valid
+}
+
+// ClosestZone returns the zone of the closest anchor enclosing the domain, or "" when no
+// anchor encloses it.
+func ClosestZone(anchors []models.TrustAnchor, domain string) string {
+	closest := ""
+	for _, anchor := range anchors {
+		if covers(anchor.Zone, domain) && (closest == "" || len(anchor.Zone) > len(closest)) {
+			closest = anchor.Zone
+		}
+	}
+	return closest
+}
+
+// NegativeAnchor returns the negative trust anchor (RFC 7646) that covers the domain, or ""
+// when none does.
+func NegativeAnchor(negativeAnchors []string, domain string) string {
+	closest := ""
+	for _, anchor := range negativeAnchors {
+		zone := canonicalZone(anchor)
+		if covers(zone, domain) && len(zone) > len(closest) {
+			closest = zone
+		}
+	}
+	return closest
+}
+
+// DelvConfig renders the anchors as a trust-anchors statement that can be passed to delv with
+// the -a option. Static anchors are used so that validation does not depend on the RFC 5011
+// state delv would otherwise keep.
+func DelvConfig(anchors []models.TrustAnchor) string {
+	var config strings.Builder
+	config.WriteString("trust-anchors {\n")
+	for _, anchor := range anchors {
+		if anchor.DS != nil {
+			fmt.Fprintf(&config, "\t%q static-ds %d %d %d %q;\n", anchor.Zone,
+				anchor.DS.KeyTag, anchor.DS.Algorithm, anchor.DS.DigestType, anchor.DS.Digest)
+		}
+		if anchor.DNSKEY != nil {
+			fmt.Fprintf(&config, "\t%q static-key %d %d %d %q;\n", anchor.Zone,
+				anchor.DNSKEY.Flags, anchor.DNSKEY.Protocol, anchor.DNSKEY.Algorithm, anchor.DNSKEY.PublicKey)
+		}
+	}
+	config.WriteString("};\n")
+	return config.String()
+}
+
+func dnskeyAnchor(flags uint16, protocol uint8, algorithm uint8, publicKey string) *dnsrecords.DNSKEYRecord {
+	keyType := "ZSK"
+	if flags&0x0001 != 0 {
+		keyType = "KSK"
+	}
+	key := &dnsrecords.DNSKEYRecord{
+		Flags:     flags,
+		Protocol:  protocol,
+		Algorithm: algorithm,
+		PublicKey: publicKey,
+		KeyType:   keyType,
+	}
+	key.KeyID, _ = key.ComputeKeyTag()
+	return key
+}
+
+func parseUints(values []string, bitSizes []int) ([]uint64, error) {
+	numbers := make([]uint64, len(values))
+	for i, value := range values {
+		number, err := strconv.ParseUint(value, 10, bitSizes[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s': %v", value, err)
+		}
+		numbers[i] = number
+	}
+	return numbers, nil
+}
+
+func parseXMLTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid validity time '%s': %v", value, err)
+	}
+	return t, nil
+}
+
+// canonicalZone lower-cases a zone name and gives it a trailing dot, so that the root zone is ".".
+func canonicalZone(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(zone), ".")) + "."
+}
+
+func covers(zone, domain string) bool {
+	domain = canonicalZone(domain)
+	return zone == "." || domain == zone || strings.HasSuffix(domain, "."+zone)
+}
@@ -0,0 +1,116 @@
+package trustanchor
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const rootAnchorsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<TrustAnchor id="E9724F53-1851-4F86-85E5-F1392102940B" source="http://data.iana.org/root-anchors/root-anchors.xml">
+<Zone>.</Zone>
+<KeyDigest id="Kjqmt7v" validFrom="2010-07-15T00:00:00+00:00" validUntil="2019-01-11T00:00:00+00:00">
+<KeyTag>19036</KeyTag>
+<Algorithm>8</Algorithm>
+<DigestType>2</DigestType>
+<Digest>49AAC11D7B6F6446702E54A1607371607A1A41855200FD2CE1CDDE32F24E8FB5</Digest>
+</KeyDigest>
+<KeyDigest id="Klajeyz" validFrom="2017-02-02T00:00:00+00:00">
+<KeyTag>20326</KeyTag>
+<Algorithm>8</Algorithm>
+<DigestType>2</DigestType>
+<Digest>E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D</Digest>
+</KeyDigest>
+</TrustAnchor>
+`
+
+func TestParseXML(t *testing.T) {
+	anchors, err := ParseXML([]byte(rootAnchorsXML))
+	if err != nil {
+		t.Fatalf("Failed to parse root anchors: %v", err)
+	}
+	if len(anchors) != 2 || anchors[0].Zone != "." || anchors[1].DS.KeyTag != 20326 {
+		t.Fatalf("Unexpected anchors %+v", anchors)
+	}
+
+	valid := ValidAt(anchors, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(valid) != 1 || valid[0].DS.KeyTag != 20326 {
+		t.Errorf("Expected only KSK-2017 to be valid, got %+v", valid)
+	}
+	valid = ValidAt(anchors, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(valid) != 2 {
+		t.Errorf("Expected both keys to be valid during the rollover, got %+v", valid)
+	}
+}
+
+func TestParse(t *testing.T) {
+	anchors, err := Parse(`; root and test anchors
+. IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D
+Test. 3600 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0d xCjjnopKl+GqJxpVXckHAeF+KkxLbxIL fDLUT0rAK9iUzy1L53eKGQ==
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse anchors: %v", err)
+	}
+	if len(anchors) != 2 {
+		t.Fatalf("Expected 2 anchors, got %d", len(anchors))
+	}
+	if anchors[0].Zone != "." || anchors[0].DS == nil || anchors[0].DS.Digest != "E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D" {
+		t.Errorf("Unexpected DS anchor %+v", anchors[0])
+	}
+	key := anchors[1].DNSKEY
+	if anchors[1].Zone != "test." || key == nil || key.Flags != 257 || key.Algorithm != 13 || key.KeyType != "KSK" ||
+		key.PublicKey != "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==" {
+		t.Errorf("Unexpected DNSKEY anchor %+v", anchors[1])
+	}
+
+	if _, err := Parse(". IN A 192.0.2.1"); err == nil {
+		t.Errorf("Expected an error for a record that is not an anchor")
+	}
+}
+
+func TestZonesAndNegativeAnchors(t *testing.T) {
+	anchors, _ := Parse(". IN DS 20326 8 2 E06D44B8\ntest. IN DS 1 13 2 AB")
+	if zone := ClosestZone(anchors, "www.example.test"); zone != "test." {
+		t.Errorf("Expected closest zone test., got %s", zone)
+	}
+	if zone := ClosestZone(anchors, "example.com"); zone != "." {
+		t.Errorf("Expected closest zone ., got %s", zone)
+	}
+	if nta := NegativeAnchor([]string{"broken.example", "example.com"}, "www.Broken.Example."); nta != "broken.example." {
+		t.Errorf("Expected negative anchor broken.example., got %s", nta)
+	}
+	if nta := NegativeAnchor([]string{"broken.example"}, "notbroken.example"); nta != "" {
+		t.Errorf("Expected no negative anchor, got %s", nta)
+	}
+}
+
+func TestNewSetWritesDelvConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "root-anchors.xml")
+	if err := os.WriteFile(path, []byte(rootAnchorsXML), 0o600); err != nil {
+		t.Fatalf("Failed to write anchors: %v", err)
+	}
+	set, err := NewSet(config.TrustAnchorConfig{File: path, NegativeAnchors: []string{"broken.example"}})
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+	defer set.Close()
+
+	args := set.DelvArgs("www.broken.example")
+	if len(args) != 3 || args[0] != "-a" || args[2] != "-i" {
+		t.Fatalf("Unexpected delv arguments %v", args)
+	}
+	written, err := os.ReadFile(args[1])
+	if err != nil {
+		t.Fatalf("Failed to read delv anchors: %v", err)
+	}
+	expected := "trust-anchors {\n\t\".\" static-ds 20326 8 2 \"E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D\";\n};\n"
+	if string(written) != expected {
+		t.Errorf("Unexpected delv anchors:\n%s", written)
+	}
+	if args := set.DelvArgs("example.com"); len(args) != 2 || strings.Contains(strings.Join(args, " "), "-i") {
+		t.Errorf("Unexpected delv arguments %v", args)
+	}
+}
@@ -0,0 +1,182 @@
+package zonemd
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"hash"
+	"sort"
+	"strings"
+)
+
+// canonicalRecord is a record of the zone in the canonical form of RFC 4034, Section 6.2.
+type canonicalRecord struct {
+	owner [][]byte
+	rtype uint16
+	rdata []byte
+	wire  []byte
+}
+
+// Digest computes the digest of a zone with the given scheme and hash algorithm (RFC 8976,
+// Section 3). The records must hold the whole zone; records outside the zone are ignored, as are
+// the ZONEMD records of the apex and their signatures.
+func Digest(records []dns.RR, origin string, scheme, hashAlgorithm uint8) ([]byte, error) {
+	if scheme != dnsrecords.ZONEMDSchemeSimple {
+		return nil, fmt.Errorf("invalid ZONEMD scheme %d: only SIMPLE (1) is supported", scheme)
+	}
+	var h hash.Hash
+	switch hashAlgorithm {
+	case dnsrecords.ZONEMDHashSHA384:
+		h = sha512.New384()
+	case dnsrecords.ZONEMDHashSHA512:
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("invalid ZONEMD hash algorithm %d: it must be SHA384 (1) or SHA512 (2)", hashAlgorithm)
+	}
+
+	origin = dns.CanonicalName(origin)
+	var canonical []canonicalRecord
+	seen := make(map[string]bool)
+	for _, rr := range records {
+		header := rr.Header()
+		owner := dns.CanonicalName(header.Name)
+		if !dns.IsSubDomain(origin, owner) || isApexZONEMD(rr, owner, origin) {
+			continue
+		}
+		record, err := canonicalize(rr)
+		if err != nil {
+			return nil, err
+		}
+		if key := string(record.wire); !seen[key] {
+			seen[key] = true
+			canonical = append(canonical, record)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return lessCanonical(canonical[i], canonical[j])
+	})
+	for _, record := range canonical {
+		h.Write(record.wire)
+	}
+	return h.Sum(nil), nil
+}
+
+// isApexZONEMD reports whether a record is a ZONEMD record of the apex or a signature covering one.
+func isApexZONEMD(rr dns.RR, owner, origin string) bool {
+	if owner != origin {
+		return false
+	}
+	if rr.Header().Rrtype == dns.TypeZONEMD {
+		return true
+	}
+	sig, ok := rr.(*dns.RRSIG)
+	return ok && sig.TypeCovered == dns.TypeZONEMD
+}
+
+// canonicalize returns the canonical wire form of a record: its owner name and the domain names
+// of its data are lower-cased and no name is compressed.
+func canonicalize(rr dns.RR) (canonicalRecord, error) {
+	rr = dns.Copy(rr)
+	header := rr.Header()
+	header.Name = dns.CanonicalName(header.Name)
+	lowerDataNames(rr)
+
+	wire := make([]byte, dns.Len(rr)+1)
+	length, err := dns.PackRR(rr, wire, 0, nil, false)
+	if err != nil {
+		return canonicalRecord{}, fmt.Errorf("invalid record '%s': %v", rr.String(), err)
+	}
+	wire = wire[:length]
+	ownerLength, err := dns.PackDomainName(header.Name, make([]byte, 256), 0, nil, false)
+	if err != nil {
+		return canonicalRecord{}, fmt.Errorf("invalid owner name '%s': %v", header.Name, err)
+	}
+	return canonicalRecord{
+		owner: labelsOf(wire[:ownerLength]),
+		rtype: header.Rrtype,
+		rdata: wire[ownerLength+10:],
+		wire:  wire,
+	}, nil
+}
+
+// lowerDataNames lower-cases the domain names in the data of the record types listed in
+// RFC 4034, Section 6.2, as updated by RFC 6840, Section 5.1.
+func lowerDataNames(rr dns.RR) {
+	switch r := rr.(type) {
+	case *dns.NS:
+		r.Ns = strings.ToLower(r.Ns)
+	case *dns.MD:
+		r.Md = strings.ToLower(r.Md)
+	case *dns.MF:
+		r.Mf = strings.ToLower(r.Mf)
+	case *dns.CNAME:
+		r.Target = strings.ToLower(r.Target)
+	case *dns.SOA:
+		r.Ns, r.Mbox = strings.ToLower(r.Ns), strings.ToLower(r.Mbox)
+	case *dns.MB:
+		r.Mb = strings.ToLower(r.Mb)
+	case *dns.MG:
+		r.Mg = strings.ToLower(r.Mg)
+	case *dns.MR:
+		r.Mr = strings.ToLower(r.Mr)
+	case *dns.PTR:
+		r.Ptr = strings.ToLower(r.Ptr)
+	case *dns.MINFO:
+		r.Rmail, r.Email = strings.ToLower(r.Rmail), strings.ToLower(r.Email)
+	case *dns.MX:
+		r.Mx = strings.ToLower(r.Mx)
+	case *dns.RP:
+		r.Mbox, r.Txt = strings.ToLower(r.Mbox), strings.ToLower(r.Txt)
+	case *dns.AFSDB:
+		r.Hostname = strings.ToLower(r.Hostname)
+	case *dns.RT:
+		r.Host = strings.ToLower(r.Host)
+	case *dns.SIG:
+		r.SignerName = strings.ToLower(r.SignerName)
+	case *dns.PX:
+		r.Map822, r.Mapx400 = strings.ToLower(r.Map822), strings.ToLower(r.Mapx400)
+	case *dns.NAPTR:
+		r.Replacement = strings.ToLower(r.Replacement)
+	case *dns.KX:
+		r.Exchanger = strings.ToLower(r.Exchanger)
+	case *dns.SRV:
+		r.Target = strings.ToLower(r.Target)
+	case *dns.DNAME:
+		r.Target = strings.ToLower(r.Target)
+	case *dns.RRSIG:
+		r.SignerName = strings.ToLower(r.SignerName)
+	}
+}
+
+// labelsOf splits an uncompressed wire format name into its labels, root label excluded.
+func labelsOf(name []byte) [][]byte {
+	var labels [][]byte
+	for i := 0; i < len(name) && name[i] != 0; i += int(name[i]) + 1 {
+		labels = append(labels, name[i+1:i+1+int(name[i])])
+	}
+	return labels
+}
+
+// lessCanonical orders records by owner name in the canonical order of RFC 4034, Section 6.1,
+// then by type, then by their data, as RFC 8976, Section 3.3.1.3 requires.
+func lessCanonical(a, b canonicalRecord) bool {
+	if c := compareNames(a.owner, b.owner); c != 0 {
+		return c < 0
+	}
+	if a.rtype != b.rtype {
+		return a.rtype < b.rtype
+	}
+	return bytes.Compare(a.rdata, b.rdata) < 0
+}
+
+// compareNames compares two names label by label from the rightmost one.
+func compareNames(a, b [][]byte) int {
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := bytes.Compare(a[i], b[j]); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,156 @@
+package zonemd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"github.com/miekg/dns"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Verifier obtains full copies of zones, from a zone file directory or by zone transfer from a
+// configured server, and verifies their ZONEMD records.
+type Verifier struct {
+	transferServer string
+	zoneDirectory  string
+	timeout        time.Duration
+}
+
+// NewVerifier creates a Verifier. Zone files are looked up in zoneDirectory as "<zone>.zone";
+// zones without a file are transferred from transferServer ("host:port"). Either may be empty.
+func NewVerifier(cfg config.ZONEMDConfig) *Verifier {
+	return &Verifier{
+		transferServer: cfg.TransferServer,
+		zoneDirectory:  cfg.ZoneDirectory,
+		timeout:        time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+}
+
+// Verify obtains the zone and checks its ZONEMD records against a digest recomputed from its data.
+func (v *Verifier) Verify(zone string) *models.ZONEMDVerification {
+	records, source, err := v.load(zone)
+	if err != nil {
+		return &models.ZONEMDVerification{Source: source, Error: err.Error()}
+	}
+	verification := VerifyZone(records, zone)
+	verification.Source = source
+	return verification
+}
+
+// load reads the zone file of a zone when there is one and transfers the zone otherwise. It
+// returns the records and a description of where they came from.
+func (v *Verifier) load(zone string) ([]dns.RR, string, error) {
+	if v.zoneDirectory != "" {
+		path := filepath.Join(v.zoneDirectory, strings.ToLower(strings.TrimSuffix(zone, "."))+".zone")
+		if _, err := os.Stat(path); err == nil {
+			records, err := ReadZoneFile(path, zone)
+			return records, "file " + path, err
+		}
+	}
+	if v.transferServer != "" {
+		records, err := TransferZone(v.transferServer, zone, v.timeout)
+		return records, "AXFR from " + v.transferServer, err
+	}
+	return nil, "", fmt.Errorf("no zone file or transfer server for zone '%s'", zone)
+}
+
+// ReadZoneFile reads the records of a zone from a file in master file format.
+func ReadZoneFile(path string, origin string) ([]dns.RR, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	parser := dns.NewZoneParser(file, dns.Fqdn(origin), path)
+	var records []dns.RR
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		records = append(records, rr)
+	}
+	if err := parser.Err(); err != nil {
+		return nil, fmt.Errorf("invalid zone file '%s': %v", path, err)
+	}
+	return records, nil
+}
+
+// TransferZone fetches the records of a zone from a server by AXFR. The closing SOA record of
+// the transfer is left out.
+func TransferZone(server string, origin string, timeout time.Duration) ([]dns.RR, error) {
+	transfer := &dns.Transfer{DialTimeout: timeout, ReadTimeout: timeout, WriteTimeout: timeout}
+	query := new(dns.Msg)
+	query.SetAxfr(dns.Fqdn(origin))
+	envelopes, err := transfer.In(query, server)
+	if err != nil {
+		return nil, fmt.Errorf("zone transfer of '%s' from %s failed: %v", origin, server, err)
+	}
+	var records []dns.RR
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, fmt.Errorf("zone transfer of '%s' from %s failed: %v", origin, server, envelope.Error)
+		}
+		records = append(records, envelope.RR...)
+	}
+	if len(records) > 1 && records[len(records)-1].Header().Rrtype == dns.TypeSOA {
+		records = records[:len(records)-1]
+	}
+	return records, nil
+}
+
+// VerifyZone checks the ZONEMD records of the apex of a zone (RFC 8976, Section 4): the zone must
+// have a single SOA record at its apex, and one ZONEMD record with a supported scheme and hash
+// algorithm, the serial of that SOA record and the recomputed digest must exist.
+func VerifyZone(records []dns.RR, origin string) *models.ZONEMDVerification {
+	origin = dns.CanonicalName(origin)
+	verification := &models.ZONEMDVerification{}
+	var soa *dns.SOA
+	var zonemds []*dns.ZONEMD
+	for _, rr := range records {
+		if dns.CanonicalName(rr.Header().Name) != origin {
+			continue
+		}
+		switch r := rr.(type) {
+		case *dns.SOA:
+			if soa != nil && soa.Serial != r.Serial {
+				verification.Error = "the zone has more than one SOA record"
+				return verification
+			}
+			soa = r
+		case *dns.ZONEMD:
+			zonemds = append(zonemds, r)
+		}
+	}
+	if soa == nil {
+		verification.Error = "the zone has no SOA record at its apex"
+		return verification
+	}
+	verification.Serial = soa.Serial
+	if len(zonemds) == 0 {
+		verification.Error = "the zone has no ZONEMD record at its apex"
+		return verification
+	}
+
+	var reasons []string
+	for _, zonemd := range zonemds {
+		if zonemd.Serial != soa.Serial {
+			reasons = append(reasons, fmt.Sprintf("ZONEMD serial %d differs from SOA serial %d", zonemd.Serial, soa.Serial))
+			continue
+		}
+		digest, err := Digest(records, origin, zonemd.Scheme, zonemd.Hash)
+		if err != nil {
+			reasons = append(reasons, err.Error())
+			continue
+		}
+		verification.Computed = strings.ToUpper(hex.EncodeToString(digest))
+		if strings.EqualFold(verification.Computed, zonemd.Digest) {
+			verification.Matched = true
+			return verification
+		}
+		reasons = append(reasons, fmt.Sprintf("the %s digest does not match", (&dnsrecords.ZONEMDRecord{HashAlgorithm: zonemd.Hash}).HashAlgorithmName()))
+	}
+	verification.Error = strings.Join(reasons, "; ")
+	return verification
+}
@@ -0,0 +1,122 @@
+package zonemd
+
+import (
+	"github.com/miekg/dns"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// simpleZone is the simple example zone of RFC 8976, Appendix A.1.
+const simpleZone = `$ORIGIN example.
+example.      86400  IN  SOA     ns1 admin 2018031900 1800 900 604800 86400
+              86400  IN  NS      ns1
+              86400  IN  NS      ns2
+              86400  IN  ZONEMD  2018031900 1 1 c68090d90a7aed716bc459f9340e3d7c1370d4d24b7e2fc3a1ddc0b9a87153b9a9713b3c9ae5cc27777f98b8e730044c
+ns1           3600   IN  A       203.0.113.63
+ns2           3600   IN  AAAA    2001:db8::63
+`
+
+func writeZone(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "example.zone")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write zone file: %v", err)
+	}
+	return path
+}
+
+func TestVerifyZoneRFC8976Example(t *testing.T) {
+	records, err := ReadZoneFile(writeZone(t, simpleZone), "example")
+	if err != nil {
+		t.Fatalf("Failed to read zone file: %v", err)
+	}
+
+	verification := VerifyZone(records, "example")
+	if !verification.Matched || verification.Error != "" || verification.Serial != 2018031900 {
+		t.Errorf("Expected the digest to match, got %+v", verification)
+	}
+}
+
+func TestVerifyZoneMismatch(t *testing.T) {
+	tampered := strings.Replace(simpleZone, "203.0.113.63", "203.0.113.64", 1)
+	records, err := ReadZoneFile(writeZone(t, tampered), "example")
+	if err != nil {
+		t.Fatalf("Failed to read zone file: %v", err)
+	}
+
+	verification := VerifyZone(records, "example")
+	if verification.Matched || !strings.Contains(verification.Error, "does not match") {
+		t.Errorf("Expected a digest mismatch, got %+v", verification)
+	}
+}
+
+func TestVerifyZoneSerialMismatch(t *testing.T) {
+	stale := strings.Replace(simpleZone, "admin 2018031900", "admin 2018031901", 1)
+	records, err := ReadZoneFile(writeZone(t, stale), "example")
+	if err != nil {
+		t.Fatalf("Failed to read zone file: %v", err)
+	}
+
+	verification := VerifyZone(records, "example")
+	if verification.Matched || !strings.Contains(verification.Error, "differs from SOA serial 2018031901") {
+		t.Errorf("Expected a serial mismatch, got %+v", verification)
+	}
+}
+
+func TestDigestIgnoresOrderCaseAndDuplicates(t *testing.T) {
+	records, err := ReadZoneFile(writeZone(t, simpleZone), "example")
+	if err != nil {
+		t.Fatalf("Failed to read zone file: %v", err)
+	}
+	shuffled := []dns.RR{records[5], records[2]}
+	upper, _ := dns.NewRR("NS1.EXAMPLE. 3600 IN A 203.0.113.63")
+	outside, _ := dns.NewRR("other. 3600 IN A 192.0.2.1")
+	shuffled = append(shuffled, upper, records[0], records[1], outside, records[4])
+
+	expected, err := Digest(records, "example", 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to compute digest: %v", err)
+	}
+	digest, err := Digest(shuffled, "example.", 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to compute digest: %v", err)
+	}
+	if string(digest) != string(expected) {
+		t.Errorf("Expected the same digest regardless of order, case, duplicates and out-of-zone data")
+	}
+	if _, err := Digest(records, "example", 2, 1); err == nil {
+		t.Errorf("Expected an error for an unsupported scheme")
+	}
+}
+
+func TestVerifierTransfer(t *testing.T) {
+	records, err := ReadZoneFile(writeZone(t, simpleZone), "example")
+	if err != nil {
+		t.Fatalf("Failed to read zone file: %v", err)
+	}
+	mux := dns.NewServeMux()
+	mux.HandleFunc("example.", func(w dns.ResponseWriter, r *dns.Msg) {
+		envelopes := make(chan *dns.Envelope)
+		transfer := new(dns.Transfer)
+		go func() {
+			envelopes <- &dns.Envelope{RR: append(append([]dns.RR{}, records...), records[0])}
+			close(envelopes)
+		}()
+		_ = transfer.Out(w, r, envelopes)
+		w.Hijack()
+	})
+	server := &dns.Server{Addr: "127.0.0.1:0", Net: "tcp", Handler: mux}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ListenAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	verifier := &Verifier{transferServer: server.Listener.Addr().String(), timeout: 2 * time.Second}
+	verification := verifier.Verify("example")
+	if !verification.Matched || !strings.HasPrefix(verification.Source, "AXFR from 127.0.0.1:") {
+		t.Errorf("Expected the transferred zone to match, got %+v", verification)
+	}
+}
@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+)
+
+// AnalyzeAliasChain sets the overall status of an alias chain and reports bogus hops and hops
+// where a secure name aliases into an insecure zone, such as a signed institution website that
+// points to an unsigned hosting provider. Synthesized CNAME records, which follow the DNAME
+// record they come from, are not treated as hops of their own zone.
+func AnalyzeAliasChain(host string, hops []models.AliasHop) *models.AliasChainReport {
+	report := &models.AliasChainReport{Host: normalizeName(host), Hops: hops, Status: VerdictSecure}
+	if len(hops) == 0 {
+		report.Status = VerdictIndeterminate
+		return report
+	}
+	report.Target = hops[len(hops)-1].Name
+
+	var lastSecure *models.AliasHop
+	for i := range hops {
+		hop := &hops[i]
+		if verdictRank[hop.Status] < verdictRank[report.Status] {
+			report.Status = hop.Status
+		}
+		switch hop.Status {
+		case VerdictBogus:
+			report.Findings = append(report.Findings, fmt.Sprintf("the %s record of %s is bogus", hop.Type, hop.Name))
+		case VerdictSecure:
+			lastSecure = hop
+		case VerdictInsecure:
+			if i > 0 && hops[i-1].Type == "DNAME" && hop.Type == "CNAME" {
+				continue
+			}
+			if lastSecure != nil && hop.Zone != lastSecure.Zone {
+				zone := hop.Zone
+				if zone == "" {
+					zone = hop.Name
+				}
+				report.Findings = append(report.Findings, fmt.Sprintf("%s is signed but aliases %s into the unsigned zone %s",
+					lastSecure.Name, hop.Name, zone))
+				lastSecure = nil
+			}
+		}
+	}
+	return report
+}
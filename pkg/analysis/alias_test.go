@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeAliasChainUnsignedProvider(t *testing.T) {
+	report := AnalyzeAliasChain("www.example.pt", []models.AliasHop{
+		{Name: "www.example.pt", Type: "CNAME", Target: "site.cdn-provider.net", Zone: "example.pt", Signed: true, Status: VerdictSecure},
+		{Name: "site.cdn-provider.net", Type: "A", Zone: "cdn-provider.net", Status: VerdictInsecure},
+	})
+
+	if report.Status != VerdictInsecure || report.Target != "site.cdn-provider.net" {
+		t.Errorf("Expected an insecure chain ending at site.cdn-provider.net, got %s at %s", report.Status, report.Target)
+	}
+	if len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "into the unsigned zone cdn-provider.net") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeAliasChainSecure(t *testing.T) {
+	report := AnalyzeAliasChain("www.example.pt", []models.AliasHop{
+		{Name: "old.example.pt", Type: "DNAME", Target: "example.pt", Zone: "example.pt", Signed: true, Status: VerdictSecure},
+		{Name: "www.old.example.pt", Type: "CNAME", Target: "www.example.pt", Zone: "example.pt", Status: VerdictSecure},
+		{Name: "www.example.pt", Type: "A", Zone: "example.pt", Signed: true, Status: VerdictSecure},
+	})
+
+	if report.Status != VerdictSecure || len(report.Findings) != 0 {
+		t.Errorf("Expected a secure chain, got %+v", report)
+	}
+}
+
+func TestAnalyzeAliasChainBogusHop(t *testing.T) {
+	report := AnalyzeAliasChain("www.example.pt", []models.AliasHop{
+		{Name: "www.example.pt", Type: "CNAME", Target: "www.example.net", Zone: "example.pt", Signed: true, Status: VerdictSecure},
+		{Name: "www.example.net", Type: "AAAA", Zone: "example.net", Signed: true, Status: VerdictBogus},
+	})
+
+	if report.Status != VerdictBogus || len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "AAAA record of www.example.net is bogus") {
+		t.Errorf("Expected a bogus chain, got %+v", report)
+	}
+}
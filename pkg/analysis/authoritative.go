@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AnalyzeAuthoritativeServers compares the SOA serials, DNSKEY sets and signatures served by
+// each authoritative server address. A server is reported when its serial is behind the newest
+// one, or when its DNSKEY set, signing status or set of signatures (key tags and inceptions)
+// differs from what most servers serve. Signatures are compared as sets, since servers may
+// return them in any order.
+func AnalyzeAuthoritativeServers(servers []models.AuthoritativeServer) *models.AuthoritativeReport {
+	report := &models.AuthoritativeReport{
+		SerialsConsistent:    true,
+		DNSKEYConsistent:     true,
+		SignaturesConsistent: true,
+	}
+
+	var responding []*models.AuthoritativeServer
+	for i := range servers {
+		if servers[i].Responded {
+			responding = append(responding, &servers[i])
+		} else {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("%s (%s) did not respond: %s", servers[i].NameServer, servers[i].Address, servers[i].Error))
+		}
+	}
+	if len(responding) == 0 {
+		report.Servers = servers
+		report.Findings = append(report.Findings, "no authoritative server responded")
+		return report
+	}
+
+	latest := responding[0].SOASerial
+	for _, server := range responding[1:] {
+		if serialNewer(server.SOASerial, latest) {
+			latest = server.SOASerial
+		}
+	}
+
+	var keySets, soaSignatures, dnskeySignatures []string
+	soaSigned, dnskeySigned := false, false
+	for _, server := range responding {
+		keySets = append(keySets, keyIDList(server.DNSKEYKeyIDs))
+		if server.SOASigned {
+			soaSigned = true
+			soaSignatures = append(soaSignatures, signatureList(server.SOASignatures))
+		}
+		if server.DNSKEYSigned {
+			dnskeySigned = true
+			dnskeySignatures = append(dnskeySignatures, signatureList(server.DNSKEYSignatures))
+		}
+	}
+	keySet := mostCommon(keySets)
+	soaSignature := mostCommon(soaSignatures)
+	dnskeySignature := mostCommon(dnskeySignatures)
+
+	for _, server := range responding {
+		if server.SOASerial != latest {
+			report.SerialsConsistent = false
+			server.Discrepancies = append(server.Discrepancies,
+				fmt.Sprintf("serves SOA serial %d, behind the latest serial %d", server.SOASerial, latest))
+		}
+		if set := keyIDList(server.DNSKEYKeyIDs); set != keySet {
+			report.DNSKEYConsistent = false
+			server.Discrepancies = append(server.Discrepancies,
+				fmt.Sprintf("serves DNSKEY set [%s] while most servers serve [%s]", set, keySet))
+		}
+		switch {
+		case soaSigned && !server.SOASigned:
+			report.SignaturesConsistent = false
+			server.Discrepancies = append(server.Discrepancies, "serves an unsigned SOA record")
+		case server.SOASigned && signatureList(server.SOASignatures) != soaSignature:
+			report.SignaturesConsistent = false
+			server.Discrepancies = append(server.Discrepancies, fmt.Sprintf("serves SOA signatures [%s] while most servers serve [%s]",
+				signatureList(server.SOASignatures), soaSignature))
+		}
+		switch {
+		case dnskeySigned && !server.DNSKEYSigned:
+			report.SignaturesConsistent = false
+			server.Discrepancies = append(server.Discrepancies, "serves an unsigned DNSKEY set")
+		case server.DNSKEYSigned && signatureList(server.DNSKEYSignatures) != dnskeySignature:
+			report.SignaturesConsistent = false
+			server.Discrepancies = append(server.Discrepancies, fmt.Sprintf("serves DNSKEY signatures [%s] while most servers serve [%s]",
+				signatureList(server.DNSKEYSignatures), dnskeySignature))
+		}
+		for _, discrepancy := range server.Discrepancies {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s (%s) %s", server.NameServer, server.Address, discrepancy))
+		}
+	}
+
+	report.Servers = servers
+	report.Consistent = len(report.Findings) == 0
+	return report
+}
+
+// serialNewer reports whether SOA serial a is newer than b using serial number arithmetic
+// (RFC 1982), so that a serial that wrapped around is still considered newer.
+func serialNewer(a, b uint32) bool {
+	return a != b && int32(a-b) > 0
+}
+
+func keyIDList(keyIDs []uint16) string {
+	ids := make([]string, len(keyIDs))
+	for i, id := range keyIDs {
+		ids[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(ids, " ")
+}
+
+// SignatureInceptions returns the key tag and inception of every signature of a record set,
+// sorted by key tag and then inception.
+func SignatureInceptions(rrsigs []dnsrecords.RRSIGRecord) []models.SignatureInception {
+	signatures := make([]models.SignatureInception, len(rrsigs))
+	for i, rrsig := range rrsigs {
+		signatures[i] = models.SignatureInception{KeyTag: rrsig.KeyTag, Inception: rrsig.Inception}
+	}
+	slices.SortFunc(signatures, compareSignatureInceptions)
+	return signatures
+}
+
+// signatureList describes a set of signatures as "keytag/inception" pairs sorted by key tag and
+// then inception, so that the same signatures served in another order compare equal.
+func signatureList(signatures []models.SignatureInception) string {
+	sorted := slices.Clone(signatures)
+	slices.SortFunc(sorted, compareSignatureInceptions)
+	pairs := make([]string, len(sorted))
+	for i, signature := range sorted {
+		pairs[i] = fmt.Sprintf("%d/%d", signature.KeyTag, signature.Inception)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// compareSignatureInceptions orders signatures by key tag and then inception.
+func compareSignatureInceptions(a, b models.SignatureInception) int {
+	if a.KeyTag != b.KeyTag {
+		return cmp.Compare(a.KeyTag, b.KeyTag)
+	}
+	return cmp.Compare(a.Inception, b.Inception)
+}
+
+// mostCommon returns the value that appears most often; ties go to the value seen first.
+func mostCommon(values []string) string {
+	counts := make(map[string]int, len(values))
+	best, bestCount := "", 0
+	for _, value := range values {
+		counts[value]++
+		if counts[value] > bestCount {
+			best, bestCount = value, counts[value]
+		}
+	}
+	return best
+}
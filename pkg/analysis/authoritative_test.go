@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+)
+
+func authoritativeServer(ns, address string, serial uint32, inception uint32, keyIDs ...uint16) models.AuthoritativeServer {
+	return models.AuthoritativeServer{
+		NameServer:       ns,
+		Address:          address,
+		Responded:        true,
+		SOASerial:        serial,
+		DNSKEYKeyIDs:     keyIDs,
+		SOASigned:        true,
+		DNSKEYSigned:     true,
+		SOASignatures:    []models.SignatureInception{{KeyTag: 4410, Inception: inception}},
+		DNSKEYSignatures: []models.SignatureInception{{KeyTag: 60485, Inception: inception}},
+	}
+}
+
+func TestAnalyzeAuthoritativeServersConsistent(t *testing.T) {
+	report := AnalyzeAuthoritativeServers([]models.AuthoritativeServer{
+		authoritativeServer("ns1.example.com", "192.0.2.53", 2023122101, 1703116800, 4410, 60485),
+		authoritativeServer("ns1.example.com", "2001:db8::53", 2023122101, 1703116800, 4410, 60485),
+		authoritativeServer("ns2.example.net", "198.51.100.53", 2023122101, 1703116800, 4410, 60485),
+	})
+	if !report.Consistent {
+		t.Errorf("Expected consistent servers, got findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeAuthoritativeServersDiscrepancies(t *testing.T) {
+	unsigned := authoritativeServer("ns2.example.net", "2001:db8:1::53", 2023122101, 1703116800, 4410, 60485)
+	unsigned.SOASigned, unsigned.SOASignatures = false, nil
+	report := AnalyzeAuthoritativeServers([]models.AuthoritativeServer{
+		authoritativeServer("ns1.example.com", "192.0.2.53", 2023122101, 1703116800, 4410, 60485),
+		authoritativeServer("ns1.example.com", "2001:db8::53", 2023122001, 1703030400, 4410),
+		authoritativeServer("ns2.example.net", "198.51.100.53", 2023122101, 1703116800, 4410, 60485),
+		unsigned,
+		{NameServer: "ns3.example.org", Address: "203.0.113.53", Error: "no response: connection timed out"},
+	})
+
+	if report.Consistent || report.SerialsConsistent || report.DNSKEYConsistent || report.SignaturesConsistent {
+		t.Fatalf("Expected every check to fail, got %+v", report)
+	}
+	stale := report.Servers[1]
+	if len(stale.Discrepancies) != 4 {
+		t.Errorf("Expected serial, DNSKEY set and both inception discrepancies, got %v", stale.Discrepancies)
+	}
+	if len(report.Servers[3].Discrepancies) != 1 || report.Servers[3].Discrepancies[0] != "serves an unsigned SOA record" {
+		t.Errorf("Expected an unsigned SOA discrepancy, got %v", report.Servers[3].Discrepancies)
+	}
+	if len(report.Servers[0].Discrepancies) != 0 || len(report.Servers[2].Discrepancies) != 0 {
+		t.Errorf("Expected no discrepancies for up to date servers")
+	}
+	// One finding for the server that did not respond plus one per discrepancy.
+	if len(report.Findings) != 6 {
+		t.Errorf("Expected 6 findings, got %d: %v", len(report.Findings), report.Findings)
+	}
+}
+
+func TestAnalyzeAuthoritativeServersSignatureOrder(t *testing.T) {
+	ksk := dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", KeyTag: 60485, Inception: 1703116800}
+	zsk := dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", KeyTag: 4410, Inception: 1703203200}
+	first := authoritativeServer("ns1.example.com", "192.0.2.53", 2023122101, 1703116800, 4410, 60485)
+	first.DNSKEYSignatures = SignatureInceptions([]dnsrecords.RRSIGRecord{ksk, zsk})
+	second := authoritativeServer("ns2.example.net", "198.51.100.53", 2023122101, 1703116800, 4410, 60485)
+	second.DNSKEYSignatures = []models.SignatureInception{{KeyTag: 60485, Inception: 1703116800}, {KeyTag: 4410, Inception: 1703203200}}
+	third := authoritativeServer("ns3.example.org", "203.0.113.53", 2023122101, 1703116800, 4410, 60485)
+	third.DNSKEYSignatures = SignatureInceptions([]dnsrecords.RRSIGRecord{ksk})
+
+	report := AnalyzeAuthoritativeServers([]models.AuthoritativeServer{first, second, third})
+
+	if first.DNSKEYSignatures[0].KeyTag != 4410 {
+		t.Errorf("Expected signatures sorted by key tag, got %+v", first.DNSKEYSignatures)
+	}
+	if len(report.Servers[0].Discrepancies) != 0 || len(report.Servers[1].Discrepancies) != 0 {
+		t.Errorf("Expected the same signatures in another order to be consistent, got %v", report.Findings)
+	}
+	if report.SignaturesConsistent || len(report.Servers[2].Discrepancies) != 1 {
+		t.Errorf("Expected the server missing the ZSK signature to be reported, got %v", report.Findings)
+	}
+}
+
+func TestSerialNewerWrapsAround(t *testing.T) {
+	if !serialNewer(1, 4294967295) || serialNewer(4294967295, 1) {
+		t.Errorf("Expected serial 1 to be newer than 4294967295")
+	}
+}
@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// knownCAATags are the CAA property tags defined by RFC 8659, RFC 9495 and the CA/Browser Forum.
+var knownCAATags = map[string]bool{
+	"issue":        true,
+	"issuewild":    true,
+	"iodef":        true,
+	"issuemail":    true,
+	"issuevmc":     true,
+	"contactemail": true,
+	"contactphone": true,
+}
+
+// CAAObservation holds the CAA answer observed for one name of the climb from the host upwards.
+type CAAObservation struct {
+	Name string
+	CAA  *dnsrecords.CAAResponse
+}
+
+// AnalyzeCAA selects the relevant CAA record set from the observations, ordered from the host
+// upwards, as the first non-empty one, and reports hosts without CAA records, relevant sets that
+// are not secure, and critical records with tags certification authorities do not know, which
+// forbid issuance altogether. status is the DNSSEC status of the answer holding the relevant set,
+// or of the host answer when there is none.
+func AnalyzeCAA(host string, observations []CAAObservation, status string) *models.CAAReport {
+	report := &models.CAAReport{Host: normalizeName(host), Status: status}
+	for _, observation := range observations {
+		if observation.CAA != nil && len(observation.CAA.Records) > 0 {
+			report.RelevantName = normalizeName(observation.Name)
+			report.Records = observation.CAA.Records
+			report.Validated = observation.CAA.Validated
+			break
+		}
+	}
+	if report.RelevantName == "" {
+		report.Findings = append(report.Findings, fmt.Sprintf("no CAA records restrict certificate issuance for %s", report.Host))
+		if status == VerdictBogus {
+			report.Findings = append(report.Findings, fmt.Sprintf("the CAA answer for %s is bogus", report.Host))
+		}
+		return report
+	}
+
+	var issue, issueWild []string
+	var hasIssueWild bool
+	for _, record := range report.Records {
+		switch record.Tag {
+		case "issue":
+			issue = appendIssuer(issue, record.Value)
+		case "issuewild":
+			hasIssueWild = true
+			issueWild = appendIssuer(issueWild, record.Value)
+		}
+		if record.IsCritical() && !knownCAATags[record.Tag] {
+			report.Findings = append(report.Findings, fmt.Sprintf("the critical CAA tag %q of %s is unknown and forbids issuance by every CA",
+				record.Tag, report.RelevantName))
+		}
+	}
+	report.Issuers = issue
+	report.WildcardIssuers = issue
+	if hasIssueWild {
+		report.WildcardIssuers = issueWild
+	}
+
+	switch status {
+	case VerdictSecure:
+	case VerdictBogus:
+		report.Findings = append(report.Findings, fmt.Sprintf("the CAA records of %s are bogus, so CAs that validate them cannot issue",
+			report.RelevantName))
+	default:
+		report.Findings = append(report.Findings, fmt.Sprintf("the CAA records of %s are not DNSSEC-validated and can be spoofed towards CAs",
+			report.RelevantName))
+	}
+	return report
+}
+
+// appendIssuer adds the issuer domain name of an "issue" or "issuewild" value, which precedes the
+// optional parameters. An empty issuer, as in ";", forbids issuance and adds nothing.
+func appendIssuer(issuers []string, value string) []string {
+	issuer, _, _ := strings.Cut(value, ";")
+	if issuer = strings.ToLower(strings.TrimSpace(issuer)); issuer != "" {
+		issuers = append(issuers, issuer)
+	}
+	return issuers
+}
@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeCAAClimbsToAncestor(t *testing.T) {
+	report := AnalyzeCAA("www.example.pt", []CAAObservation{
+		{Name: "www.example.pt", CAA: &dnsrecords.CAAResponse{Validated: true}},
+		{Name: "example.pt", CAA: &dnsrecords.CAAResponse{Validated: true, Records: []dnsrecords.CAARecord{
+			{Tag: "issue", Value: "letsencrypt.org; validationmethods=dns-01"},
+			{Tag: "issue", Value: "Sectigo.com"},
+			{Tag: "issuewild", Value: ";"},
+		}}},
+	}, VerdictSecure)
+
+	if report.RelevantName != "example.pt" || !report.Validated {
+		t.Errorf("Expected the validated records of example.pt, got %s", report.RelevantName)
+	}
+	if !slices.Equal(report.Issuers, []string{"letsencrypt.org", "sectigo.com"}) || len(report.WildcardIssuers) != 0 {
+		t.Errorf("Unexpected issuers %v and wildcard issuers %v", report.Issuers, report.WildcardIssuers)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("Expected no findings, got %v", report.Findings)
+	}
+}
+
+func TestAnalyzeCAAUnsignedAndCritical(t *testing.T) {
+	report := AnalyzeCAA("example.pt", []CAAObservation{
+		{Name: "example.pt", CAA: &dnsrecords.CAAResponse{Records: []dnsrecords.CAARecord{
+			{Tag: "issue", Value: "letsencrypt.org"},
+			{Flags: 128, Tag: "tbs", Value: "unknown"},
+		}}},
+	}, VerdictInsecure)
+
+	if !slices.Equal(report.WildcardIssuers, []string{"letsencrypt.org"}) {
+		t.Errorf("Expected wildcard issuers taken from issue records, got %v", report.WildcardIssuers)
+	}
+	if len(report.Findings) != 2 || !strings.Contains(report.Findings[0], `tag "tbs"`) || !strings.Contains(report.Findings[1], "not DNSSEC-validated") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeCAANone(t *testing.T) {
+	report := AnalyzeCAA("www.example.pt", []CAAObservation{
+		{Name: "www.example.pt", CAA: &dnsrecords.CAAResponse{}},
+		{Name: "example.pt", CAA: &dnsrecords.CAAResponse{}},
+		{Name: "pt", CAA: &dnsrecords.CAAResponse{}},
+	}, VerdictSecure)
+
+	if report.RelevantName != "" || len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "no CAA records") {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
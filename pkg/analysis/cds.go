@@ -0,0 +1,146 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// AnalyzeCDS compares the CDS and CDNSKEY records of the assessment against the DNSKEY
+// records of the zone and the DS records of the parent. It detects delete requests
+// (algorithm 0, RFC 8078), signalling that references unpublished keys, and CDS/CDNSKEY
+// sets that disagree with each other, and it decides whether a parent could maintain the
+// DS records automatically from the signalling.
+func AnalyzeCDS(assessment *models.Assessment) *models.CDSReport {
+	report := &models.CDSReport{}
+	cds := cdsResponse(assessment)
+	cdnskey := cdnskeyResponse(assessment)
+	report.CDSPresent = cds != nil && len(cds.Records) > 0
+	report.CDNSKEYPresent = cdnskey != nil && len(cdnskey.Records) > 0
+	if !report.CDSPresent && !report.CDNSKEYPresent {
+		report.Findings = append(report.Findings, "no CDS or CDNSKEY records are published")
+		return report
+	}
+
+	report.Validated = (!report.CDSPresent || cds.Validated) && (!report.CDNSKEYPresent || cdnskey.Validated)
+	if !report.Validated {
+		report.Findings = append(report.Findings, "the CDS/CDNSKEY records are not validated and cannot be accepted by the parent")
+	}
+	if !report.CDSPresent {
+		report.Findings = append(report.Findings, "only CDNSKEY records are published")
+	}
+	if !report.CDNSKEYPresent {
+		report.Findings = append(report.Findings, "only CDS records are published")
+	}
+
+	cdsDelete := report.CDSPresent && cds.IsDeleteRequest()
+	cdnskeyDelete := report.CDNSKEYPresent && cdnskey.IsDeleteRequest()
+	report.DeleteRequest = cdsDelete || cdnskeyDelete
+	if (cdsDelete && len(cds.Records) > 1) || (cdnskeyDelete && len(cdnskey.Records) > 1) {
+		report.Findings = append(report.Findings, "a delete request is mixed with other signalling records")
+	}
+
+	var keys []dnsrecords.DNSKEYRecord
+	if dnskey := dnskeyResponse(assessment); dnskey != nil {
+		keys = dnskey.Records
+	}
+	owner := assessment.Domain
+
+	report.CDSMatchesDNSKEY = !report.CDSPresent || cdsDelete
+	if report.CDSPresent && !cdsDelete {
+		report.CDSMatchesDNSKEY = true
+		for _, record := range cds.Records {
+			if !supportedDigestType(record.DigestType) {
+				report.Findings = append(report.Findings,
+					fmt.Sprintf("CDS %d uses unsupported digest type %d", record.KeyTag, record.DigestType))
+			}
+			if !anyKey(keys, func(key dnsrecords.DNSKEYRecord) bool { return dsMatchesDNSKEY(owner, record, key) }) {
+				report.CDSMatchesDNSKEY = false
+				report.Findings = append(report.Findings,
+					fmt.Sprintf("CDS %d does not match any published DNSKEY", record.KeyTag))
+			}
+		}
+	}
+
+	report.CDNSKEYMatchesDNSKEY = !report.CDNSKEYPresent || cdnskeyDelete
+	if report.CDNSKEYPresent && !cdnskeyDelete {
+		report.CDNSKEYMatchesDNSKEY = true
+		for _, record := range cdnskey.Records {
+			if !anyKey(keys, func(key dnsrecords.DNSKEYRecord) bool { return sameDNSKEY(record, key) }) {
+				report.CDNSKEYMatchesDNSKEY = false
+				report.Findings = append(report.Findings, "a CDNSKEY record does not match any published DNSKEY")
+			}
+		}
+	}
+
+	report.Consistent = true
+	if report.CDSPresent && report.CDNSKEYPresent {
+		report.Consistent = consistentSignalling(owner, cds, cdnskey, cdsDelete, cdnskeyDelete)
+		if !report.Consistent {
+			report.Findings = append(report.Findings, "the CDS and CDNSKEY records signal different keys")
+		}
+	}
+
+	if ds := dsResponse(assessment); ds != nil && report.CDSPresent && !cdsDelete {
+		report.InSyncWithDS = sameDSSet(cds.Records, ds.Records)
+	}
+
+	report.AutomationReady = report.Validated && report.Consistent &&
+		(report.DeleteRequest || (report.CDSMatchesDNSKEY && report.CDNSKEYMatchesDNSKEY))
+	return report
+}
+
+// consistentSignalling reports whether every CDNSKEY has a CDS digest and every CDS has a
+// CDNSKEY, or whether both record sets carry a delete request.
+func consistentSignalling(owner string, cds *dnsrecords.CDSResponse, cdnskey *dnsrecords.CDNSKEYResponse,
+	cdsDelete, cdnskeyDelete bool) bool {
+	if cdsDelete || cdnskeyDelete {
+		return cdsDelete && cdnskeyDelete
+	}
+	for _, record := range cds.Records {
+		if !anyKey(cdnskey.Records, func(key dnsrecords.DNSKEYRecord) bool { return dsMatchesDNSKEY(owner, record, key) }) {
+			return false
+		}
+	}
+	for _, key := range cdnskey.Records {
+		matched := false
+		for _, record := range cds.Records {
+			if dsMatchesDNSKEY(owner, record, key) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func sameDSSet(a, b []dnsrecords.DSRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if sameDS(x, y) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func anyKey(keys []dnsrecords.DNSKEYRecord, match func(dnsrecords.DNSKEYRecord) bool) bool {
+	for _, key := range keys {
+		if match(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+)
+
+// rfc4034Key is the DNSKEY of the DS example in RFC 4034, Section 5.4.
+var rfc4034Key = dnsrecords.DNSKEYRecord{
+	Flags:     256,
+	Protocol:  3,
+	Algorithm: 5,
+	PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw==",
+	KeyID:     60485,
+}
+
+var rfc4034DS = dnsrecords.DSRecord{KeyTag: 60485, Algorithm: 5, DigestType: 1, Digest: "2BB183AF5F22588179A53B0A98631FAD1A292118"}
+
+func newCDSAssessment() *models.Assessment {
+	assessment := models.NewAssessment("https://dskey.example.com", "dskey.example.com")
+	assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{rfc4034Key}, Validated: true}
+	assessment.Records["DS"] = &dnsrecords.DSResponse{Records: []dnsrecords.DSRecord{rfc4034DS}, Validated: true}
+	return assessment
+}
+
+func TestDSDigest(t *testing.T) {
+	digest, err := dsDigest("dskey.example.com", rfc4034Key, 1)
+	if err != nil {
+		t.Fatalf("dsDigest: unexpected error: %v", err)
+	}
+	if digest != rfc4034DS.Digest {
+		t.Errorf("Expected digest %s, got %s", rfc4034DS.Digest, digest)
+	}
+}
+
+func TestAnalyzeCDSInSync(t *testing.T) {
+	assessment := newCDSAssessment()
+	assessment.Records["CDS"] = &dnsrecords.CDSResponse{Records: []dnsrecords.DSRecord{rfc4034DS}, Validated: true}
+	assessment.Records["CDNSKEY"] = &dnsrecords.CDNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{rfc4034Key}, Validated: true}
+
+	report := AnalyzeCDS(assessment)
+
+	if !report.CDSMatchesDNSKEY || !report.CDNSKEYMatchesDNSKEY || !report.Consistent {
+		t.Errorf("Expected consistent signalling matching the published keys, got %+v", report)
+	}
+	if !report.InSyncWithDS || !report.AutomationReady || report.DeleteRequest {
+		t.Errorf("Expected signalling in sync with the parent and ready for automation, got %+v", report)
+	}
+}
+
+func TestAnalyzeCDSUnknownKey(t *testing.T) {
+	assessment := newCDSAssessment()
+	unknown := rfc4034DS
+	unknown.Digest = "0000000000000000000000000000000000000000"
+	assessment.Records["CDS"] = &dnsrecords.CDSResponse{Records: []dnsrecords.DSRecord{unknown}, Validated: true}
+	assessment.Records["CDNSKEY"] = &dnsrecords.CDNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{rfc4034Key}, Validated: true}
+
+	report := AnalyzeCDS(assessment)
+
+	if report.CDSMatchesDNSKEY || report.Consistent || report.AutomationReady {
+		t.Errorf("Expected mismatching signalling to be reported, got %+v", report)
+	}
+}
+
+func TestAnalyzeCDSDeleteRequest(t *testing.T) {
+	assessment := newCDSAssessment()
+	assessment.Records["CDS"] = &dnsrecords.CDSResponse{
+		Records:   []dnsrecords.DSRecord{{KeyTag: 0, Algorithm: 0, DigestType: 0, Digest: "00"}},
+		Validated: true,
+	}
+	assessment.Records["CDNSKEY"] = &dnsrecords.CDNSKEYResponse{
+		Records:   []dnsrecords.DNSKEYRecord{{Flags: 0, Protocol: 3, Algorithm: 0, PublicKey: "AA=="}},
+		Validated: true,
+	}
+
+	report := AnalyzeCDS(assessment)
+
+	if !report.DeleteRequest || !report.Consistent || !report.AutomationReady {
+		t.Errorf("Expected a consistent delete request, got %+v", report)
+	}
+}
+
+func TestAnalyzeCDSAbsent(t *testing.T) {
+	report := AnalyzeCDS(newCDSAssessment())
+	if report.CDSPresent || report.CDNSKEYPresent || report.AutomationReady {
+		t.Errorf("Expected no signalling, got %+v", report)
+	}
+}
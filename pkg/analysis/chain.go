@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// ChainObservation holds the DS record set published by the parent of a zone cut and the DNSKEY
+// record set of the zone. Either may be nil when the query failed.
+type ChainObservation struct {
+	Zone   string
+	DS     *dnsrecords.DSResponse
+	DNSKEY *dnsrecords.DNSKEYResponse
+}
+
+// AnalyzeChain follows the chain of trust through the zone cuts observed from the top-level domain
+// down to the zone enclosing the host, starting from the signed root. apex is the zone apex of the
+// assessed domain; zones delegated below a secure apex that are not secure are reported.
+func AnalyzeChain(host string, apex string, observations []ChainObservation) *models.ChainReport {
+	report := &models.ChainReport{Host: normalizeName(host), Status: VerdictInsecure}
+	apex = normalizeName(apex)
+	parent, parentStatus := ".", VerdictSecure
+	apexStatus := ""
+	for _, observation := range observations {
+		link := chainLink(observation, parent)
+		switch {
+		case parentStatus != VerdictSecure:
+			link.Status = parentStatus
+		case !link.DSPresent:
+			link.Status = VerdictInsecure
+			if link.DNSKEYPresent {
+				report.Findings = append(report.Findings,
+					fmt.Sprintf("%s is signed but %s publishes no DS record for it (island of security)", link.Zone, parent))
+			}
+		case link.Validated && link.DSMatchesKey:
+			link.Status = VerdictSecure
+		default:
+			link.Status = VerdictBogus
+			report.BrokenAt = link.Zone
+			report.Findings = append(report.Findings, fmt.Sprintf("chain of trust breaks at %s: %s publishes DS records %v, %s",
+				link.Zone, parent, link.DSKeyTags, brokenLinkReason(link)))
+		}
+
+		if link.Zone == apex {
+			apexStatus = link.Status
+		} else if apexStatus == VerdictSecure && isSubdomain(link.Zone, apex) && link.Status != VerdictSecure {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("%s is delegated below the secure apex %s but is %s", link.Zone, apex, link.Status))
+		}
+		report.Links = append(report.Links, link)
+		parent, parentStatus = link.Zone, link.Status
+	}
+	if len(report.Links) > 0 {
+		report.Status = report.Links[len(report.Links)-1].Status
+	}
+	return report
+}
+
+func chainLink(observation ChainObservation, parent string) models.ChainLink {
+	link := models.ChainLink{Zone: normalizeName(observation.Zone), ParentZone: parent}
+	if observation.DS != nil {
+		for _, ds := range observation.DS.Records {
+			link.DSKeyTags = append(link.DSKeyTags, ds.KeyTag)
+		}
+		link.DSPresent = len(observation.DS.Records) > 0
+	}
+	if observation.DNSKEY != nil {
+		for _, key := range observation.DNSKEY.Records {
+			link.KeyTags = append(link.KeyTags, keyTag(key))
+			if observation.DS != nil {
+				for _, ds := range observation.DS.Records {
+					if dsMatchesDNSKEY(link.Zone, ds, key) {
+						link.DSMatchesKey = true
+					}
+				}
+			}
+		}
+		link.DNSKEYPresent = len(observation.DNSKEY.Records) > 0
+		link.Validated = observation.DNSKEY.Validated && link.DNSKEYPresent
+	}
+	return link
+}
+
+func brokenLinkReason(link models.ChainLink) string {
+	switch {
+	case !link.DNSKEYPresent:
+		return "but the zone publishes no DNSKEY records"
+	case !link.DSMatchesKey:
+		return "but none of them matches a DNSKEY record of the zone"
+	default:
+		return "but the DNSKEY set of the zone does not validate"
+	}
+}
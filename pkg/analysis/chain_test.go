@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+// chainZone returns the observation of a zone signed with the KSK of linkageKSK. withDS adds the
+// matching DS record to the parent side.
+func chainZone(t *testing.T, zone string, signed, withDS, validated bool) ChainObservation {
+	t.Helper()
+	ksk, _ := linkageKSK(t)
+	digest, err := dsDigest(zone, ksk, 2)
+	if err != nil {
+		t.Fatalf("dsDigest: unexpected error: %v", err)
+	}
+	observation := ChainObservation{Zone: zone, DS: &dnsrecords.DSResponse{}, DNSKEY: &dnsrecords.DNSKEYResponse{}}
+	if withDS {
+		observation.DS.Records = []dnsrecords.DSRecord{{KeyTag: ksk.KeyID, Algorithm: 5, DigestType: 2, Digest: digest}}
+	}
+	if signed {
+		observation.DNSKEY.Records = []dnsrecords.DNSKEYRecord{ksk}
+		observation.DNSKEY.Validated = validated
+	}
+	return observation
+}
+
+func TestAnalyzeChainSecure(t *testing.T) {
+	report := AnalyzeChain("www.example.ac.uk", "example.ac.uk", []ChainObservation{
+		chainZone(t, "uk", true, true, true),
+		chainZone(t, "ac.uk", true, true, true),
+		chainZone(t, "example.ac.uk", true, true, true),
+	})
+
+	if report.Status != VerdictSecure || report.BrokenAt != "" || len(report.Findings) != 0 {
+		t.Errorf("Expected a secure chain, got %+v", report)
+	}
+	if len(report.Links) != 3 || report.Links[0].ParentZone != "." || report.Links[2].ParentZone != "ac.uk" ||
+		!report.Links[2].DSMatchesKey {
+		t.Errorf("Unexpected links %+v", report.Links)
+	}
+}
+
+func TestAnalyzeChainBrokenSubzone(t *testing.T) {
+	report := AnalyzeChain("a.cs.example.ac.uk", "example.ac.uk", []ChainObservation{
+		chainZone(t, "uk", true, true, true),
+		chainZone(t, "ac.uk", true, true, true),
+		chainZone(t, "example.ac.uk", true, true, true),
+		chainZone(t, "cs.example.ac.uk", false, true, false),
+	})
+
+	if report.Status != VerdictBogus || report.BrokenAt != "cs.example.ac.uk" {
+		t.Errorf("Expected the chain to break at cs.example.ac.uk, got %s at %s", report.Status, report.BrokenAt)
+	}
+	if len(report.Findings) != 2 || !strings.Contains(report.Findings[0], "publishes no DNSKEY records") ||
+		!strings.Contains(report.Findings[1], "below the secure apex example.ac.uk but is bogus") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeChainIslandOfSecurity(t *testing.T) {
+	report := AnalyzeChain("www.dept.example.de", "example.de", []ChainObservation{
+		chainZone(t, "de", true, true, true),
+		chainZone(t, "example.de", true, true, true),
+		chainZone(t, "dept.example.de", true, false, false),
+	})
+
+	if report.Status != VerdictInsecure || report.BrokenAt != "" {
+		t.Errorf("Expected an insecure delegation, got %s at %s", report.Status, report.BrokenAt)
+	}
+	if len(report.Findings) != 2 || !strings.Contains(report.Findings[0], "island of security") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeChainInsecureParent(t *testing.T) {
+	report := AnalyzeChain("www.example.com", "example.com", []ChainObservation{
+		chainZone(t, "com", true, true, true),
+		chainZone(t, "example.com", false, false, false),
+		chainZone(t, "www.example.com", true, true, false),
+	})
+
+	if report.Status != VerdictInsecure || report.Links[2].Status != VerdictInsecure || len(report.Findings) != 0 {
+		t.Errorf("Expected the insecure parent to make the subzone insecure, got %+v", report)
+	}
+}
@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// tlsaDataLengths are the lengths, in hexadecimal digits, of the certificate association data
+// of the hashed matching types.
+var tlsaDataLengths = map[uint8]int{
+	dnsrecords.TLSAMatchingSHA256: 2 * sha256.Size,
+	dnsrecords.TLSAMatchingSHA512: 2 * sha512.Size,
+}
+
+// AnalyzeDANE decides which endpoints DANE clients would authenticate with their TLSA records
+// and reports records they cannot use: unsigned or bogus answers, unknown parameters, malformed
+// digests, PKIX usages on SMTP, which RFC 7672 tells clients to ignore, and records that do not
+// match the certificate chain presented by the service.
+func AnalyzeDANE(endpoints []models.TLSAEndpoint) *models.DANEReport {
+	report := &models.DANEReport{Endpoints: endpoints}
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if len(endpoint.Records) == 0 {
+			if endpoint.Status == VerdictBogus {
+				report.Findings = append(report.Findings, fmt.Sprintf("the TLSA answer for %s is bogus", endpoint.Name))
+			}
+			continue
+		}
+		switch endpoint.Status {
+		case VerdictSecure:
+			endpoint.Usable = true
+		case VerdictBogus:
+			report.Findings = append(report.Findings, fmt.Sprintf("the TLSA records of %s are bogus, so DANE clients cannot connect to %s:%d",
+				endpoint.Name, endpoint.Host, endpoint.Port))
+		default:
+			report.Findings = append(report.Findings, fmt.Sprintf("the TLSA records of %s are not validated and are ignored by DANE clients",
+				endpoint.Name))
+		}
+		for _, record := range endpoint.Records {
+			report.Findings = append(report.Findings, tlsaRecordFindings(endpoint, record)...)
+		}
+		if endpoint.Certificate != nil && endpoint.Certificate.Error == "" && !endpoint.Certificate.Matched {
+			report.Findings = append(report.Findings, fmt.Sprintf("no TLSA record of %s matches the certificate presented by %s:%d",
+				endpoint.Name, endpoint.Host, endpoint.Port))
+		}
+	}
+	return report
+}
+
+// tlsaRecordFindings reports the problems of a single TLSA record of an endpoint.
+func tlsaRecordFindings(endpoint *models.TLSAEndpoint, record dnsrecords.TLSARecord) []string {
+	var notes []string
+	describe := fmt.Sprintf("TLSA %d %d %d of %s", record.Usage, record.Selector, record.MatchingType, endpoint.Name)
+	if record.UsageName() == "unknown" {
+		notes = append(notes, fmt.Sprintf("%s has the unknown usage %d", describe, record.Usage))
+	}
+	if record.SelectorName() == "unknown" {
+		notes = append(notes, fmt.Sprintf("%s has the unknown selector %d", describe, record.Selector))
+	}
+	if record.MatchingTypeName() == "unknown" {
+		notes = append(notes, fmt.Sprintf("%s has the unknown matching type %d", describe, record.MatchingType))
+	}
+	if length, ok := tlsaDataLengths[record.MatchingType]; ok && len(record.CertificateData) != length {
+		notes = append(notes, fmt.Sprintf("%s holds %d hexadecimal digits where %s needs %d",
+			describe, len(record.CertificateData), record.MatchingTypeName(), length))
+	}
+	if endpoint.Service == "smtp" && (record.Usage == dnsrecords.TLSAUsagePKIXTA || record.Usage == dnsrecords.TLSAUsagePKIXEE) {
+		notes = append(notes, fmt.Sprintf("%s uses %s, which SMTP clients ignore (RFC 7672)", describe, record.UsageName()))
+	}
+	return notes
+}
+
+// MatchTLSA reports whether a TLSA record matches a certificate chain, leaf first. Usages that
+// constrain the end entity (PKIX-EE and DANE-EE) are matched against the leaf only, and usages
+// that constrain a trust anchor (PKIX-TA and DANE-TA) against any certificate of the chain. Only
+// the association is checked; the PKIX path validation the PKIX usages also require is not.
+func MatchTLSA(record dnsrecords.TLSARecord, chain []*x509.Certificate) (bool, error) {
+	if len(chain) == 0 {
+		return false, fmt.Errorf("invalid certificate chain: it must not be empty")
+	}
+	expected, err := hex.DecodeString(record.CertificateData)
+	if err != nil {
+		return false, fmt.Errorf("invalid certificate association data '%s' in TLSA r: %v", record.CertificateData, err)
+	}
+	candidates := chain
+	switch record.Usage {
+	case dnsrecords.TLSAUsagePKIXEE, dnsrecords.TLSAUsageDANEEE:
+		candidates = chain[:1]
+	case dnsrecords.TLSAUsagePKIXTA, dnsrecords.TLSAUsageDANETA:
+	default:
+		return false, fmt.Errorf("invalid usage %d in TLSA r", record.Usage)
+	}
+	for _, certificate := range candidates {
+		var selected []byte
+		switch record.Selector {
+		case dnsrecords.TLSASelectorCert:
+			selected = certificate.Raw
+		case dnsrecords.TLSASelectorSPKI:
+			selected = certificate.RawSubjectPublicKeyInfo
+		default:
+			return false, fmt.Errorf("invalid selector %d in TLSA r", record.Selector)
+		}
+		switch record.MatchingType {
+		case dnsrecords.TLSAMatchingFull:
+		case dnsrecords.TLSAMatchingSHA256:
+			digest := sha256.Sum256(selected)
+			selected = digest[:]
+		case dnsrecords.TLSAMatchingSHA512:
+			digest := sha512.Sum512(selected)
+			selected = digest[:]
+		default:
+			return false, fmt.Errorf("invalid matching type %d in TLSA r", record.MatchingType)
+		}
+		if bytes.Equal(selected, expected) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// presentedChain starts a local TLS server and returns the certificate chain it presents.
+func presentedChain(t *testing.T) []*x509.Certificate {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+	conn, err := tls.Dial("tcp", server.Listener.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to the test server: %v", err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates
+}
+
+func TestMatchTLSA(t *testing.T) {
+	chain := presentedChain(t)
+	leaf := chain[0]
+	spki256 := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+	cert512 := sha512.Sum512(leaf.Raw)
+
+	tests := []struct {
+		name   string
+		record dnsrecords.TLSARecord
+		match  bool
+	}{
+		{"DANE-EE SPKI SHA2-256", dnsrecords.TLSARecord{Usage: 3, Selector: 1, MatchingType: 1, CertificateData: hex.EncodeToString(spki256[:])}, true},
+		{"DANE-EE Cert SHA2-512", dnsrecords.TLSARecord{Usage: 3, Selector: 0, MatchingType: 2, CertificateData: hex.EncodeToString(cert512[:])}, true},
+		{"DANE-TA Cert Full", dnsrecords.TLSARecord{Usage: 2, Selector: 0, MatchingType: 0, CertificateData: hex.EncodeToString(leaf.Raw)}, true},
+		{"DANE-EE SPKI SHA2-256 other key", dnsrecords.TLSARecord{Usage: 3, Selector: 1, MatchingType: 1, CertificateData: strings.Repeat("00", 32)}, false},
+		{"DANE-EE Cert SHA2-256 of SPKI", dnsrecords.TLSARecord{Usage: 3, Selector: 0, MatchingType: 1, CertificateData: hex.EncodeToString(spki256[:])}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := MatchTLSA(tt.record, chain)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if matched != tt.match {
+				t.Errorf("Expected match %v, got %v", tt.match, matched)
+			}
+		})
+	}
+
+	if _, err := MatchTLSA(dnsrecords.TLSARecord{Usage: 3, Selector: 7, MatchingType: 1, CertificateData: "00"}, chain); err == nil {
+		t.Errorf("Expected an error for an unknown selector")
+	}
+}
+
+func TestAnalyzeDANE(t *testing.T) {
+	digest := strings.Repeat("AB", 32)
+	report := AnalyzeDANE([]models.TLSAEndpoint{
+		{Name: "_443._tcp.www.example.pt", Host: "www.example.pt", Port: 443, Service: "https", Status: VerdictSecure,
+			Records:     []dnsrecords.TLSARecord{{Usage: 3, Selector: 1, MatchingType: 1, CertificateData: digest}},
+			Certificate: &models.TLSACertificateCheck{Matched: true}},
+		{Name: "_25._tcp.mail.example.pt", Host: "mail.example.pt", Port: 25, Service: "smtp", Status: VerdictInsecure,
+			Records: []dnsrecords.TLSARecord{{Usage: 1, Selector: 1, MatchingType: 2, CertificateData: digest}}},
+		{Name: "_25._tcp.mx.example.net", Host: "mx.example.net", Port: 25, Service: "smtp", Status: VerdictInsecure},
+	})
+
+	if !report.Endpoints[0].Usable || report.Endpoints[1].Usable || report.Endpoints[2].Usable {
+		t.Errorf("Expected only the secure HTTPS endpoint to be usable, got %+v", report.Endpoints)
+	}
+	expected := []string{"not validated", "needs 128", "PKIX-EE, which SMTP clients ignore"}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), report.Findings)
+	}
+	for i, note := range expected {
+		if !strings.Contains(report.Findings[i], note) {
+			t.Errorf("Expected finding %d to mention %q, got %q", i, note, report.Findings[i])
+		}
+	}
+}
+
+func TestAnalyzeDANECertificateMismatch(t *testing.T) {
+	report := AnalyzeDANE([]models.TLSAEndpoint{
+		{Name: "_443._tcp.www.example.pt", Host: "www.example.pt", Port: 443, Service: "https", Status: VerdictSecure,
+			Records:     []dnsrecords.TLSARecord{{Usage: 3, Selector: 1, MatchingType: 1, CertificateData: strings.Repeat("AB", 32)}},
+			Certificate: &models.TLSACertificateCheck{}},
+	})
+
+	if len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "matches the certificate presented by www.example.pt:443") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
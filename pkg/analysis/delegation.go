@@ -0,0 +1,143 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"sort"
+	"strings"
+)
+
+// DelegationObservation holds the data collected about the delegation of a zone: the referral
+// from the parent, the apex NS record set, the addresses of every name server and the result of
+// probing each address.
+type DelegationObservation struct {
+	Zone       string
+	ParentZone string
+	ParentNS   []string
+	ChildNS    []string
+	Glue       map[string][]string
+	Addresses  map[string][]string
+	Probes     []models.NameServerCheck
+}
+
+// AnalyzeDelegation compares the parent-side and child-side NS record sets, checks the glue of
+// in-bailiwick name servers against their addresses, and collects the lame name servers.
+func AnalyzeDelegation(observation DelegationObservation) *models.DelegationReport {
+	report := &models.DelegationReport{
+		ParentZone:  normalizeName(observation.ParentZone),
+		ParentNS:    normalizeNames(observation.ParentNS),
+		ChildNS:     normalizeNames(observation.ChildNS),
+		NameServers: observation.Probes,
+	}
+
+	if len(report.ParentNS) == 0 {
+		report.Findings = append(report.Findings, "no delegation was obtained from the parent zone")
+	}
+	if len(report.ChildNS) == 0 {
+		report.Findings = append(report.Findings, "no NS records were obtained from the zone apex")
+	}
+	if len(report.ParentNS) > 0 && len(report.ChildNS) > 0 {
+		report.MissingAtChild = difference(report.ParentNS, report.ChildNS)
+		report.MissingAtParent = difference(report.ChildNS, report.ParentNS)
+		for _, ns := range report.MissingAtChild {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s is delegated by the parent but not listed at the apex", ns))
+		}
+		for _, ns := range report.MissingAtParent {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s is listed at the apex but not delegated by the parent", ns))
+		}
+	}
+
+	glueByName := normalizeKeys(observation.Glue)
+	addressesByName := normalizeKeys(observation.Addresses)
+	for _, ns := range union(report.ParentNS, report.ChildNS) {
+		glue := normalizeAddresses(glueByName[ns])
+		addresses := normalizeAddresses(addressesByName[ns])
+		if len(addresses) == 0 {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s does not resolve to any address", ns))
+		}
+		if len(glue) == 0 {
+			if isSubdomain(ns, observation.Zone) {
+				report.Findings = append(report.Findings, fmt.Sprintf("%s is in-bailiwick but has no glue", ns))
+			}
+			continue
+		}
+		check := models.GlueCheck{
+			NameServer:    ns,
+			GlueAddresses: glue,
+			Addresses:     addresses,
+			Matches:       len(difference(glue, addresses)) == 0 && len(difference(addresses, glue)) == 0,
+		}
+		if !check.Matches {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("glue for %s (%s) does not match its addresses (%s)", ns,
+					strings.Join(glue, ", "), strings.Join(addresses, ", ")))
+		}
+		report.Glue = append(report.Glue, check)
+	}
+
+	for _, probe := range observation.Probes {
+		if !probe.Lame {
+			continue
+		}
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("%s (%s) is lame: %s", probe.NameServer, probe.Address, probe.Reason))
+		report.LameServers = union(report.LameServers, []string{normalizeName(probe.NameServer)})
+	}
+
+	report.Consistent = len(report.Findings) == 0
+	return report
+}
+
+func normalizeNames(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		normalized = union(normalized, []string{normalizeName(name)})
+	}
+	return normalized
+}
+
+func normalizeKeys(addresses map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(addresses))
+	for name, values := range addresses {
+		normalized[normalizeName(name)] = append(normalized[normalizeName(name)], values...)
+	}
+	return normalized
+}
+
+func normalizeAddresses(addresses []string) []string {
+	var normalized []string
+	for _, address := range addresses {
+		normalized = union(normalized, []string{strings.ToLower(address)})
+	}
+	return normalized
+}
+
+// difference returns the sorted elements of a that are not in b.
+func difference(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, v := range b {
+		present[v] = true
+	}
+	var result []string
+	for _, v := range a {
+		if !present[v] {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// union returns the sorted, de-duplicated elements of a and b.
+func union(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var result []string
+	for _, v := range append(append([]string{}, a...), b...) {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
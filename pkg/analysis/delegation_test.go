@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeDelegationConsistent(t *testing.T) {
+	report := AnalyzeDelegation(DelegationObservation{
+		Zone:       "example.com",
+		ParentZone: "com",
+		ParentNS:   []string{"ns1.example.com.", "NS2.example.net."},
+		ChildNS:    []string{"ns2.example.net", "ns1.example.com"},
+		Glue:       map[string][]string{"ns1.example.com.": {"192.0.2.53", "2001:DB8::53"}},
+		Addresses: map[string][]string{
+			"ns1.example.com": {"2001:db8::53", "192.0.2.53"},
+			"ns2.example.net": {"198.51.100.53"},
+		},
+		Probes: []models.NameServerCheck{
+			{NameServer: "ns1.example.com", Address: "192.0.2.53"},
+			{NameServer: "ns2.example.net", Address: "198.51.100.53"},
+		},
+	})
+
+	if !report.Consistent {
+		t.Fatalf("Expected a consistent delegation, got findings %v", report.Findings)
+	}
+	if len(report.Glue) != 1 || !report.Glue[0].Matches {
+		t.Errorf("Expected matching glue for ns1.example.com, got %+v", report.Glue)
+	}
+}
+
+func TestAnalyzeDelegationDiscrepancies(t *testing.T) {
+	report := AnalyzeDelegation(DelegationObservation{
+		Zone:       "example.com",
+		ParentZone: "com",
+		ParentNS:   []string{"ns1.example.com", "ns2.example.com", "old.example.net"},
+		ChildNS:    []string{"ns1.example.com", "ns2.example.com", "ns3.example.org"},
+		Glue:       map[string][]string{"ns1.example.com": {"192.0.2.1"}},
+		Addresses: map[string][]string{
+			"ns1.example.com": {"192.0.2.53"},
+			"ns2.example.com": {"192.0.2.54"},
+			"ns3.example.org": {"203.0.113.53"},
+		},
+		Probes: []models.NameServerCheck{
+			{NameServer: "ns1.example.com", Address: "192.0.2.53"},
+			{NameServer: "ns2.example.com", Address: "192.0.2.54", Lame: true, Reason: "the server answered REFUSED"},
+			{NameServer: "ns3.example.org", Address: "203.0.113.53"},
+		},
+	})
+
+	if report.Consistent {
+		t.Fatalf("Expected an inconsistent delegation")
+	}
+	if !reflect.DeepEqual(report.MissingAtChild, []string{"old.example.net"}) {
+		t.Errorf("Unexpected MissingAtChild %v", report.MissingAtChild)
+	}
+	if !reflect.DeepEqual(report.MissingAtParent, []string{"ns3.example.org"}) {
+		t.Errorf("Unexpected MissingAtParent %v", report.MissingAtParent)
+	}
+	if len(report.Glue) != 1 || report.Glue[0].Matches {
+		t.Errorf("Expected mismatched glue for ns1.example.com, got %+v", report.Glue)
+	}
+	if !reflect.DeepEqual(report.LameServers, []string{"ns2.example.com"}) {
+		t.Errorf("Unexpected LameServers %v", report.LameServers)
+	}
+	// Missing at child, missing at parent, old.example.net unresolvable, wrong glue,
+	// missing glue for ns2.example.com, and the lame server.
+	if len(report.Findings) != 6 {
+		t.Errorf("Expected 6 findings, got %d: %v", len(report.Findings), report.Findings)
+	}
+}
@@ -0,0 +1,202 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// Denial of existence methods reported in DenialOfExistenceReport.Method.
+const (
+	DenialMethodNSEC3 = "NSEC3"
+	DenialMethodNSEC  = "NSEC"
+	DenialMethodNone  = "none"
+)
+
+// AnalyzeDenialOfExistence evaluates the negative proof returned for queryName, a name
+// that is not expected to exist. For NSEC3 it verifies the closest encloser proof of
+// RFC 5155, Section 7.2.1: a record matching the closest encloser, a record covering the
+// next closer name and a record covering the wildcard at the closest encloser. For NSEC it
+// verifies that the query name and the wildcard at the closest encloser are covered, as
+// described in RFC 4035, Section 3.1.3.2.
+func AnalyzeDenialOfExistence(queryName string, response *dnsrecords.DenialResponse) *models.DenialOfExistenceReport {
+	report := &models.DenialOfExistenceReport{
+		QueryName: normalizeName(queryName),
+		Method:    DenialMethodNone,
+		Response:  response,
+	}
+	if response == nil {
+		report.Findings = append(report.Findings, "no negative response was obtained")
+		return report
+	}
+	report.NXDomain = response.NXDomain
+	report.Validated = response.Validated
+
+	if !response.NXDomain {
+		report.Findings = append(report.Findings,
+			"the probe name was not reported as non-existent (wildcard or synthesized answer)")
+	}
+	if !response.Validated {
+		report.Findings = append(report.Findings, "the negative response was not validated")
+	}
+
+	switch {
+	case len(response.NSEC3Records) > 0:
+		report.Method = DenialMethodNSEC3
+		analyzeNSEC3Proof(report, response.NSEC3Records)
+	case len(response.NSECRecords) > 0:
+		report.Method = DenialMethodNSEC
+		analyzeNSECProof(report, response.NSECRecords)
+	default:
+		report.Findings = append(report.Findings, "no NSEC or NSEC3 records were returned")
+		return report
+	}
+
+	report.ProofComplete = report.ClosestEncloserProven && report.NextCloserCovered && report.WildcardCovered
+	if !report.ProofComplete {
+		report.Findings = append(report.Findings, "the denial of existence proof is incomplete")
+	}
+	return report
+}
+
+func analyzeNSEC3Proof(report *models.DenialOfExistenceReport, records []dnsrecords.NSEC3Record) {
+	params := records[0]
+	report.HashAlgorithm = params.HashAlgorithm
+	report.Iterations = params.Iterations
+	report.Salt = params.Salt
+	for _, record := range records {
+		if record.OptOut {
+			report.OptOut = true
+		}
+		if record.HashAlgorithm != params.HashAlgorithm || record.Iterations != params.Iterations ||
+			record.Salt != params.Salt {
+			report.Findings = append(report.Findings, "NSEC3 records use inconsistent hash parameters")
+			break
+		}
+	}
+	if report.OptOut {
+		report.Findings = append(report.Findings, "NSEC3 opt-out is in use")
+	}
+	if report.Iterations > 0 {
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("NSEC3 uses %d additional iterations, RFC 9276 recommends 0", report.Iterations))
+	}
+	if report.Salt != "" {
+		report.Findings = append(report.Findings, "NSEC3 uses a salt, RFC 9276 recommends none")
+	}
+	for _, record := range records {
+		if record.RRSIG == nil {
+			report.Findings = append(report.Findings, fmt.Sprintf("NSEC3 record %s has no signature", record.OwnerName))
+		}
+	}
+
+	hash := func(name string) string {
+		h, err := nsec3Hash(name, params.HashAlgorithm, params.Iterations, params.Salt)
+		if err != nil {
+			return ""
+		}
+		return h
+	}
+	matching := func(name string) bool {
+		h := hash(name)
+		for _, record := range records {
+			if h != "" && record.HashedOwner() == h {
+				return true
+			}
+		}
+		return false
+	}
+	covering := func(name string) bool {
+		h := hash(name)
+		for _, record := range records {
+			if h != "" && nsec3Covers(record.HashedOwner(), record.NextHashedOwner, h) {
+				return true
+			}
+		}
+		return false
+	}
+	if hash(report.QueryName) == "" {
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("unsupported NSEC3 hash algorithm %d", params.HashAlgorithm))
+		return
+	}
+
+	zone := normalizeName(params.Zone())
+	nextCloser := report.QueryName
+	for candidate := parentName(report.QueryName); candidate != ""; candidate = parentName(candidate) {
+		if matching(candidate) {
+			report.ClosestEncloser = candidate
+			report.ClosestEncloserProven = true
+			break
+		}
+		if candidate == zone {
+			break
+		}
+		nextCloser = candidate
+	}
+	if !report.ClosestEncloserProven {
+		report.Findings = append(report.Findings, "no NSEC3 record matches the closest encloser")
+		return
+	}
+	report.NextCloserCovered = covering(nextCloser)
+	if !report.NextCloserCovered {
+		report.Findings = append(report.Findings, fmt.Sprintf("no NSEC3 record covers the next closer name %s", nextCloser))
+	}
+	report.WildcardCovered = covering("*." + report.ClosestEncloser)
+	if !report.WildcardCovered {
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("no NSEC3 record covers the wildcard *.%s", report.ClosestEncloser))
+	}
+}
+
+func analyzeNSECProof(report *models.DenialOfExistenceReport, records []dnsrecords.NSECProofRecord) {
+	for _, record := range records {
+		if record.RRSIG == nil {
+			report.Findings = append(report.Findings, fmt.Sprintf("NSEC record %s has no signature", record.OwnerName))
+		}
+	}
+
+	for _, record := range records {
+		if !nsecCovers(record.OwnerName, record.NextDomainName, report.QueryName) {
+			continue
+		}
+		report.NextCloserCovered = true
+		report.ClosestEncloserProven = true
+		report.ClosestEncloser = longerName(commonAncestor(report.QueryName, record.OwnerName),
+			commonAncestor(report.QueryName, record.NextDomainName))
+		break
+	}
+	if !report.NextCloserCovered {
+		report.Findings = append(report.Findings, "no NSEC record covers the query name")
+		return
+	}
+
+	wildcard := "*." + report.ClosestEncloser
+	for _, record := range records {
+		if nsecCovers(record.OwnerName, record.NextDomainName, wildcard) {
+			report.WildcardCovered = true
+			break
+		}
+	}
+	if !report.WildcardCovered {
+		report.Findings = append(report.Findings, fmt.Sprintf("no NSEC record covers the wildcard %s", wildcard))
+	}
+}
+
+// commonAncestor returns the longest name that is an ancestor of (or equal to) both names.
+func commonAncestor(a, b string) string {
+	la, lb := labels(a), labels(b)
+	var common []string
+	for i, j := len(la)-1, len(lb)-1; i >= 0 && j >= 0 && la[i] == lb[j]; i, j = i-1, j-1 {
+		common = append([]string{la[i]}, common...)
+	}
+	return strings.Join(common, ".")
+}
+
+func longerName(a, b string) string {
+	if len(labels(b)) > len(labels(a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+func TestNSEC3Hash(t *testing.T) {
+	// Test vectors from RFC 5155, Appendix A.
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"example", "0P9MHAVEQVM6T7VBL5LOP2U3T2RP3TOM"},
+		{"a.example", "35MTHGPGCU1QG68FAB165KLNSNK3DPVL"},
+		{"x.w.example", "B4UM86EGHHDS6NEA196SMVMLO4ORS995"},
+	}
+	for _, tc := range testCases {
+		hash, err := nsec3Hash(tc.name, 1, 12, "aabbccdd")
+		if err != nil {
+			t.Fatalf("nsec3Hash(%s): unexpected error: %v", tc.name, err)
+		}
+		if hash != tc.expected {
+			t.Errorf("nsec3Hash(%s): expected %s, got %s", tc.name, tc.expected, hash)
+		}
+	}
+}
+
+func TestCanonicalCompare(t *testing.T) {
+	// Canonical order example from RFC 4034, Section 6.1.
+	ordered := []string{"example", "a.example", "yljkjljk.a.example", "Z.a.example",
+		"zABC.a.EXAMPLE", "z.example", "*.z.example"}
+	for i := 0; i < len(ordered)-1; i++ {
+		if canonicalCompare(ordered[i], ordered[i+1]) >= 0 {
+			t.Errorf("Expected %s to sort before %s", ordered[i], ordered[i+1])
+		}
+	}
+}
+
+func TestAnalyzeDenialOfExistenceNSEC3(t *testing.T) {
+	response := &dnsrecords.DenialResponse{
+		NXDomain:  true,
+		Validated: true,
+		NSEC3Records: []dnsrecords.NSEC3Record{
+			{OwnerName: "0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.example", HashAlgorithm: 1, Flags: 1, OptOut: true,
+				Iterations: 12, Salt: "AABBCCDD", NextHashedOwner: "2T7B4G4VSA5SMI47K61MV5BV1A22BOJR"},
+			{OwnerName: "b4um86eghhds6nea196smvmlo4ors995.example", HashAlgorithm: 1, Flags: 1, OptOut: true,
+				Iterations: 12, Salt: "AABBCCDD", NextHashedOwner: "GJEQE526PLBF1G8MKLP59ENFD789NJGI"},
+			{OwnerName: "35mthgpgcu1qg68fab165klnsnk3dpvl.example", HashAlgorithm: 1, Flags: 1, OptOut: true,
+				Iterations: 12, Salt: "AABBCCDD", NextHashedOwner: "B4UM86EGHHDS6NEA196SMVMLO4ORS995"},
+		},
+	}
+
+	report := AnalyzeDenialOfExistence("a.c.x.w.example", response)
+
+	if report.Method != DenialMethodNSEC3 {
+		t.Errorf("Expected method NSEC3, got %s", report.Method)
+	}
+	if report.ClosestEncloser != "x.w.example" {
+		t.Errorf("Expected closest encloser x.w.example, got %s", report.ClosestEncloser)
+	}
+	if !report.ProofComplete {
+		t.Errorf("Expected a complete proof, got %+v", report)
+	}
+	if !report.OptOut || report.Iterations != 12 || report.Salt != "AABBCCDD" {
+		t.Errorf("Unexpected NSEC3 parameters in report: %+v", report)
+	}
+
+	response.NSEC3Records = response.NSEC3Records[:2]
+	report = AnalyzeDenialOfExistence("a.c.x.w.example", response)
+	if report.ProofComplete || report.WildcardCovered {
+		t.Errorf("Expected an incomplete proof without the wildcard record, got %+v", report)
+	}
+	if !report.NextCloserCovered {
+		t.Errorf("Expected the next closer name to remain covered")
+	}
+}
+
+func TestAnalyzeDenialOfExistenceNSEC(t *testing.T) {
+	response := &dnsrecords.DenialResponse{
+		NXDomain:  true,
+		Validated: true,
+		NSECRecords: []dnsrecords.NSECProofRecord{
+			{OwnerName: "example.org", NextDomainName: "mail.example.org", Types: "A;NS;SOA;RRSIG;NSEC;DNSKEY"},
+			{OwnerName: "mail.example.org", NextDomainName: "www.example.org", Types: "A;RRSIG;NSEC"},
+		},
+	}
+
+	report := AnalyzeDenialOfExistence("nothere.example.org", response)
+
+	if report.Method != DenialMethodNSEC {
+		t.Errorf("Expected method NSEC, got %s", report.Method)
+	}
+	if report.ClosestEncloser != "example.org" {
+		t.Errorf("Expected closest encloser example.org, got %s", report.ClosestEncloser)
+	}
+	if !report.ProofComplete {
+		t.Errorf("Expected a complete proof, got %+v", report)
+	}
+}
+
+func TestAnalyzeDenialOfExistenceWithoutProof(t *testing.T) {
+	report := AnalyzeDenialOfExistence("probe.example.org", &dnsrecords.DenialResponse{NXDomain: true})
+	if report.Method != DenialMethodNone || report.ProofComplete {
+		t.Errorf("Expected no denial method and an incomplete proof, got %+v", report)
+	}
+}
+
+func TestAnalyzeDenialOfExistenceShortFormSignatures(t *testing.T) {
+	response := `;; resolution failed: ncache nxdomain
+; negative response, fully validated
+; dnssec-probe-3f9a1c2b7d4e.uminho.pt.  300     IN      \-ANY   ;-$NXDOMAIN
+; 0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.uminho.pt. RRSIG NSEC3 ...
+; 0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.uminho.pt. NSEC3 1 0 0 - 0P9MHAVEQVM6T7VBL5LOP2U3T2RP3TOO NS SOA MX TXT RRSIG DNSKEY NSEC3PARAM`
+	result, err := (&dnsrecords.DenialResponse{}).Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse denial response: %v", err)
+	}
+	report := AnalyzeDenialOfExistence("dnssec-probe-3f9a1c2b7d4e.uminho.pt", result.(*dnsrecords.DenialResponse))
+
+	if report.Method != DenialMethodNSEC3 {
+		t.Errorf("Expected the NSEC3 method, got %s", report.Method)
+	}
+	for _, finding := range report.Findings {
+		if strings.Contains(finding, "no signature") || strings.Contains(finding, "no negative response") {
+			t.Errorf("Unexpected finding %q", finding)
+		}
+	}
+}
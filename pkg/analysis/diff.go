@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"slices"
+)
+
+// DiffAssessments compares two assessments of the same domain and reports what changed from
+// previous to current: the DNSSEC status, the published keys and their algorithms, the DS
+// records held by the parent, the denial of existence method, the grade and the findings.
+//
+// Findings of the authoritative server and resolver comparisons are left out, since they
+// mention serial numbers and per-resolver answers that change between scans without any
+// change to the deployment.
+func DiffAssessments(previous, current *models.Assessment) *models.AssessmentDiff {
+	diff := &models.AssessmentDiff{
+		Domain:         current.Domain,
+		PreviousStart:  previous.Start,
+		CurrentStart:   current.Start,
+		PreviousStatus: AssessmentStatus(previous),
+		CurrentStatus:  AssessmentStatus(current),
+		PreviousDenial: denialMethod(previous),
+		CurrentDenial:  denialMethod(current),
+		PreviousGrade:  grade(previous),
+		CurrentGrade:   grade(current),
+	}
+	previousKeys, previousAlgorithms := publishedKeys(previous)
+	currentKeys, currentAlgorithms := publishedKeys(current)
+	diff.KeysAdded, diff.KeysRemoved = setDifference(previousKeys, currentKeys)
+	diff.AlgorithmsAdded, diff.AlgorithmsRemoved = setDifference(previousAlgorithms, currentAlgorithms)
+	diff.DSAdded, diff.DSRemoved = setDifference(dsKeyTags(previous), dsKeyTags(current))
+	diff.NewFindings, diff.ResolvedFindings = setDifference(findings(previous), findings(current))
+	return diff
+}
+
+// publishedKeys returns the key tags and the algorithms of the DNSKEY records of an assessment.
+func publishedKeys(assessment *models.Assessment) ([]uint16, []uint8) {
+	var tags []uint16
+	var algorithms []uint8
+	if dnskey := dnskeyResponse(assessment); dnskey != nil {
+		for _, key := range dnskey.Records {
+			tags = append(tags, keyTag(key))
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return tags, algorithms
+}
+
+func dsKeyTags(assessment *models.Assessment) []uint16 {
+	var tags []uint16
+	if ds := dsResponse(assessment); ds != nil {
+		for _, record := range ds.Records {
+			tags = append(tags, record.KeyTag)
+		}
+	}
+	return tags
+}
+
+func denialMethod(assessment *models.Assessment) string {
+	if assessment.DenialOfExistence == nil {
+		return ""
+	}
+	return assessment.DenialOfExistence.Method
+}
+
+func grade(assessment *models.Assessment) string {
+	if assessment.Score == nil {
+		return ""
+	}
+	return assessment.Score.Grade
+}
+
+// findings collects the findings of the reports of an assessment, each prefixed with the part
+// of the assessment that reported it.
+func findings(assessment *models.Assessment) []string {
+	var all []string
+	add := func(source string, notes []string) {
+		for _, note := range notes {
+			all = append(all, source+": "+note)
+		}
+	}
+	if assessment.Score != nil {
+		for _, criterion := range assessment.Score.Breakdown {
+			add(criterion.Criterion, criterion.Findings)
+		}
+	}
+	if assessment.DenialOfExistence != nil {
+		add("denial_of_existence", assessment.DenialOfExistence.Findings)
+	}
+	if assessment.CDS != nil {
+		add("cds", assessment.CDS.Findings)
+	}
+	if assessment.KeyLinkage != nil {
+		add("key_linkage", assessment.KeyLinkage.Findings)
+	}
+	if assessment.Rollover != nil {
+		add("rollover", assessment.Rollover.Findings)
+	}
+	if assessment.Delegation != nil {
+		add("delegation", assessment.Delegation.Findings)
+	}
+	if assessment.Chain != nil {
+		add("chain", assessment.Chain.Findings)
+	}
+	if assessment.Aliases != nil {
+		add("aliases", assessment.Aliases.Findings)
+	}
+	if assessment.DANE != nil {
+		add("dane", assessment.DANE.Findings)
+	}
+	if assessment.CAA != nil {
+		add("caa", assessment.CAA.Findings)
+	}
+	if assessment.Mail != nil {
+		add("mail", assessment.Mail.Findings)
+	}
+	if assessment.ServiceBinding != nil {
+		add("service_binding", assessment.ServiceBinding.Findings)
+	}
+	if assessment.ZONEMD != nil {
+		add("zonemd", assessment.ZONEMD.Findings)
+	}
+	if assessment.TrustAnchor != nil {
+		add("trust_anchor", assessment.TrustAnchor.Findings)
+	}
+	return all
+}
+
+// setDifference returns the distinct values present only in current and only in previous, sorted.
+func setDifference[T uint8 | uint16 | string](previous, current []T) (added, removed []T) {
+	for _, value := range current {
+		if !slices.Contains(previous, value) && !slices.Contains(added, value) {
+			added = append(added, value)
+		}
+	}
+	for _, value := range previous {
+		if !slices.Contains(current, value) && !slices.Contains(removed, value) {
+			removed = append(removed, value)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
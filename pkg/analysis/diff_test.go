@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDiffAssessmentsUnchanged(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	previous := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key}, []dnsrecords.DSRecord{ds}, nil)
+	current := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{rfc4034Key, ksk}, []dnsrecords.DSRecord{ds}, nil)
+
+	diff := DiffAssessments(previous, current)
+
+	if diff.HasChanges() {
+		t.Errorf("Expected no changes, got %+v", diff)
+	}
+	if diff.CurrentStatus != VerdictSecure {
+		t.Errorf("Expected status %s, got %s", VerdictSecure, diff.CurrentStatus)
+	}
+}
+
+func TestDiffAssessmentsChanges(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	ecdsa := dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 13,
+		PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="}
+	previous := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key}, []dnsrecords.DSRecord{ds}, nil)
+	previous.DenialOfExistence = &models.DenialOfExistenceReport{Method: DenialMethodNSEC,
+		Findings: []string{"NSEC allows zone enumeration"}}
+	previous.Score = &models.SecurityScore{Grade: "B"}
+	current := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ecdsa}, []dnsrecords.DSRecord{rfc4034DS}, nil)
+	current.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse).Validated = false
+	current.DenialOfExistence = &models.DenialOfExistenceReport{Method: DenialMethodNSEC3,
+		Findings: []string{"NSEC3 uses 10 additional iterations"}}
+	current.Score = &models.SecurityScore{Grade: "F"}
+
+	diff := DiffAssessments(previous, current)
+
+	if !diff.StatusChanged() || diff.PreviousStatus != VerdictSecure || diff.CurrentStatus != VerdictBogus {
+		t.Errorf("Expected a secure to bogus transition, got %s to %s", diff.PreviousStatus, diff.CurrentStatus)
+	}
+	removed := []uint16{ksk.KeyID, 60485}
+	slices.Sort(removed)
+	if !reflect.DeepEqual(diff.KeysAdded, []uint16{2371}) || !reflect.DeepEqual(diff.KeysRemoved, removed) {
+		t.Errorf("Unexpected key changes: added %v, removed %v", diff.KeysAdded, diff.KeysRemoved)
+	}
+	if !reflect.DeepEqual(diff.AlgorithmsAdded, []uint8{13}) || !reflect.DeepEqual(diff.AlgorithmsRemoved, []uint8{5}) {
+		t.Errorf("Unexpected algorithm changes: added %v, removed %v", diff.AlgorithmsAdded, diff.AlgorithmsRemoved)
+	}
+	if !reflect.DeepEqual(diff.DSAdded, []uint16{rfc4034DS.KeyTag}) || !reflect.DeepEqual(diff.DSRemoved, []uint16{ds.KeyTag}) {
+		t.Errorf("Unexpected DS changes: added %v, removed %v", diff.DSAdded, diff.DSRemoved)
+	}
+	if !diff.DenialChanged() || diff.PreviousDenial != DenialMethodNSEC || diff.CurrentDenial != DenialMethodNSEC3 {
+		t.Errorf("Expected an NSEC to NSEC3 transition, got %s to %s", diff.PreviousDenial, diff.CurrentDenial)
+	}
+	if diff.PreviousGrade != "B" || diff.CurrentGrade != "F" {
+		t.Errorf("Expected grade B to F, got %s to %s", diff.PreviousGrade, diff.CurrentGrade)
+	}
+	if !reflect.DeepEqual(diff.NewFindings, []string{"denial_of_existence: NSEC3 uses 10 additional iterations"}) ||
+		!reflect.DeepEqual(diff.ResolvedFindings, []string{"denial_of_existence: NSEC allows zone enumeration"}) {
+		t.Errorf("Unexpected finding changes: new %v, resolved %v", diff.NewFindings, diff.ResolvedFindings)
+	}
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// dsDigest computes the upper-case hexadecimal DS digest of a DNSKEY owned by owner,
+// using the given digest type (RFC 4034, Section 5.1.4 and RFC 6605).
+func dsDigest(owner string, key dnsrecords.DNSKEYRecord, digestType uint8) (string, error) {
+	rdata, err := key.RData()
+	if err != nil {
+		return "", err
+	}
+	input := append(wireName(owner), rdata...)
+	var digest []byte
+	switch digestType {
+	case 1:
+		sum := sha1.Sum(input)
+		digest = sum[:]
+	case 2:
+		sum := sha256.Sum256(input)
+		digest = sum[:]
+	case 4:
+		sum := sha512.Sum384(input)
+		digest = sum[:]
+	default:
+		return "", fmt.Errorf("unsupported DS digest type %d", digestType)
+	}
+	return strings.ToUpper(hex.EncodeToString(digest)), nil
+}
+
+// supportedDigestType reports whether dsDigest can compute digests of the given type.
+func supportedDigestType(digestType uint8) bool {
+	return digestType == 1 || digestType == 2 || digestType == 4
+}
+
+// dsMatchesDNSKEY reports whether a DS (or CDS) record is the digest of the given DNSKEY.
+func dsMatchesDNSKEY(owner string, ds dnsrecords.DSRecord, key dnsrecords.DNSKEYRecord) bool {
+	if ds.Algorithm != key.Algorithm {
+		return false
+	}
+	digest, err := dsDigest(owner, key, ds.DigestType)
+	return err == nil && strings.EqualFold(digest, ds.Digest)
+}
+
+// sameDNSKEY reports whether two DNSKEY (or CDNSKEY) records carry the same key data.
+func sameDNSKEY(a, b dnsrecords.DNSKEYRecord) bool {
+	return a.Flags == b.Flags && a.Protocol == b.Protocol && a.Algorithm == b.Algorithm &&
+		a.PublicKey == b.PublicKey
+}
+
+// sameDS reports whether two DS (or CDS) records are equal.
+func sameDS(a, b dnsrecords.DSRecord) bool {
+	return a.KeyTag == b.KeyTag && a.Algorithm == b.Algorithm && a.DigestType == b.DigestType &&
+		strings.EqualFold(a.Digest, b.Digest)
+}
+
+// keyTag returns the key tag of a DNSKEY computed from its RDATA (RFC 4034, Appendix B). Keys whose
+// public key cannot be decoded keep the key tag they were given.
+func keyTag(key dnsrecords.DNSKEYRecord) uint16 {
+	if tag, err := key.ComputeKeyTag(); err == nil {
+		return tag
+	}
+	return key.KeyID
+}
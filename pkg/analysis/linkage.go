@@ -0,0 +1,170 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"sort"
+)
+
+// keySetTypes are the record types a key signing key signs.
+var keySetTypes = map[string]bool{"DNSKEY": true, "CDS": true, "CDNSKEY": true}
+
+// AnalyzeKeyLinkage matches every RRSIG of the assessment to the DNSKEY that produced it, using
+// the key tag computed from the key RDATA and the algorithm. Signatures of other zones are listed
+// but not matched. Keys that sign nothing are reported as stand-by when the parent holds a DS
+// record for them and as pre-published otherwise; signatures made by keys that are not published,
+// a DNSKEY set not signed by any key with a DS record, and algorithms that sign nothing are
+// reported as broken rollovers.
+func AnalyzeKeyLinkage(assessment *models.Assessment) *models.KeyLinkageReport {
+	zone := normalizeName(assessment.Domain)
+	report := &models.KeyLinkageReport{Zone: zone}
+	var keys []dnsrecords.DNSKEYRecord
+	if dnskey := dnskeyResponse(assessment); dnskey != nil {
+		keys = dnskey.Records
+	}
+	var dsRecords []dnsrecords.DSRecord
+	if ds := dsResponse(assessment); ds != nil {
+		dsRecords = ds.Records
+	}
+	sigs := linkedSignatures(assessment)
+	if len(keys) == 0 && len(sigs) == 0 {
+		report.Findings = append(report.Findings, fmt.Sprintf("zone %s publishes no DNSKEY records and no signatures", zone))
+		return report
+	}
+
+	report.Keys = make([]models.KeyUsage, len(keys))
+	for i, key := range keys {
+		report.Keys[i] = models.KeyUsage{KeyTag: keyTag(key), Flags: key.Flags, Algorithm: key.Algorithm}
+		for _, ds := range dsRecords {
+			if dsMatchesDNSKEY(zone, ds, key) {
+				report.Keys[i].HasDS = true
+				break
+			}
+		}
+	}
+
+	missing := make(map[uint16]bool)
+	for _, sig := range sigs {
+		link := models.SignatureLink{TypeCovered: sig.TypeCovered, KeyTag: sig.KeyTag, Algorithm: sig.Algorithm, SignerName: sig.SignerName}
+		if normalizeName(sig.SignerName) == zone {
+			// Key tags are not unique, so a signature is linked to every key it may come from.
+			for i := range report.Keys {
+				usage := &report.Keys[i]
+				if usage.KeyTag == sig.KeyTag && usage.Algorithm == sig.Algorithm {
+					link.KeyFound = true
+					if !slices.Contains(usage.SignedTypes, sig.TypeCovered) {
+						usage.SignedTypes = append(usage.SignedTypes, sig.TypeCovered)
+					}
+				}
+			}
+			if !link.KeyFound {
+				if !missing[sig.KeyTag] {
+					missing[sig.KeyTag] = true
+					report.MissingKeys = append(report.MissingKeys, sig.KeyTag)
+				}
+				report.Findings = append(report.Findings, fmt.Sprintf("the RRSIG over %s references key %d (algorithm %d), which is not published in zone %s",
+					sig.TypeCovered, sig.KeyTag, sig.Algorithm, zone))
+			}
+		}
+		report.Signatures = append(report.Signatures, link)
+	}
+
+	dnskeySignedWithDS := false
+	dnskeySigned := false
+	signingAlgorithms := make(map[uint8]bool)
+	for i := range report.Keys {
+		usage := &report.Keys[i]
+		usage.Role = keyRole(usage.SignedTypes)
+		if len(usage.SignedTypes) > 0 {
+			signingAlgorithms[usage.Algorithm] = true
+		}
+		if slices.Contains(usage.SignedTypes, "DNSKEY") {
+			dnskeySigned = true
+			dnskeySignedWithDS = dnskeySignedWithDS || usage.HasDS
+		}
+		switch {
+		case usage.Flags&dnskeyFlagRevoke != 0:
+			usage.Status = models.KeyStatusRevoked
+		case len(usage.SignedTypes) > 0:
+			usage.Status = models.KeyStatusActive
+		case usage.HasDS:
+			usage.Status = models.KeyStatusStandby
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("key %d is a stand-by key: the parent holds a DS record for it but it signs nothing", usage.KeyTag))
+		default:
+			usage.Status = models.KeyStatusPrePublished
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("key %d is published but signs nothing (pre-published or left over from a rollover)", usage.KeyTag))
+		}
+		if len(usage.SignedTypes) == 0 {
+			report.UnusedKeys = append(report.UnusedKeys, usage.KeyTag)
+		}
+	}
+
+	if len(dsRecords) > 0 && dnskeySigned && !dnskeySignedWithDS {
+		report.Findings = append(report.Findings,
+			"no key referenced by a DS record signs the DNSKEY set: the KSK was rolled without updating the DS records")
+	}
+	for _, algorithm := range keyAlgorithms(report.Keys) {
+		if len(signingAlgorithms) > 0 && !signingAlgorithms[algorithm] {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("algorithm %d has published keys but signs nothing, although RFC 4035 Section 2.2 requires every algorithm of the DNSKEY set to sign the zone (incomplete algorithm rollover)", algorithm))
+		}
+	}
+	return report
+}
+
+// linkedSignatures returns the signatures to link to keys: every signature of every record set,
+// since a set is signed by more than one key during rollovers and, for the DNSKEY set, in zones
+// that separate KSK and ZSK roles. The signatures of the DNSKEY set come first.
+func linkedSignatures(assessment *models.Assessment) []dnsrecords.RRSIGRecord {
+	byType := signatures(assessment)
+	sigs := append([]dnsrecords.RRSIGRecord(nil), byType["DNSKEY"]...)
+	delete(byType, "DNSKEY")
+	recordTypes := make([]string, 0, len(byType))
+	for recordType := range byType {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Strings(recordTypes)
+	for _, recordType := range recordTypes {
+		sigs = append(sigs, byType[recordType]...)
+	}
+	return sigs
+}
+
+func keyRole(signedTypes []string) string {
+	signsKeys, signsData := false, false
+	for _, recordType := range signedTypes {
+		if keySetTypes[recordType] {
+			signsKeys = true
+		} else {
+			signsData = true
+		}
+	}
+	switch {
+	case signsKeys && signsData:
+		return "CSK"
+	case signsKeys:
+		return "KSK"
+	case signsData:
+		return "ZSK"
+	default:
+		return ""
+	}
+}
+
+// keyAlgorithms returns the algorithms of the keys that are not revoked, in ascending order.
+func keyAlgorithms(keys []models.KeyUsage) []uint8 {
+	seen := make(map[uint8]bool)
+	var algorithms []uint8
+	for _, key := range keys {
+		if key.Flags&dnskeyFlagRevoke == 0 && !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	sort.Slice(algorithms, func(i, j int) bool { return algorithms[i] < algorithms[j] })
+	return algorithms
+}
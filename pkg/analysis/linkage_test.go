@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+func newLinkageAssessment(t *testing.T, keys []dnsrecords.DNSKEYRecord, ds []dnsrecords.DSRecord,
+	dnskeySigs []dnsrecords.RRSIGRecord) *models.Assessment {
+	t.Helper()
+	assessment := models.NewAssessment("https://dskey.example.com", "dskey.example.com")
+	assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{Records: keys, RRSIGs: dnskeySigs, Validated: true}
+	assessment.Records["DS"] = &dnsrecords.DSResponse{Records: ds, Validated: true}
+	return assessment
+}
+
+func linkageKSK(t *testing.T) (dnsrecords.DNSKEYRecord, dnsrecords.DSRecord) {
+	t.Helper()
+	ksk := rfc4034Key
+	ksk.Flags = 257
+	ksk.KeyID = keyTag(ksk)
+	digest, err := dsDigest("dskey.example.com", ksk, 2)
+	if err != nil {
+		t.Fatalf("dsDigest: unexpected error: %v", err)
+	}
+	return ksk, dnsrecords.DSRecord{KeyTag: ksk.KeyID, Algorithm: 5, DigestType: 2, Digest: digest}
+}
+
+func TestAnalyzeKeyLinkageHealthy(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	assessment := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key}, []dnsrecords.DSRecord{ds},
+		[]dnsrecords.RRSIGRecord{{TypeCovered: "DNSKEY", Algorithm: 5, KeyTag: ksk.KeyID, SignerName: "dskey.example.com."}})
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "SOA", Algorithm: 5, KeyTag: 60485, SignerName: "dskey.example.com"},
+	}
+
+	report := AnalyzeKeyLinkage(assessment)
+
+	if len(report.Findings) != 0 || len(report.UnusedKeys) != 0 || len(report.MissingKeys) != 0 {
+		t.Errorf("Expected a healthy linkage, got %+v", report)
+	}
+	if len(report.Keys) != 2 || report.Keys[0].Role != "KSK" || !report.Keys[0].HasDS || report.Keys[1].Role != "ZSK" ||
+		report.Keys[1].Status != models.KeyStatusActive {
+		t.Errorf("Unexpected key usage %+v", report.Keys)
+	}
+	if len(report.Signatures) != 2 || !report.Signatures[0].KeyFound || !report.Signatures[1].KeyFound {
+		t.Errorf("Expected every signature to be linked, got %+v", report.Signatures)
+	}
+}
+
+func TestAnalyzeKeyLinkageBrokenRollover(t *testing.T) {
+	ksk, _ := linkageKSK(t)
+	prePublished := dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 13,
+		PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="}
+	assessment := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key, prePublished},
+		[]dnsrecords.DSRecord{rfc4034DS},
+		[]dnsrecords.RRSIGRecord{{TypeCovered: "DNSKEY", Algorithm: 5, KeyTag: ksk.KeyID, SignerName: "dskey.example.com"}})
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "SOA", Algorithm: 5, KeyTag: 12345, SignerName: "dskey.example.com"},
+	}
+	assessment.Records["A"] = &dnsrecords.AResponse{
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "A", Algorithm: 8, KeyTag: 999, SignerName: "example.com"},
+	}
+
+	report := AnalyzeKeyLinkage(assessment)
+
+	if report.Keys[1].Status != models.KeyStatusStandby || report.Keys[2].Status != models.KeyStatusPrePublished {
+		t.Errorf("Expected a stand-by ZSK and a pre-published key, got %+v", report.Keys)
+	}
+	if len(report.UnusedKeys) != 2 || len(report.MissingKeys) != 1 || report.MissingKeys[0] != 12345 {
+		t.Errorf("Unexpected unused keys %v or missing keys %v", report.UnusedKeys, report.MissingKeys)
+	}
+	if report.Signatures[1].TypeCovered != "A" || report.Signatures[1].KeyFound {
+		t.Errorf("Expected the signature of another zone not to be linked, got %+v", report.Signatures[1])
+	}
+	expected := []string{"key 12345", "stand-by", "pre-published", "no key referenced by a DS record", "algorithm 13"}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), report.Findings)
+	}
+	for i, fragment := range expected {
+		if !strings.Contains(report.Findings[i], fragment) {
+			t.Errorf("Expected finding %d to mention %q, got %q", i, fragment, report.Findings[i])
+		}
+	}
+}
+
+func TestAnalyzeKeyLinkageUnsigned(t *testing.T) {
+	assessment := models.NewAssessment("https://example.com", "example.com")
+
+	report := AnalyzeKeyLinkage(assessment)
+
+	if len(report.Keys) != 0 || len(report.Findings) != 1 {
+		t.Errorf("Expected a single finding for an unsigned zone, got %+v", report)
+	}
+}
+
+func TestAnalyzeKeyLinkageDoubleSignature(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	newZSK := dnsrecords.DNSKEYRecord{Flags: 256, Protocol: 3, Algorithm: 13,
+		PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="}
+	newKSK := newZSK
+	newKSK.Flags = 257
+	newKSK.PublicKey = "oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA=="
+	// During an algorithm rollover every record set is signed with both algorithms.
+	assessment := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key, newKSK, newZSK}, []dnsrecords.DSRecord{ds},
+		[]dnsrecords.RRSIGRecord{
+			{TypeCovered: "DNSKEY", Algorithm: 5, KeyTag: ksk.KeyID, SignerName: "dskey.example.com"},
+			{TypeCovered: "DNSKEY", Algorithm: 13, KeyTag: keyTag(newKSK), SignerName: "dskey.example.com"},
+		})
+	zoneSigs := func(recordType string) []dnsrecords.RRSIGRecord {
+		return []dnsrecords.RRSIGRecord{
+			{TypeCovered: recordType, Algorithm: 5, KeyTag: 60485, SignerName: "dskey.example.com"},
+			{TypeCovered: recordType, Algorithm: 13, KeyTag: keyTag(newZSK), SignerName: "dskey.example.com"},
+		}
+	}
+	soaSigs, aSigs := zoneSigs("SOA"), zoneSigs("A")
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{RRSIG: &soaSigs[1], RRSIGs: soaSigs}
+	assessment.Records["A"] = &dnsrecords.AResponse{RRSIG: &aSigs[1], RRSIGs: aSigs}
+
+	report := AnalyzeKeyLinkage(assessment)
+
+	if len(report.Findings) != 0 || len(report.UnusedKeys) != 0 || len(report.Signatures) != 6 {
+		t.Errorf("Expected every key to be linked to its signatures, got %+v", report)
+	}
+	for _, key := range report.Keys {
+		if key.Status != models.KeyStatusActive {
+			t.Errorf("Expected key %d to be active, got %s", key.KeyTag, key.Status)
+		}
+	}
+	snapshot := NewKeySetSnapshot(assessment, assessment.Start)
+	if len(snapshot.ZoneSigners) != 2 || len(snapshot.DNSKEYSigners) != 2 {
+		t.Errorf("Expected both ZSKs and both KSKs to sign, got zone signers %v and DNSKEY signers %v",
+			snapshot.ZoneSigners, snapshot.DNSKEYSigners)
+	}
+}
@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// Kinds of mail policies published in TXT records.
+const (
+	MailPolicySPF    = "spf"
+	MailPolicyDMARC  = "dmarc"
+	MailPolicyMTASTS = "mta-sts"
+	MailPolicyTLSRPT = "tls-rpt"
+)
+
+// mailPolicyVersions are the version tags that identify the TXT record of each kind of policy.
+var mailPolicyVersions = map[string]string{
+	MailPolicySPF:    "v=spf1",
+	MailPolicyDMARC:  "v=DMARC1",
+	MailPolicyMTASTS: "v=STSv1",
+	MailPolicyTLSRPT: "v=TLSRPTv1",
+}
+
+// mailPolicyNames are the names used in findings for each kind of policy.
+var mailPolicyNames = map[string]string{
+	MailPolicySPF:    "SPF",
+	MailPolicyDMARC:  "DMARC",
+	MailPolicyMTASTS: "MTA-STS",
+	MailPolicyTLSRPT: "TLS-RPT",
+}
+
+// MailPolicyName returns the name at which a kind of mail policy of a domain is published.
+func MailPolicyName(kind, domain string) string {
+	switch kind {
+	case MailPolicyDMARC:
+		return "_dmarc." + domain
+	case MailPolicyMTASTS:
+		return "_mta-sts." + domain
+	case MailPolicyTLSRPT:
+		return "_smtp._tls." + domain
+	default:
+		return domain
+	}
+}
+
+// MailObservation holds the TXT answer observed for one kind of mail policy and its DNSSEC status.
+type MailObservation struct {
+	Kind   string
+	Name   string
+	TXT    *dnsrecords.TXTResponse
+	Status string
+}
+
+// AnalyzeMail interprets the MX records and the mail policies of a domain and reports missing
+// SPF and DMARC policies, policies that do not protect anything, duplicated policy records, which
+// receivers treat as errors, and records that are not served from a signed zone.
+func AnalyzeMail(domain string, mx *dnsrecords.MXResponse, mxStatus string, observations []MailObservation) *models.MailReport {
+	report := &models.MailReport{Domain: normalizeName(domain), MXStatus: mxStatus}
+	if mx != nil {
+		report.Exchangers = mx.Exchangers()
+		for _, record := range mx.Records {
+			report.NullMX = report.NullMX || record.IsNullMX()
+		}
+		if len(mx.Records) > 0 {
+			report.Findings = append(report.Findings, statusFindings("MX record set", report.Domain, mxStatus)...)
+		}
+	}
+
+	for _, observation := range observations {
+		policy := &models.MailPolicy{Name: observation.Name, Status: observation.Status}
+		if observation.TXT != nil {
+			policy.Validated = observation.TXT.Validated
+			var matching []string
+			for _, record := range observation.TXT.Records {
+				if isMailPolicyRecord(record.Text, mailPolicyVersions[observation.Kind]) {
+					matching = append(matching, record.Text)
+				}
+			}
+			if len(matching) > 1 {
+				report.Findings = append(report.Findings, fmt.Sprintf("%s publishes %d %s records, which receivers treat as an error",
+					observation.Name, len(matching), mailPolicyNames[observation.Kind]))
+			}
+			if len(matching) > 0 {
+				policy.Record = matching[0]
+			}
+		}
+		if policy.Record == "" {
+			if observation.Kind == MailPolicySPF || observation.Kind == MailPolicyDMARC {
+				report.Findings = append(report.Findings, fmt.Sprintf("no %s record at %s", mailPolicyNames[observation.Kind], observation.Name))
+			}
+		} else {
+			if observation.Kind == MailPolicySPF {
+				policy.Mechanisms, policy.Tags = parseSPF(policy.Record)
+			} else {
+				policy.Tags = parseTagList(policy.Record)
+			}
+			report.Findings = append(report.Findings, mailPolicyFindings(observation.Kind, policy)...)
+			report.Findings = append(report.Findings,
+				statusFindings(mailPolicyNames[observation.Kind]+" record", observation.Name, observation.Status)...)
+		}
+		switch observation.Kind {
+		case MailPolicySPF:
+			report.SPF = policy
+		case MailPolicyDMARC:
+			report.DMARC = policy
+		case MailPolicyMTASTS:
+			report.MTASTS = policy
+		case MailPolicyTLSRPT:
+			report.TLSRPT = policy
+		}
+	}
+	return report
+}
+
+// isMailPolicyRecord reports whether the text of a TXT record starts with the version tag of a
+// kind of policy. Version tags are matched without regard to case, and a record such as
+// "v=spf10" is not an SPF record.
+func isMailPolicyRecord(text, version string) bool {
+	if len(text) < len(version) || !strings.EqualFold(text[:len(version)], version) {
+		return false
+	}
+	rest := text[len(version):]
+	return rest == "" || rest[0] == ' ' || rest[0] == ';'
+}
+
+// parseSPF splits an SPF record into its mechanisms and its modifiers (RFC 7208, Section 4.6.1).
+func parseSPF(record string) ([]string, map[string]string) {
+	var mechanisms []string
+	modifiers := make(map[string]string)
+	for _, term := range strings.Fields(record)[1:] {
+		if name, value, found := strings.Cut(term, "="); found && !strings.ContainsAny(name, ":/") {
+			modifiers[strings.ToLower(name)] = value
+			continue
+		}
+		mechanisms = append(mechanisms, term)
+	}
+	return mechanisms, modifiers
+}
+
+// parseTagList splits a policy record made of "tag=value" pairs separated by semicolons, as used
+// by DMARC, MTA-STS and TLS-RPT.
+func parseTagList(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(record, ";") {
+		if name, value, found := strings.Cut(pair, "="); found {
+			tags[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+	}
+	return tags
+}
+
+// mailPolicyFindings reports policies that are published but do not protect anything.
+func mailPolicyFindings(kind string, policy *models.MailPolicy) []string {
+	var notes []string
+	switch kind {
+	case MailPolicySPF:
+		for _, mechanism := range policy.Mechanisms {
+			if strings.EqualFold(mechanism, "all") || strings.EqualFold(mechanism, "+all") {
+				notes = append(notes, fmt.Sprintf("the SPF record of %s ends with %s and authorizes every sender", policy.Name, mechanism))
+			}
+		}
+	case MailPolicyDMARC:
+		switch strings.ToLower(policy.Tags["p"]) {
+		case "":
+			notes = append(notes, fmt.Sprintf("the DMARC record at %s has no policy", policy.Name))
+		case "none":
+			notes = append(notes, fmt.Sprintf("the DMARC policy at %s is none, which only monitors", policy.Name))
+		}
+	case MailPolicyMTASTS:
+		if policy.Tags["id"] == "" {
+			notes = append(notes, fmt.Sprintf("the MTA-STS record at %s has no policy id", policy.Name))
+		}
+	case MailPolicyTLSRPT:
+		if policy.Tags["rua"] == "" {
+			notes = append(notes, fmt.Sprintf("the TLS-RPT record at %s has no reporting address", policy.Name))
+		}
+	}
+	return notes
+}
+
+// statusFindings reports record sets that are bogus or not validated, such as those of unsigned zones.
+func statusFindings(what, name, status string) []string {
+	switch status {
+	case VerdictSecure:
+		return nil
+	case VerdictBogus:
+		return []string{fmt.Sprintf("the %s of %s is bogus", what, name)}
+	default:
+		return []string{fmt.Sprintf("the %s of %s is not DNSSEC-validated", what, name)}
+	}
+}
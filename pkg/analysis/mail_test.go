@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func mailObservation(kind, domain, status string, texts ...string) MailObservation {
+	txt := &dnsrecords.TXTResponse{Validated: status == VerdictSecure}
+	for _, text := range texts {
+		txt.Records = append(txt.Records, dnsrecords.TXTRecord{Text: text})
+	}
+	return MailObservation{Kind: kind, Name: MailPolicyName(kind, domain), TXT: txt, Status: status}
+}
+
+func TestAnalyzeMailSecure(t *testing.T) {
+	mx := &dnsrecords.MXResponse{Validated: true, Records: []dnsrecords.MXRecord{{Preference: 10, Exchange: "mail.example.pt"}}}
+	report := AnalyzeMail("example.pt", mx, VerdictSecure, []MailObservation{
+		mailObservation(MailPolicySPF, "example.pt", VerdictSecure, "google-site-verification=abc", "v=spf1 mx include:_spf.example.net redirect=_spf.example.pt -all"),
+		mailObservation(MailPolicyDMARC, "example.pt", VerdictSecure, "v=DMARC1; p=reject; rua=mailto:dmarc@example.pt"),
+		mailObservation(MailPolicyMTASTS, "example.pt", VerdictSecure, "v=STSv1; id=20240301"),
+		mailObservation(MailPolicyTLSRPT, "example.pt", VerdictSecure, "v=TLSRPTv1; rua=mailto:tlsrpt@example.pt"),
+	})
+
+	if len(report.Findings) != 0 {
+		t.Errorf("Expected no findings, got %v", report.Findings)
+	}
+	if !slices.Equal(report.Exchangers, []string{"mail.example.pt"}) || report.NullMX {
+		t.Errorf("Unexpected exchangers %v", report.Exchangers)
+	}
+	if !slices.Equal(report.SPF.Mechanisms, []string{"mx", "include:_spf.example.net", "-all"}) || report.SPF.Tags["redirect"] != "_spf.example.pt" {
+		t.Errorf("Unexpected SPF terms %v %v", report.SPF.Mechanisms, report.SPF.Tags)
+	}
+	if report.DMARC.Name != "_dmarc.example.pt" || report.DMARC.Tags["p"] != "reject" || !report.DMARC.Validated {
+		t.Errorf("Unexpected DMARC policy %+v", report.DMARC)
+	}
+	if report.MTASTS.Tags["id"] != "20240301" || report.TLSRPT.Name != "_smtp._tls.example.pt" {
+		t.Errorf("Unexpected MTA-STS %+v or TLS-RPT %+v", report.MTASTS, report.TLSRPT)
+	}
+}
+
+func TestAnalyzeMailWeakAndUnsigned(t *testing.T) {
+	report := AnalyzeMail("example.pt", &dnsrecords.MXResponse{}, VerdictInsecure, []MailObservation{
+		mailObservation(MailPolicySPF, "example.pt", VerdictInsecure, "v=spf1 +all", "v=spf1 -all"),
+		mailObservation(MailPolicyDMARC, "example.pt", VerdictInsecure, "v=DMARC1; p=none"),
+		mailObservation(MailPolicyMTASTS, "example.pt", VerdictInsecure),
+		mailObservation(MailPolicyTLSRPT, "example.pt", VerdictInsecure),
+	})
+
+	expected := []string{
+		"2 SPF records",
+		"ends with +all",
+		"SPF record of example.pt is not DNSSEC-validated",
+		"is none, which only monitors",
+		"DMARC record of _dmarc.example.pt is not DNSSEC-validated",
+	}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), report.Findings)
+	}
+	for i, note := range expected {
+		if !strings.Contains(report.Findings[i], note) {
+			t.Errorf("Expected finding %d to mention %q, got %q", i, note, report.Findings[i])
+		}
+	}
+}
+
+func TestAnalyzeMailMissingPolicies(t *testing.T) {
+	mx := &dnsrecords.MXResponse{Records: []dnsrecords.MXRecord{{}}}
+	report := AnalyzeMail("example.pt", mx, VerdictSecure, []MailObservation{
+		mailObservation(MailPolicySPF, "example.pt", VerdictSecure, "v=spf10 unrelated"),
+		mailObservation(MailPolicyDMARC, "example.pt", VerdictSecure),
+	})
+
+	if !report.NullMX || len(report.Exchangers) != 0 {
+		t.Errorf("Expected a null MX record, got %+v", report)
+	}
+	if len(report.Findings) != 2 || !strings.Contains(report.Findings[0], "no SPF record") || !strings.Contains(report.Findings[1], "no DMARC record") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// labels returns the lower-cased labels of a domain name, without the root label.
+func labels(name string) []string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, ".")
+}
+
+// normalizeName lower-cases a domain name and removes the trailing dot.
+func normalizeName(name string) string {
+	return strings.Join(labels(name), ".")
+}
+
+// parentName returns the name with its leftmost label removed.
+func parentName(name string) string {
+	l := labels(name)
+	if len(l) <= 1 {
+		return ""
+	}
+	return strings.Join(l[1:], ".")
+}
+
+// isSubdomain reports whether name is equal to or below zone.
+func isSubdomain(name, zone string) bool {
+	name, zone = normalizeName(name), normalizeName(zone)
+	return zone == "" || name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// canonicalCompare compares two domain names in the canonical DNS name order defined
+// in RFC 4034, Section 6.1. It returns -1, 0 or 1.
+func canonicalCompare(a, b string) int {
+	la, lb := labels(a), labels(b)
+	for i, j := len(la)-1, len(lb)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := strings.Compare(la[i], lb[j]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(la) < len(lb):
+		return -1
+	case len(la) > len(lb):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// nsecCovers reports whether name falls strictly between owner and next in canonical order.
+// The last record of a zone wraps around to the apex, which is handled as a circular span.
+func nsecCovers(owner, next, name string) bool {
+	if canonicalCompare(owner, next) < 0 {
+		return canonicalCompare(owner, name) < 0 && canonicalCompare(name, next) < 0
+	}
+	return canonicalCompare(owner, name) < 0 || canonicalCompare(name, next) < 0
+}
+
+// nsec3Covers reports whether hash falls strictly between the hashed owner and the next
+// hashed owner. Base32hex preserves the byte order of the hashes, so the encoded strings
+// can be compared directly.
+func nsec3Covers(owner, next, hash string) bool {
+	owner, next, hash = strings.ToUpper(owner), strings.ToUpper(next), strings.ToUpper(hash)
+	if owner < next {
+		return owner < hash && hash < next
+	}
+	return owner < hash || hash < next
+}
+
+// nsec3Hash computes the base32hex-encoded NSEC3 hash of a name as specified in RFC 5155, Section 5.
+func nsec3Hash(name string, algorithm uint8, iterations uint16, salt string) (string, error) {
+	if algorithm != 1 {
+		return "", fmt.Errorf("unsupported NSEC3 hash algorithm %d", algorithm)
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return "", fmt.Errorf("invalid NSEC3 salt '%s': %v", salt, err)
+	}
+
+	digest := sha1.Sum(append(wireName(name), saltBytes...))
+	for i := uint16(0); i < iterations; i++ {
+		digest = sha1.Sum(append(digest[:], saltBytes...))
+	}
+	return base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(digest[:]), nil
+}
+
+// wireName returns the canonical (lower-case, uncompressed) wire format of a domain name.
+func wireName(name string) []byte {
+	var wire []byte
+	for _, label := range labels(name) {
+		wire = append(wire, byte(len(label)))
+		wire = append(wire, label...)
+	}
+	return append(wire, 0)
+}
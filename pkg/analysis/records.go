@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// The helpers below retrieve the typed results stored in an Assessment's Records map.
+// They return nil when the record type was not queried or its result has an unexpected type.
+
+func dnskeyResponse(assessment *models.Assessment) *dnsrecords.DNSKEYResponse {
+	result, _ := assessment.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse)
+	return result
+}
+
+func dsResponse(assessment *models.Assessment) *dnsrecords.DSResponse {
+	result, _ := assessment.Records["DS"].(*dnsrecords.DSResponse)
+	return result
+}
+
+func soaRecord(assessment *models.Assessment) *dnsrecords.SOARecord {
+	result, _ := assessment.Records["SOA"].(*dnsrecords.SOARecord)
+	return result
+}
+
+func nsecRecord(assessment *models.Assessment) *dnsrecords.NSECRecord {
+	result, _ := assessment.Records["NSEC"].(*dnsrecords.NSECRecord)
+	return result
+}
+
+func nsec3ParamRecord(assessment *models.Assessment) *dnsrecords.NSEC3PARAMRecord {
+	result, _ := assessment.Records["NSEC3PARAM"].(*dnsrecords.NSEC3PARAMRecord)
+	return result
+}
+
+func aResponse(assessment *models.Assessment) *dnsrecords.AResponse {
+	result, _ := assessment.Records["A"].(*dnsrecords.AResponse)
+	return result
+}
+
+func aaaaResponse(assessment *models.Assessment) *dnsrecords.AAAAResponse {
+	result, _ := assessment.Records["AAAA"].(*dnsrecords.AAAAResponse)
+	return result
+}
+
+func cdsResponse(assessment *models.Assessment) *dnsrecords.CDSResponse {
+	result, _ := assessment.Records["CDS"].(*dnsrecords.CDSResponse)
+	return result
+}
+
+func cdnskeyResponse(assessment *models.Assessment) *dnsrecords.CDNSKEYResponse {
+	result, _ := assessment.Records["CDNSKEY"].(*dnsrecords.CDNSKEYResponse)
+	return result
+}
+
+// signatures collects every RRSIG present in the zone-side answers of an assessment, keyed by
+// the record type that was queried. A record set carries several signatures during key and
+// algorithm rollovers, so every one of them is kept. The DS signature is excluded because it is
+// produced by the parent zone.
+func signatures(assessment *models.Assessment) map[string][]dnsrecords.RRSIGRecord {
+	sigs := make(map[string][]dnsrecords.RRSIGRecord)
+	add := func(recordType string, rrsig *dnsrecords.RRSIGRecord, rrsigs []dnsrecords.RRSIGRecord) {
+		if all := allSignatures(rrsig, rrsigs); len(all) > 0 {
+			sigs[recordType] = all
+		}
+	}
+	if r := dnskeyResponse(assessment); r != nil {
+		add("DNSKEY", r.RRSIG, r.RRSIGs)
+	}
+	if r := soaRecord(assessment); r != nil {
+		add("SOA", r.RRSIG, r.RRSIGs)
+	}
+	if r := nsecRecord(assessment); r != nil {
+		add("NSEC", r.RRSIG, r.RRSIGs)
+	}
+	if r := nsec3ParamRecord(assessment); r != nil {
+		add("NSEC3PARAM", r.RRSIG, r.RRSIGs)
+	}
+	if r := aResponse(assessment); r != nil {
+		add("A", r.RRSIG, r.RRSIGs)
+	}
+	if r := aaaaResponse(assessment); r != nil {
+		add("AAAA", r.RRSIG, r.RRSIGs)
+	}
+	if r := cdsResponse(assessment); r != nil {
+		add("CDS", r.RRSIG, r.RRSIGs)
+	}
+	if r := cdnskeyResponse(assessment); r != nil {
+		add("CDNSKEY", r.RRSIG, r.RRSIGs)
+	}
+	return sigs
+}
+
+// allSignatures returns every signature of a record set. Results stored before every signature
+// was kept only hold the last one, in rrsig.
+func allSignatures(rrsig *dnsrecords.RRSIGRecord, rrsigs []dnsrecords.RRSIGRecord) []dnsrecords.RRSIGRecord {
+	if len(rrsigs) == 0 && rrsig != nil {
+		return []dnsrecords.RRSIGRecord{*rrsig}
+	}
+	return rrsigs
+}
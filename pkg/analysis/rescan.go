@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"time"
+)
+
+// RescanPolicy sets how soon a domain is assessed again.
+//
+// Fields:
+//
+//	Interval: The regular time between two assessments of a domain.
+//
+//	MinInterval: The shortest time between two assessments, whatever the signatures and TTLs say.
+//
+//	ExpiryMargin: How long before the earliest signature expiration the domain is assessed again,
+//	              so that a zone that fails to re-sign is noticed before its signatures expire.
+type RescanPolicy struct {
+	Interval     time.Duration
+	MinInterval  time.Duration
+	ExpiryMargin time.Duration
+}
+
+// RescanBasis holds what the time of the next assessment of a domain depends on, so that it can
+// be stored next to an assessment and the schedule computed without decoding the assessment.
+//
+// Fields:
+//
+//	Start: The time the assessment started.
+//
+//	EarliestExpiration: The earliest expiration of the signatures of the assessment, zero when
+//	                    the assessment holds no signature.
+//
+//	ShortestTTL: The shortest original TTL of the signatures and address records, zero when
+//	             there is none.
+type RescanBasis struct {
+	Start              time.Time
+	EarliestExpiration time.Time
+	ShortestTTL        time.Duration
+}
+
+// NewRescanBasis extracts the rescan basis of an assessment.
+func NewRescanBasis(assessment *models.Assessment) RescanBasis {
+	basis := RescanBasis{Start: assessment.Start, ShortestTTL: shortestTTL(assessment)}
+	for _, sigs := range signatures(assessment) {
+		for _, sig := range sigs {
+			expiration := time.Unix(int64(sig.Expiration), 0)
+			if basis.EarliestExpiration.IsZero() || expiration.Before(basis.EarliestExpiration) {
+				basis.EarliestExpiration = expiration
+			}
+		}
+	}
+	return basis
+}
+
+// Next returns when the domain should be assessed again. It is the regular interval after the
+// assessment started, brought forward to ExpiryMargin before the earliest signature expiration
+// and to the shortest TTL, but never earlier than MinInterval after the assessment started.
+func (b RescanBasis) Next(policy RescanPolicy) time.Time {
+	next := b.Start.Add(policy.Interval)
+	if !b.EarliestExpiration.IsZero() {
+		if expiry := b.EarliestExpiration.Add(-policy.ExpiryMargin); expiry.Before(next) {
+			next = expiry
+		}
+	}
+	if b.ShortestTTL > 0 {
+		if expiry := b.Start.Add(b.ShortestTTL); expiry.Before(next) {
+			next = expiry
+		}
+	}
+	if earliest := b.Start.Add(policy.MinInterval); next.Before(earliest) {
+		next = earliest
+	}
+	return next
+}
+
+// NextRescan returns when a domain should be assessed again after the given assessment.
+func NextRescan(assessment *models.Assessment, policy RescanPolicy) time.Time {
+	return NewRescanBasis(assessment).Next(policy)
+}
+
+// shortestTTL returns the shortest original TTL of the signatures and address records of an
+// assessment, or zero when there is none.
+func shortestTTL(assessment *models.Assessment) time.Duration {
+	var ttls []uint32
+	for _, sigs := range signatures(assessment) {
+		for _, sig := range sigs {
+			ttls = append(ttls, sig.OriginalTTL)
+		}
+	}
+	if r := aResponse(assessment); r != nil {
+		for _, record := range r.Records {
+			ttls = append(ttls, record.OriginalTTL)
+		}
+	}
+	if r := aaaaResponse(assessment); r != nil {
+		for _, record := range r.Records {
+			ttls = append(ttls, record.OriginalTTL)
+		}
+	}
+	var shortest uint32
+	for _, ttl := range ttls {
+		if ttl > 0 && (shortest == 0 || ttl < shortest) {
+			shortest = ttl
+		}
+	}
+	return time.Duration(shortest) * time.Second
+}
@@ -0,0 +1,53 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+	"time"
+)
+
+var testRescanPolicy = RescanPolicy{Interval: 24 * time.Hour, MinInterval: time.Hour, ExpiryMargin: 48 * time.Hour}
+
+func rescanAssessment(start time.Time) *models.Assessment {
+	assessment := models.NewAssessment("https://example.com", "example.com")
+	assessment.Start = start
+	return assessment
+}
+
+func TestNextRescanRegularInterval(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assessment := rescanAssessment(start)
+	assessment.Records["A"] = &dnsrecords.AResponse{Records: []dnsrecords.ARecord{{IPv4: "192.0.2.1", OriginalTTL: 86400 * 2}}}
+
+	if next := NextRescan(assessment, testRescanPolicy); !next.Equal(start.Add(24 * time.Hour)) {
+		t.Errorf("Expected the regular interval, got %v", next)
+	}
+}
+
+func TestNextRescanSignatureExpiry(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assessment := rescanAssessment(start)
+	expiration := start.Add(60 * time.Hour)
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "SOA", OriginalTTL: 86400, Expiration: uint32(expiration.Unix())},
+	}
+
+	if next := NextRescan(assessment, testRescanPolicy); !next.Equal(start.Add(12 * time.Hour)) {
+		t.Errorf("Expected a rescan 48 hours before the expiration, got %v", next)
+	}
+}
+
+func TestNextRescanShortTTL(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assessment := rescanAssessment(start)
+	assessment.Records["A"] = &dnsrecords.AResponse{Records: []dnsrecords.ARecord{{IPv4: "192.0.2.1", OriginalTTL: 3 * 3600}}}
+	if next := NextRescan(assessment, testRescanPolicy); !next.Equal(start.Add(3 * time.Hour)) {
+		t.Errorf("Expected a rescan after the TTL, got %v", next)
+	}
+
+	assessment.Records["A"] = &dnsrecords.AResponse{Records: []dnsrecords.ARecord{{IPv4: "192.0.2.1", OriginalTTL: 60}}}
+	if next := NextRescan(assessment, testRescanPolicy); !next.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected the minimum interval, got %v", next)
+	}
+}
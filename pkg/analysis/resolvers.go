@@ -0,0 +1,132 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"sort"
+	"strings"
+)
+
+// Validation verdicts of a recursive resolver, from the worst to the best.
+const (
+	VerdictBogus         = "bogus"
+	VerdictIndeterminate = "indeterminate"
+	VerdictInsecure      = "insecure"
+	VerdictSecure        = "secure"
+)
+
+var verdictRank = map[string]int{
+	VerdictBogus:         0,
+	VerdictIndeterminate: 1,
+	VerdictInsecure:      2,
+	VerdictSecure:        3,
+}
+
+// ClassifyResolverAnswer derives the verdict of a validating resolver from its answer to a query
+// with the DNSSEC OK bit set and, when that answer is SERVFAIL, from its answer to the same query
+// with checking disabled. A resolver returns SERVFAIL for bogus data but answers it when checking
+// is disabled, which tells validation failures apart from resolution failures. A nil response
+// means the resolver did not answer.
+func ClassifyResolverAnswer(response, checkingDisabled *dnsrecords.DigResponse) string {
+	switch {
+	case response == nil:
+		return VerdictIndeterminate
+	case response.Status == "SERVFAIL":
+		if checkingDisabled != nil && (checkingDisabled.Status == "NOERROR" || checkingDisabled.Status == "NXDOMAIN") {
+			return VerdictBogus
+		}
+		return VerdictIndeterminate
+	case response.Status != "NOERROR" && response.Status != "NXDOMAIN":
+		return VerdictIndeterminate
+	case response.HasFlag("ad"):
+		return VerdictSecure
+	default:
+		return VerdictInsecure
+	}
+}
+
+// ClassifyValidatorAnswer derives the verdict of the configured validator from its answer, in the
+// format delv prints, so that the configured trust anchors and negative trust anchors apply: secure
+// when the answer, positive or negative, was fully validated and insecure when it was not signed.
+// When the answer failed, the answer to the same query with checking disabled tells bogus data
+// apart from resolution failures, as in ClassifyResolverAnswer. An empty answer means the
+// validator did not answer.
+func ClassifyValidatorAnswer(answer string, checkingDisabled *dnsrecords.DigResponse) string {
+	switch {
+	case strings.TrimSpace(answer) == "":
+		return VerdictIndeterminate
+	case ValidatorFailed(answer):
+		return ClassifyResolverAnswer(&dnsrecords.DigResponse{Status: "SERVFAIL"}, checkingDisabled)
+	case strings.Contains(answer, "fully validated"):
+		return VerdictSecure
+	default:
+		return VerdictInsecure
+	}
+}
+
+// ValidatorFailed reports whether the validator could not resolve or validate an answer printed
+// by delv. Proofs that the name or the record type does not exist are answers, not failures.
+func ValidatorFailed(answer string) bool {
+	return strings.Contains(answer, "resolution failed") && !strings.Contains(answer, "resolution failed: ncache")
+}
+
+// CompareResolvers sets the overall verdict of each resolver, the worst of its per record type
+// verdicts, and reports the record types for which the resolvers that answered disagree, the
+// configured validator included. Indeterminate verdicts are not treated as disagreements, since
+// they say nothing about validation.
+func CompareResolvers(verdicts []models.ResolverVerdict) *models.ResolverComparisonReport {
+	report := &models.ResolverComparisonReport{Agreement: true}
+	recordTypes := make(map[string]bool)
+	for i := range verdicts {
+		verdicts[i].Verdict = VerdictSecure
+		if len(verdicts[i].RecordVerdicts) == 0 {
+			verdicts[i].Verdict = VerdictIndeterminate
+		}
+		for recordType, verdict := range verdicts[i].RecordVerdicts {
+			recordTypes[recordType] = true
+			if verdictRank[verdict] < verdictRank[verdicts[i].Verdict] {
+				verdicts[i].Verdict = verdict
+			}
+		}
+	}
+
+	var sortedTypes []string
+	for recordType := range recordTypes {
+		sortedTypes = append(sortedTypes, recordType)
+	}
+	sort.Strings(sortedTypes)
+
+	for _, recordType := range sortedTypes {
+		byVerdict := make(map[string][]string)
+		for _, verdict := range verdicts {
+			v, ok := verdict.RecordVerdicts[recordType]
+			if ok && v != VerdictIndeterminate {
+				byVerdict[v] = append(byVerdict[v], resolverName(verdict))
+			}
+		}
+		if len(byVerdict) < 2 {
+			continue
+		}
+		report.Agreement = false
+		var parts []string
+		for _, v := range []string{VerdictBogus, VerdictInsecure, VerdictSecure} {
+			if resolvers, ok := byVerdict[v]; ok {
+				parts = append(parts, fmt.Sprintf("%s at %s", v, strings.Join(resolvers, ", ")))
+			}
+		}
+		report.Disagreements = append(report.Disagreements, fmt.Sprintf("%s: %s", recordType, strings.Join(parts, "; ")))
+	}
+
+	report.Resolvers = verdicts
+	return report
+}
+
+// resolverName names a resolver in disagreements, telling the configured validator apart from a
+// compared resolver at the same address.
+func resolverName(verdict models.ResolverVerdict) string {
+	if verdict.Validator {
+		return verdict.Resolver + " (validator)"
+	}
+	return verdict.Resolver
+}
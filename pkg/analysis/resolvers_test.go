@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+)
+
+func TestClassifyResolverAnswer(t *testing.T) {
+	testCases := []struct {
+		name             string
+		response         *dnsrecords.DigResponse
+		checkingDisabled *dnsrecords.DigResponse
+		expected         string
+	}{
+		{"no answer", nil, nil, VerdictIndeterminate},
+		{"authenticated", &dnsrecords.DigResponse{Status: "NOERROR", Flags: []string{"qr", "rd", "ra", "ad"}}, nil, VerdictSecure},
+		{"authenticated denial", &dnsrecords.DigResponse{Status: "NXDOMAIN", Flags: []string{"qr", "ad"}}, nil, VerdictSecure},
+		{"not authenticated", &dnsrecords.DigResponse{Status: "NOERROR", Flags: []string{"qr", "rd", "ra"}}, nil, VerdictInsecure},
+		{"validation failure", &dnsrecords.DigResponse{Status: "SERVFAIL"}, &dnsrecords.DigResponse{Status: "NOERROR"}, VerdictBogus},
+		{"resolution failure", &dnsrecords.DigResponse{Status: "SERVFAIL"}, &dnsrecords.DigResponse{Status: "SERVFAIL"}, VerdictIndeterminate},
+		{"refused", &dnsrecords.DigResponse{Status: "REFUSED"}, nil, VerdictIndeterminate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if verdict := ClassifyResolverAnswer(tc.response, tc.checkingDisabled); verdict != tc.expected {
+				t.Errorf("Expected verdict %s, got %s", tc.expected, verdict)
+			}
+		})
+	}
+}
+
+func TestClassifyValidatorAnswer(t *testing.T) {
+	testCases := []struct {
+		name             string
+		answer           string
+		checkingDisabled *dnsrecords.DigResponse
+		expected         string
+	}{
+		{"no answer", "", nil, VerdictIndeterminate},
+		{"validated", "; fully validated\nexample.com. 3600 IN A 192.0.2.1\n", nil, VerdictSecure},
+		{"validated denial", ";; resolution failed: ncache nxdomain\n; negative response, fully validated\n", nil, VerdictSecure},
+		{"unsigned", "; unsigned answer\nexample.com. 3600 IN A 192.0.2.1\n", nil, VerdictInsecure},
+		{"unsigned denial", ";; resolution failed: ncache nxrrset\n; negative response, unsigned answer\n", nil, VerdictInsecure},
+		{"validation failure", ";; resolution failed: RRSIG failed to verify\n", &dnsrecords.DigResponse{Status: "NOERROR"}, VerdictBogus},
+		{"resolution failure", ";; resolution failed: SERVFAIL\n", &dnsrecords.DigResponse{Status: "SERVFAIL"}, VerdictIndeterminate},
+		{"failure without retry", ";; resolution failed: timed out\n", nil, VerdictIndeterminate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if verdict := ClassifyValidatorAnswer(tc.answer, tc.checkingDisabled); verdict != tc.expected {
+				t.Errorf("Expected verdict %s, got %s", tc.expected, verdict)
+			}
+		})
+	}
+}
+
+func TestCompareResolversDisagreement(t *testing.T) {
+	report := CompareResolvers([]models.ResolverVerdict{
+		{Resolver: "1.1.1.1", RecordVerdicts: map[string]string{"SOA": VerdictSecure, "DNSKEY": VerdictSecure}},
+		{Resolver: "8.8.8.8", RecordVerdicts: map[string]string{"SOA": VerdictSecure, "DNSKEY": VerdictSecure}},
+		{Resolver: "192.0.2.1", RecordVerdicts: map[string]string{"SOA": VerdictBogus, "DNSKEY": VerdictIndeterminate}},
+	})
+
+	if report.Agreement {
+		t.Fatalf("Expected a disagreement")
+	}
+	if len(report.Disagreements) != 1 || report.Disagreements[0] != "SOA: bogus at 192.0.2.1; secure at 1.1.1.1, 8.8.8.8" {
+		t.Errorf("Unexpected disagreements %v", report.Disagreements)
+	}
+	if report.Resolvers[0].Verdict != VerdictSecure || report.Resolvers[2].Verdict != VerdictBogus {
+		t.Errorf("Unexpected overall verdicts %+v", report.Resolvers)
+	}
+}
+
+func TestCompareResolversAgreement(t *testing.T) {
+	report := CompareResolvers([]models.ResolverVerdict{
+		{Resolver: "1.1.1.1", RecordVerdicts: map[string]string{"SOA": VerdictInsecure}},
+		{Resolver: "9.9.9.9", RecordVerdicts: map[string]string{"SOA": VerdictIndeterminate}},
+	})
+
+	if !report.Agreement {
+		t.Errorf("Expected agreement, got disagreements %v", report.Disagreements)
+	}
+	if report.Resolvers[1].Verdict != VerdictIndeterminate {
+		t.Errorf("Expected an indeterminate verdict, got %s", report.Resolvers[1].Verdict)
+	}
+}
+
+func TestCompareResolversValidatorDisagreement(t *testing.T) {
+	report := CompareResolvers([]models.ResolverVerdict{
+		{Resolver: "1.1.1.1", Validator: true, RecordVerdicts: map[string]string{"DS": VerdictInsecure}},
+		{Resolver: "1.1.1.1", RecordVerdicts: map[string]string{"DS": VerdictSecure}},
+	})
+
+	if len(report.Disagreements) != 1 || report.Disagreements[0] != "DS: insecure at 1.1.1.1 (validator); secure at 1.1.1.1" {
+		t.Errorf("Expected the validator to be named in the disagreement, got %v", report.Disagreements)
+	}
+}
@@ -0,0 +1,217 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"slices"
+	"sort"
+	"time"
+)
+
+// rolloverKey identifies a key across snapshots. Key tags alone may collide between algorithms.
+type rolloverKey struct {
+	tag       uint16
+	algorithm uint8
+}
+
+type rollover struct {
+	event   models.RolloverEvent
+	start   int
+	oldKeys []rolloverKey
+	newKeys []rolloverKey
+}
+
+// NewKeySetSnapshot records the key set state observed by an assessment at the given time. It
+// uses the key linkage report of the assessment, computing it when the assessment has none.
+func NewKeySetSnapshot(assessment *models.Assessment, at time.Time) models.KeySetSnapshot {
+	snapshot := models.KeySetSnapshot{Zone: normalizeName(assessment.Domain), FirstSeen: at, LastSeen: at}
+	if dnskey := dnskeyResponse(assessment); dnskey != nil {
+		snapshot.Keys = dnskey.Records
+	}
+	linkage := assessment.KeyLinkage
+	if linkage == nil {
+		linkage = AnalyzeKeyLinkage(assessment)
+	}
+	for _, key := range linkage.Keys {
+		if slices.Contains(key.SignedTypes, "DNSKEY") {
+			snapshot.DNSKEYSigners = append(snapshot.DNSKEYSigners, key.KeyTag)
+		}
+		if slices.ContainsFunc(key.SignedTypes, func(recordType string) bool { return !keySetTypes[recordType] }) {
+			snapshot.ZoneSigners = append(snapshot.ZoneSigners, key.KeyTag)
+		}
+	}
+	if ds := dsResponse(assessment); ds != nil {
+		for _, record := range ds.Records {
+			if !slices.Contains(snapshot.DSKeyTags, record.KeyTag) {
+				snapshot.DSKeyTags = append(snapshot.DSKeyTags, record.KeyTag)
+			}
+		}
+	}
+	return snapshot
+}
+
+// AnalyzeRollovers compares the current key set state of a zone with its history and reports the
+// ZSK, KSK and algorithm rollovers it reveals. A rollover starts when a key appears next to keys of
+// the same role and algorithm (or, for an algorithm rollover, next to keys of other algorithms
+// only), and it is followed through the pre-publish, double-signature and post-publish phases
+// until the old keys are withdrawn. Rollovers that stay in an intermediate phase for longer than
+// stuckAfter are flagged as stuck; a stuckAfter of zero disables the check.
+//
+// The history must be in chronological order. When its last snapshot has the same state as the
+// current one, the two are treated as a single observation.
+func AnalyzeRollovers(history []models.KeySetSnapshot, current models.KeySetSnapshot, stuckAfter time.Duration) *models.RolloverReport {
+	timeline := append([]models.KeySetSnapshot(nil), history...)
+	if last := len(timeline) - 1; last >= 0 && timeline[last].SameState(current) {
+		timeline[last].LastSeen = current.LastSeen
+	} else {
+		timeline = append(timeline, current)
+	}
+	report := &models.RolloverReport{Zone: current.Zone, Observations: len(timeline), Since: timeline[0].FirstSeen}
+
+	var rollovers []*rollover
+	for i := 1; i < len(timeline); i++ {
+		previous, snapshot := timeline[i-1], timeline[i]
+		previousKeys := rolloverKeys(previous)
+		algorithms := make(map[uint8]bool)
+		for _, key := range previousKeys {
+			algorithms[key.algorithm] = true
+		}
+
+		byAlgorithm := make(map[uint8]*rollover)
+		byRole := make(map[string]*rollover)
+		for _, key := range snapshot.Keys {
+			id := rolloverKey{tag: keyTag(key), algorithm: key.Algorithm}
+			if key.Flags&dnskeyFlagRevoke != 0 || slices.Contains(previousKeys, id) {
+				continue
+			}
+			if len(algorithms) > 0 && !algorithms[key.Algorithm] {
+				if byAlgorithm[key.Algorithm] == nil {
+					byAlgorithm[key.Algorithm] = &rollover{event: models.RolloverEvent{Kind: models.RolloverAlgorithm}, start: i,
+						oldKeys: previousKeys}
+					rollovers = append(rollovers, byAlgorithm[key.Algorithm])
+				}
+				byAlgorithm[key.Algorithm].newKeys = append(byAlgorithm[key.Algorithm].newKeys, id)
+				continue
+			}
+
+			kind := keyRoleKind(key.Flags)
+			group := fmt.Sprintf("%s/%d", kind, key.Algorithm)
+			if byRole[group] == nil {
+				var oldKeys []rolloverKey
+				for _, old := range previous.Keys {
+					if old.Flags&dnskeyFlagRevoke == 0 && keyRoleKind(old.Flags) == kind && old.Algorithm == key.Algorithm {
+						oldKeys = append(oldKeys, rolloverKey{tag: keyTag(old), algorithm: old.Algorithm})
+					}
+				}
+				if len(oldKeys) == 0 {
+					continue
+				}
+				byRole[group] = &rollover{event: models.RolloverEvent{Kind: kind}, start: i, oldKeys: oldKeys}
+				rollovers = append(rollovers, byRole[group])
+			}
+			byRole[group].newKeys = append(byRole[group].newKeys, id)
+		}
+	}
+
+	latest := timeline[len(timeline)-1]
+	for _, r := range rollovers {
+		r.event.Started = timeline[r.start].FirstSeen
+		r.event.OldKeys = rolloverTags(r.oldKeys)
+		r.event.NewKeys = rolloverTags(r.newKeys)
+		for _, snapshot := range timeline[r.start:] {
+			phase := rolloverPhase(r, snapshot)
+			if phase != r.event.Phase {
+				r.event.Phase = phase
+				r.event.PhaseSince = snapshot.FirstSeen
+			}
+			if phase == models.RolloverPhaseCompleted {
+				r.event.Completed = snapshot.FirstSeen
+			}
+			if phase == models.RolloverPhaseCompleted || phase == models.RolloverPhaseAbandoned {
+				break
+			}
+		}
+
+		switch r.event.Phase {
+		case models.RolloverPhaseBroken:
+			report.Findings = append(report.Findings, fmt.Sprintf("%s rollover to key %s is broken: no DS record at the parent references the new key",
+				r.event.Kind, keyIDList(r.event.NewKeys)))
+		case models.RolloverPhasePrePublish, models.RolloverPhaseDoubleSignature, models.RolloverPhasePostPublish:
+			if stuckAfter <= 0 || latest.LastSeen.Sub(r.event.PhaseSince) <= stuckAfter {
+				break
+			}
+			r.event.Stuck = true
+			finding := fmt.Sprintf("%s rollover to key %s has been in the %s phase since %s", r.event.Kind,
+				keyIDList(r.event.NewKeys), r.event.Phase, r.event.PhaseSince.Format(time.DateOnly))
+			if r.event.Kind != models.RolloverZSK && !anyTag(latest.DSKeyTags, r.newKeys) {
+				finding += " without a DS update at the parent"
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		report.Events = append(report.Events, r.event)
+	}
+	return report
+}
+
+// rolloverPhase returns the phase of a rollover in the given snapshot. Only the DNSKEY set counts
+// as signed data for KSK rollovers, and only the other record sets count for ZSK rollovers.
+func rolloverPhase(r *rollover, snapshot models.KeySetSnapshot) string {
+	published := rolloverKeys(snapshot)
+	signers := snapshot.ZoneSigners
+	switch r.event.Kind {
+	case models.RolloverKSK:
+		signers = snapshot.DNSKEYSigners
+	case models.RolloverAlgorithm:
+		signers = append(append([]uint16(nil), snapshot.DNSKEYSigners...), snapshot.ZoneSigners...)
+	}
+	newPublished := slices.ContainsFunc(r.newKeys, func(key rolloverKey) bool { return slices.Contains(published, key) })
+	oldPublished := slices.ContainsFunc(r.oldKeys, func(key rolloverKey) bool { return slices.Contains(published, key) })
+	newSigning := newPublished && anyTag(signers, r.newKeys)
+	oldSigning := oldPublished && anyTag(signers, r.oldKeys)
+
+	switch {
+	case !newPublished:
+		return models.RolloverPhaseAbandoned
+	case !newSigning:
+		return models.RolloverPhasePrePublish
+	case oldSigning:
+		return models.RolloverPhaseDoubleSignature
+	case r.event.Kind != models.RolloverZSK && len(snapshot.DSKeyTags) > 0 && !anyTag(snapshot.DSKeyTags, r.newKeys):
+		return models.RolloverPhaseBroken
+	case oldPublished:
+		return models.RolloverPhasePostPublish
+	default:
+		return models.RolloverPhaseCompleted
+	}
+}
+
+func keyRoleKind(flags uint16) string {
+	if flags&dnskeyFlagSEP != 0 {
+		return models.RolloverKSK
+	}
+	return models.RolloverZSK
+}
+
+// rolloverKeys returns the keys of a snapshot that are not revoked.
+func rolloverKeys(snapshot models.KeySetSnapshot) []rolloverKey {
+	var keys []rolloverKey
+	for _, key := range snapshot.Keys {
+		if key.Flags&dnskeyFlagRevoke == 0 {
+			keys = append(keys, rolloverKey{tag: keyTag(key), algorithm: key.Algorithm})
+		}
+	}
+	return keys
+}
+
+func rolloverTags(keys []rolloverKey) []uint16 {
+	tags := make([]uint16, len(keys))
+	for i, key := range keys {
+		tags[i] = key.tag
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	return tags
+}
+
+func anyTag(tags []uint16, keys []rolloverKey) bool {
+	return slices.ContainsFunc(keys, func(key rolloverKey) bool { return slices.Contains(tags, key.tag) })
+}
@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+	"time"
+)
+
+type rolloverFixture struct {
+	zsk, zsk2, ksk, ksk2, ksk13 dnsrecords.DNSKEYRecord
+}
+
+func newRolloverKeys() rolloverFixture {
+	keys := rolloverFixture{zsk: rfc4034Key}
+	keys.zsk2 = dnsrecords.DNSKEYRecord{Flags: 256, Protocol: 3, Algorithm: 5, PublicKey: "AwEAAbQIht7R2chVP06KG0T+2qFP"}
+	keys.ksk = rfc4034Key
+	keys.ksk.Flags = 257
+	keys.ksk2 = dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 5, PublicKey: "AwEAAaz/tAm8yTn4Mfeh"}
+	keys.ksk13 = dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 13,
+		PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="}
+	return keys
+}
+
+func rolloverSnapshot(at time.Time, keys []dnsrecords.DNSKEYRecord, dnskeySigners, zoneSigners, ds []dnsrecords.DNSKEYRecord) models.KeySetSnapshot {
+	tags := func(keys []dnsrecords.DNSKEYRecord) []uint16 {
+		var result []uint16
+		for _, key := range keys {
+			result = append(result, keyTag(key))
+		}
+		return result
+	}
+	return models.KeySetSnapshot{Zone: "example.com", FirstSeen: at, LastSeen: at, Keys: keys,
+		DNSKEYSigners: tags(dnskeySigners), ZoneSigners: tags(zoneSigners), DSKeyTags: tags(ds)}
+}
+
+func TestAnalyzeRolloversStuckKSK(t *testing.T) {
+	k := newRolloverKeys()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signers, zoneSigners, ds := []dnsrecords.DNSKEYRecord{k.ksk}, []dnsrecords.DNSKEYRecord{k.zsk}, []dnsrecords.DNSKEYRecord{k.ksk}
+	history := []models.KeySetSnapshot{
+		rolloverSnapshot(start, []dnsrecords.DNSKEYRecord{k.zsk, k.ksk}, signers, zoneSigners, ds),
+		rolloverSnapshot(start.AddDate(0, 1, 0), []dnsrecords.DNSKEYRecord{k.zsk, k.ksk, k.ksk2}, signers, zoneSigners, ds),
+	}
+	current := rolloverSnapshot(start.AddDate(0, 5, 0), []dnsrecords.DNSKEYRecord{k.zsk, k.ksk, k.ksk2}, signers, zoneSigners, ds)
+
+	report := AnalyzeRollovers(history, current, 60*24*time.Hour)
+
+	if report.Observations != 2 || !report.Since.Equal(start) {
+		t.Errorf("Expected the current state to extend the last observation, got %+v", report)
+	}
+	if len(report.Events) != 1 {
+		t.Fatalf("Expected a single rollover, got %+v", report.Events)
+	}
+	event := report.Events[0]
+	if event.Kind != models.RolloverKSK || event.Phase != models.RolloverPhasePrePublish || !event.Stuck ||
+		event.NewKeys[0] != keyTag(k.ksk2) {
+		t.Errorf("Expected a stuck KSK rollover in the pre-publish phase, got %+v", event)
+	}
+	if len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "without a DS update") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeRolloversZSKAndAlgorithm(t *testing.T) {
+	k := newRolloverKeys()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ds := []dnsrecords.DNSKEYRecord{k.ksk}
+	history := []models.KeySetSnapshot{
+		rolloverSnapshot(start, []dnsrecords.DNSKEYRecord{k.zsk, k.ksk}, ds, []dnsrecords.DNSKEYRecord{k.zsk}, ds),
+		rolloverSnapshot(start.AddDate(0, 0, 7), []dnsrecords.DNSKEYRecord{k.zsk, k.zsk2, k.ksk}, ds, []dnsrecords.DNSKEYRecord{k.zsk}, ds),
+		rolloverSnapshot(start.AddDate(0, 0, 14), []dnsrecords.DNSKEYRecord{k.zsk, k.zsk2, k.ksk}, ds, []dnsrecords.DNSKEYRecord{k.zsk2}, ds),
+		rolloverSnapshot(start.AddDate(0, 0, 21), []dnsrecords.DNSKEYRecord{k.zsk2, k.ksk}, ds, []dnsrecords.DNSKEYRecord{k.zsk2}, ds),
+	}
+	current := rolloverSnapshot(start.AddDate(0, 0, 28), []dnsrecords.DNSKEYRecord{k.zsk2, k.ksk, k.ksk13},
+		[]dnsrecords.DNSKEYRecord{k.ksk, k.ksk13}, []dnsrecords.DNSKEYRecord{k.zsk2}, ds)
+
+	report := AnalyzeRollovers(history, current, 60*24*time.Hour)
+
+	if len(report.Events) != 2 || len(report.Findings) != 0 {
+		t.Fatalf("Expected two rollovers without findings, got %+v", report)
+	}
+	zsk := report.Events[0]
+	if zsk.Kind != models.RolloverZSK || zsk.Phase != models.RolloverPhaseCompleted ||
+		!zsk.Started.Equal(start.AddDate(0, 0, 7)) || !zsk.Completed.Equal(start.AddDate(0, 0, 21)) {
+		t.Errorf("Expected a completed ZSK rollover, got %+v", zsk)
+	}
+	algorithm := report.Events[1]
+	if algorithm.Kind != models.RolloverAlgorithm || algorithm.Phase != models.RolloverPhaseDoubleSignature ||
+		len(algorithm.NewKeys) != 1 || algorithm.NewKeys[0] != keyTag(k.ksk13) {
+		t.Errorf("Expected an algorithm rollover in the double-signature phase, got %+v", algorithm)
+	}
+}
+
+func TestAnalyzeRolloversBrokenKSK(t *testing.T) {
+	k := newRolloverKeys()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ds := []dnsrecords.DNSKEYRecord{k.ksk}
+	history := []models.KeySetSnapshot{
+		rolloverSnapshot(start, []dnsrecords.DNSKEYRecord{k.zsk, k.ksk}, ds, []dnsrecords.DNSKEYRecord{k.zsk}, ds),
+	}
+	current := rolloverSnapshot(start.AddDate(0, 0, 1), []dnsrecords.DNSKEYRecord{k.zsk, k.ksk2},
+		[]dnsrecords.DNSKEYRecord{k.ksk2}, []dnsrecords.DNSKEYRecord{k.zsk}, ds)
+
+	report := AnalyzeRollovers(history, current, 0)
+
+	if len(report.Events) != 1 || report.Events[0].Phase != models.RolloverPhaseBroken {
+		t.Fatalf("Expected a broken KSK rollover, got %+v", report.Events)
+	}
+	if len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "broken") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestNewKeySetSnapshot(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	assessment := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk, rfc4034Key}, []dnsrecords.DSRecord{ds},
+		[]dnsrecords.RRSIGRecord{{TypeCovered: "DNSKEY", Algorithm: 5, KeyTag: ksk.KeyID, SignerName: "dskey.example.com"}})
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "SOA", Algorithm: 5, KeyTag: 60485, SignerName: "dskey.example.com"},
+	}
+
+	snapshot := NewKeySetSnapshot(assessment, assessment.Start)
+
+	if len(snapshot.Keys) != 2 || len(snapshot.DNSKEYSigners) != 1 || snapshot.DNSKEYSigners[0] != ksk.KeyID ||
+		len(snapshot.ZoneSigners) != 1 || snapshot.ZoneSigners[0] != 60485 ||
+		len(snapshot.DSKeyTags) != 1 || snapshot.DSKeyTags[0] != ksk.KeyID {
+		t.Errorf("Unexpected snapshot %+v", snapshot)
+	}
+}
@@ -0,0 +1,350 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Criterion identifiers used in the score breakdown.
+const (
+	CriterionDeployment        = "deployment"
+	CriterionAlgorithmStrength = "algorithm_strength"
+	CriterionDigestStrength    = "digest_strength"
+	CriterionDenialOfExistence = "denial_of_existence"
+	CriterionSignatureHygiene  = "signature_hygiene"
+	CriterionKeyManagement     = "key_management"
+)
+
+const (
+	// signatureExpiryWarning is the remaining validity below which a signature is considered about to expire.
+	signatureExpiryWarning = 3 * 24 * time.Hour
+	// signatureValidityLimit is the validity period above which a signature widens the replay window needlessly.
+	signatureValidityLimit = 180 * 24 * time.Hour
+	// maxExpectedKeys is the number of DNSKEYs above which a key set is considered cluttered.
+	maxExpectedKeys = 4
+)
+
+// algorithmScores rates DNSSEC signing algorithms following the implementation
+// recommendations of RFC 8624. Algorithms absent from the map score zero.
+var algorithmScores = map[uint8]float64{
+	1:  0,   // RSAMD5
+	3:  0,   // DSA
+	5:  20,  // RSASHA1
+	6:  0,   // DSA-NSEC3-SHA1
+	7:  20,  // RSASHA1-NSEC3-SHA1
+	8:  80,  // RSASHA256
+	10: 60,  // RSASHA512
+	12: 0,   // ECC-GOST
+	13: 100, // ECDSAP256SHA256
+	14: 100, // ECDSAP384SHA384
+	15: 100, // ED25519
+	16: 100, // ED448
+}
+
+// digestScores rates DS digest types following RFC 8624. Digest types absent from the map score zero.
+var digestScores = map[uint8]float64{
+	1: 30,  // SHA-1
+	2: 100, // SHA-256
+	3: 0,   // GOST R 34.11-94
+	4: 100, // SHA-384
+}
+
+// Scorer turns a completed Assessment into a SecurityScore using configurable
+// per-criterion weights.
+type Scorer struct {
+	weights config.ScoringConfig
+}
+
+func NewScorer(weights config.ScoringConfig) *Scorer {
+	return &Scorer{weights: weights}
+}
+
+func NewScorerDefault() *Scorer {
+	return NewScorer(*config.Scoring())
+}
+
+// Score evaluates every criterion against the records of the assessment and combines
+// them into a weighted 0–100 score and a letter grade. Time-dependent checks use the
+// end of the assessment as the reference time, so the same assessment always yields
+// the same score.
+func (s *Scorer) Score(assessment *models.Assessment) *models.SecurityScore {
+	now := assessment.End
+	if now.IsZero() {
+		now = assessment.Start
+	}
+
+	breakdown := []models.CriterionScore{
+		s.criterion(CriterionDeployment, s.weights.Deployment, scoreDeployment(assessment)),
+		s.criterion(CriterionAlgorithmStrength, s.weights.AlgorithmStrength, scoreAlgorithmStrength(assessment)),
+		s.criterion(CriterionDigestStrength, s.weights.DigestStrength, scoreDigestStrength(assessment)),
+		s.criterion(CriterionDenialOfExistence, s.weights.DenialOfExistence, scoreDenialOfExistence(assessment)),
+		s.criterion(CriterionSignatureHygiene, s.weights.SignatureHygiene, scoreSignatureHygiene(assessment, now)),
+		s.criterion(CriterionKeyManagement, s.weights.KeyManagement, scoreKeyManagement(assessment)),
+	}
+
+	weighted, totalWeight := 0.0, 0.0
+	for _, c := range breakdown {
+		weighted += c.Score * c.Weight
+		totalWeight += c.Weight
+	}
+	total := 0.0
+	if totalWeight > 0 {
+		total = round(weighted / totalWeight)
+	}
+
+	return &models.SecurityScore{
+		Score:     total,
+		Grade:     Grade(total),
+		Breakdown: breakdown,
+	}
+}
+
+// Grade maps a 0–100 score to a letter grade.
+func Grade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+type criterionResult struct {
+	score    float64
+	findings []string
+}
+
+func (s *Scorer) criterion(name string, weight float64, result criterionResult) models.CriterionScore {
+	return models.CriterionScore{
+		Criterion: name,
+		Weight:    weight,
+		Score:     round(result.score),
+		Findings:  result.findings,
+	}
+}
+
+func scoreDeployment(assessment *models.Assessment) criterionResult {
+	result := criterionResult{}
+	dnskey := dnskeyResponse(assessment)
+	if dnskey != nil && len(dnskey.Records) > 0 {
+		result.score += 40
+	} else {
+		result.findings = append(result.findings, "no DNSKEY records published")
+	}
+	ds := dsResponse(assessment)
+	if ds != nil && len(ds.Records) > 0 {
+		result.score += 30
+	} else {
+		result.findings = append(result.findings, "no DS records at the parent zone")
+	}
+	if dnskey != nil && dnskey.Validated {
+		result.score += 30
+	} else {
+		result.findings = append(result.findings, "DNSKEY answer was not validated")
+	}
+	return result
+}
+
+func scoreAlgorithmStrength(assessment *models.Assessment) criterionResult {
+	dnskey := dnskeyResponse(assessment)
+	if dnskey == nil || len(dnskey.Records) == 0 {
+		return criterionResult{findings: []string{"no DNSKEY records to evaluate"}}
+	}
+	result := criterionResult{score: 100}
+	for _, key := range dnskey.Records {
+		score := algorithmScores[key.Algorithm]
+		if score < 100 {
+			result.findings = appendUnique(result.findings,
+				fmt.Sprintf("algorithm %d (%s) is not recommended for signing", key.Algorithm, key.AlgorithmName))
+		}
+		result.score = math.Min(result.score, score)
+	}
+	return result
+}
+
+func scoreDigestStrength(assessment *models.Assessment) criterionResult {
+	ds := dsResponse(assessment)
+	if ds == nil || len(ds.Records) == 0 {
+		return criterionResult{findings: []string{"no DS records to evaluate"}}
+	}
+	result := criterionResult{score: 100}
+	for _, record := range ds.Records {
+		score := digestScores[record.DigestType]
+		if score < 100 {
+			result.findings = appendUnique(result.findings,
+				fmt.Sprintf("DS digest type %d is not recommended", record.DigestType))
+		}
+		result.score = math.Min(result.score, score)
+	}
+	return result
+}
+
+func scoreDenialOfExistence(assessment *models.Assessment) criterionResult {
+	result := scoreDenialParameters(assessment)
+	report := assessment.DenialOfExistence
+	if report == nil || report.Method == DenialMethodNone || result.score == 0 {
+		return result
+	}
+	if report.OptOut {
+		result.score = math.Max(result.score-10, 0)
+		result.findings = append(result.findings, "NSEC3 opt-out leaves unsigned delegations unprotected")
+	}
+	if !report.ProofComplete {
+		result.score = math.Min(result.score, 40)
+		result.findings = append(result.findings, "the negative response carries an incomplete denial of existence proof")
+	}
+	return result
+}
+
+func scoreDenialParameters(assessment *models.Assessment) criterionResult {
+	nsec3Param := nsec3ParamRecord(assessment)
+	if nsec3Param != nil && (nsec3Param.RRSIG != nil || nsec3Param.HashAlgorithm != 0) {
+		result := criterionResult{score: 100}
+		switch {
+		case nsec3Param.Iterations > 100:
+			result.score = 20
+		case nsec3Param.Iterations > 10:
+			result.score = 50
+		case nsec3Param.Iterations > 0:
+			result.score = 80
+		}
+		if nsec3Param.Iterations > 0 {
+			result.findings = append(result.findings,
+				fmt.Sprintf("NSEC3 uses %d additional iterations, RFC 9276 recommends 0", nsec3Param.Iterations))
+		}
+		if nsec3Param.SaltLength > 0 {
+			result.score = math.Max(result.score-10, 0)
+			result.findings = append(result.findings, "NSEC3 uses a salt, RFC 9276 recommends none")
+		}
+		return result
+	}
+	nsec := nsecRecord(assessment)
+	if nsec != nil && nsec.NextDomainName != "" {
+		return criterionResult{score: 60, findings: []string{"plain NSEC in use, the zone may be enumerable"}}
+	}
+	return criterionResult{findings: []string{"no authenticated denial of existence detected"}}
+}
+
+func scoreSignatureHygiene(assessment *models.Assessment, now time.Time) criterionResult {
+	sigs := signatures(assessment)
+	if len(sigs) == 0 {
+		return criterionResult{findings: []string{"no signatures to evaluate"}}
+	}
+
+	recordTypes := make([]string, 0, len(sigs))
+	for recordType := range sigs {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Strings(recordTypes)
+
+	result := criterionResult{score: 100}
+	addFinding := func(finding string) {
+		if !slices.Contains(result.findings, finding) {
+			result.findings = append(result.findings, finding)
+		}
+	}
+	for _, recordType := range recordTypes {
+		for _, sig := range sigs[recordType] {
+			inception := time.Unix(int64(sig.Inception), 0)
+			expiration := time.Unix(int64(sig.Expiration), 0)
+			switch {
+			case now.After(expiration):
+				result.score = 0
+				addFinding(fmt.Sprintf("%s signature expired", recordType))
+			case now.Before(inception):
+				result.score = 0
+				addFinding(fmt.Sprintf("%s signature is not yet valid", recordType))
+			case expiration.Sub(now) < signatureExpiryWarning:
+				result.score = math.Min(result.score, 50)
+				addFinding(fmt.Sprintf("%s signature expires within 3 days", recordType))
+			case expiration.Sub(inception) > signatureValidityLimit:
+				result.score = math.Min(result.score, 70)
+				addFinding(fmt.Sprintf("%s signature validity period exceeds 180 days", recordType))
+			}
+		}
+	}
+	return result
+}
+
+func scoreKeyManagement(assessment *models.Assessment) criterionResult {
+	dnskey := dnskeyResponse(assessment)
+	if dnskey == nil || len(dnskey.Records) == 0 {
+		return criterionResult{findings: []string{"no DNSKEY records to evaluate"}}
+	}
+	result := criterionResult{}
+
+	hasKSK, hasZSK := false, false
+	for _, key := range dnskey.Records {
+		switch key.Flags {
+		case 257:
+			hasKSK = true
+		case 256:
+			hasZSK = true
+		}
+	}
+	if hasKSK && hasZSK {
+		result.score += 40
+	} else {
+		result.score += 25
+		result.findings = append(result.findings, "no KSK/ZSK split, a single key type is in use")
+	}
+
+	ds := dsResponse(assessment)
+	if ds == nil || len(ds.Records) == 0 {
+		result.findings = append(result.findings, "no DS record links the key set to the parent zone")
+	} else if dsMatchesKey(assessment) {
+		result.score += 40
+	} else {
+		result.findings = append(result.findings, "no DS record references a published DNSKEY")
+	}
+
+	if len(dnskey.Records) <= maxExpectedKeys {
+		result.score += 20
+	} else {
+		result.score += 10
+		result.findings = append(result.findings,
+			fmt.Sprintf("%d DNSKEY records published, more than the %d expected", len(dnskey.Records), maxExpectedKeys))
+	}
+	return result
+}
+
+// dsMatchesKey reports whether at least one DS record references a published DNSKEY by key tag and algorithm.
+func dsMatchesKey(assessment *models.Assessment) bool {
+	dnskey := dnskeyResponse(assessment)
+	ds := dsResponse(assessment)
+	if dnskey == nil || ds == nil {
+		return false
+	}
+	for _, record := range ds.Records {
+		for _, key := range dnskey.Records {
+			if record.KeyTag == key.KeyID && record.Algorithm == key.Algorithm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
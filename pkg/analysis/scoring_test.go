@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/config"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+	"time"
+)
+
+var testWeights = config.ScoringConfig{
+	Deployment:        30,
+	AlgorithmStrength: 20,
+	DigestStrength:    10,
+	DenialOfExistence: 15,
+	SignatureHygiene:  15,
+	KeyManagement:     10,
+}
+
+func newSignedAssessment(scanTime time.Time) *models.Assessment {
+	inception := uint32(scanTime.Add(-7 * 24 * time.Hour).Unix())
+	expiration := uint32(scanTime.Add(14 * 24 * time.Hour).Unix())
+	assessment := models.NewAssessment("https://example.com", "example.com")
+	assessment.Start = scanTime
+	assessment.End = scanTime
+	assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{
+		Records: []dnsrecords.DNSKEYRecord{
+			{Flags: 256, Protocol: 3, Algorithm: 13, KeyType: "ZSK", AlgorithmName: "ECDSAP256SHA256", KeyID: 1111},
+			{Flags: 257, Protocol: 3, Algorithm: 13, KeyType: "KSK", AlgorithmName: "ECDSAP256SHA256", KeyID: 2222},
+		},
+		Validated: true,
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", Algorithm: 13, KeyTag: 2222,
+			Inception: inception, Expiration: expiration},
+	}
+	assessment.Records["DS"] = &dnsrecords.DSResponse{
+		Records:   []dnsrecords.DSRecord{{KeyTag: 2222, Algorithm: 13, DigestType: 2, Digest: "ABCDEF"}},
+		Validated: true,
+	}
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{
+		Validated: true,
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "SOA", Algorithm: 13, KeyTag: 1111,
+			Inception: inception, Expiration: expiration},
+	}
+	assessment.Records["NSEC3PARAM"] = &dnsrecords.NSEC3PARAMRecord{
+		HashAlgorithm: 1,
+		Validated:     true,
+		RRSIG: &dnsrecords.RRSIGRecord{TypeCovered: "NSEC3PARAM", Algorithm: 13, KeyTag: 1111,
+			Inception: inception, Expiration: expiration},
+	}
+	return assessment
+}
+
+func TestScoreWellConfiguredZone(t *testing.T) {
+	scanTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	score := NewScorer(testWeights).Score(newSignedAssessment(scanTime))
+
+	if score.Score != 100 {
+		t.Errorf("Expected score 100, got %v (%+v)", score.Score, score.Breakdown)
+	}
+	if score.Grade != "A" {
+		t.Errorf("Expected grade A, got %s", score.Grade)
+	}
+	if len(score.Breakdown) != 6 {
+		t.Fatalf("Expected 6 criteria in the breakdown, got %d", len(score.Breakdown))
+	}
+}
+
+func TestScoreWeakConfiguration(t *testing.T) {
+	scanTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assessment := newSignedAssessment(scanTime)
+	dnskey := assessment.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse)
+	for i := range dnskey.Records {
+		dnskey.Records[i].Algorithm = 5
+		dnskey.Records[i].AlgorithmName = "RSASHA1"
+	}
+	ds := assessment.Records["DS"].(*dnsrecords.DSResponse)
+	ds.Records[0].DigestType = 1
+	ds.Records[0].Algorithm = 5
+	nsec3Param := assessment.Records["NSEC3PARAM"].(*dnsrecords.NSEC3PARAMRecord)
+	nsec3Param.Iterations = 150
+	nsec3Param.SaltLength = 8
+	dnskey.RRSIG.Expiration = uint32(scanTime.Add(-time.Hour).Unix())
+
+	score := NewScorer(testWeights).Score(assessment)
+
+	expected := map[string]float64{
+		CriterionDeployment:        100,
+		CriterionAlgorithmStrength: 20,
+		CriterionDigestStrength:    30,
+		CriterionDenialOfExistence: 10,
+		CriterionSignatureHygiene:  0,
+		CriterionKeyManagement:     100,
+	}
+	for _, criterion := range score.Breakdown {
+		if criterion.Score != expected[criterion.Criterion] {
+			t.Errorf("Expected %s score %v, got %v", criterion.Criterion, expected[criterion.Criterion], criterion.Score)
+		}
+		if criterion.Score < 100 && len(criterion.Findings) == 0 {
+			t.Errorf("Expected findings explaining the %s deductions", criterion.Criterion)
+		}
+	}
+	if score.Score != 48.5 {
+		t.Errorf("Expected score 48.5, got %v", score.Score)
+	}
+	if score.Grade != "F" {
+		t.Errorf("Expected grade F, got %s", score.Grade)
+	}
+}
+
+func TestScoreUnsignedZone(t *testing.T) {
+	assessment := models.NewAssessment("https://example.com", "example.com")
+	assessment.Records["SOA"] = &dnsrecords.SOARecord{Validated: false}
+
+	score := NewScorer(testWeights).Score(assessment)
+
+	if score.Score != 0 {
+		t.Errorf("Expected score 0, got %v", score.Score)
+	}
+	if score.Grade != "F" {
+		t.Errorf("Expected grade F, got %s", score.Grade)
+	}
+}
+
+func TestGrade(t *testing.T) {
+	testCases := []struct {
+		score    float64
+		expected string
+	}{
+		{100, "A"},
+		{90, "A"},
+		{89.9, "B"},
+		{75, "C"},
+		{60, "D"},
+		{59.9, "F"},
+		{0, "F"},
+	}
+	for _, tc := range testCases {
+		if grade := Grade(tc.score); grade != tc.expected {
+			t.Errorf("Grade(%v): expected %s, got %s", tc.score, tc.expected, grade)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// AnalyzeServiceBinding reports HTTPS records of a host whose answer is not secure, since the
+// protocols, Encrypted Client Hello configurations and address hints they carry can then be
+// altered or stripped on the way to the client. status is the DNSSEC status of the answer.
+func AnalyzeServiceBinding(host string, https *dnsrecords.SVCBResponse, status string) *models.ServiceBindingReport {
+	report := &models.ServiceBindingReport{Host: normalizeName(host), Status: status}
+	if https == nil {
+		return report
+	}
+	report.Records = https.Records
+	report.Validated = https.Validated
+	for _, record := range https.Records {
+		report.ECH = report.ECH || record.ECH != ""
+	}
+	if len(https.Records) == 0 {
+		return report
+	}
+
+	switch status {
+	case VerdictSecure:
+	case VerdictBogus:
+		report.Findings = append(report.Findings, fmt.Sprintf("the HTTPS records of %s are bogus", report.Host))
+	default:
+		parameters := "protocols and address hints"
+		if report.ECH {
+			parameters = "protocols, address hints and ECH configurations"
+		}
+		report.Findings = append(report.Findings, fmt.Sprintf("the HTTPS records of %s are not DNSSEC-validated, so their %s can be altered",
+			report.Host, parameters))
+	}
+	return report
+}
@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeServiceBindingSecure(t *testing.T) {
+	https := &dnsrecords.SVCBResponse{RecordType: "HTTPS", Validated: true, Records: []dnsrecords.SVCBRecord{
+		{Priority: 1, Target: ".", ALPN: []string{"h3", "h2"}, ECH: "AEX+DQBBpQAgACBNhA=="},
+	}}
+	report := AnalyzeServiceBinding("www.example.pt", https, VerdictSecure)
+
+	if !report.ECH || !report.Validated || len(report.Findings) != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestAnalyzeServiceBindingUnsignedECH(t *testing.T) {
+	https := &dnsrecords.SVCBResponse{RecordType: "HTTPS", Records: []dnsrecords.SVCBRecord{
+		{Priority: 1, Target: ".", ECH: "AEX+DQBBpQAgACBNhA=="},
+	}}
+	report := AnalyzeServiceBinding("www.example.pt", https, VerdictInsecure)
+
+	if len(report.Findings) != 1 || !strings.Contains(report.Findings[0], "ECH configurations can be altered") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeServiceBindingNone(t *testing.T) {
+	report := AnalyzeServiceBinding("www.example.pt", &dnsrecords.SVCBResponse{RecordType: "HTTPS"}, VerdictInsecure)
+
+	if len(report.Records) != 0 || len(report.Findings) != 0 {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+}
@@ -0,0 +1,18 @@
+package analysis
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+
+// AssessmentStatus summarizes the DNSSEC deployment of an assessment as a verdict: secure when
+// the DNSKEY set of the domain was validated, bogus when the parent holds DS records but the keys
+// could not be validated, and insecure otherwise, which covers unsigned zones and signed zones
+// without a chain of trust.
+func AssessmentStatus(assessment *models.Assessment) string {
+	dnskey := dnskeyResponse(assessment)
+	if dnskey != nil && dnskey.Validated && len(dnskey.Records) > 0 {
+		return VerdictSecure
+	}
+	if ds := dsResponse(assessment); ds != nil && len(ds.Records) > 0 {
+		return VerdictBogus
+	}
+	return VerdictInsecure
+}
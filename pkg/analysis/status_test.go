@@ -0,0 +1,25 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+)
+
+func TestAssessmentStatus(t *testing.T) {
+	ksk, ds := linkageKSK(t)
+	secure := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk}, []dnsrecords.DSRecord{ds}, nil)
+	bogus := newLinkageAssessment(t, nil, []dnsrecords.DSRecord{ds}, nil)
+	island := newLinkageAssessment(t, []dnsrecords.DNSKEYRecord{ksk}, nil, nil)
+	island.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse).Validated = false
+
+	for expected, assessment := range map[string]*models.Assessment{
+		VerdictSecure:   secure,
+		VerdictBogus:    bogus,
+		VerdictInsecure: island,
+	} {
+		if status := AssessmentStatus(assessment); status != expected {
+			t.Errorf("Expected status %s, got %s", expected, status)
+		}
+	}
+}
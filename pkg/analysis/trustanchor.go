@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"time"
+)
+
+// dnskeyFlagRevoke is the REVOKE flag of a DNSKEY record (RFC 5011, Section 3).
+const dnskeyFlagRevoke = 0x0080
+
+// dnskeyFlagSEP is the Secure Entry Point flag that marks key signing keys (RFC 4034, Section 2.1.1).
+const dnskeyFlagSEP = 0x0001
+
+// AnalyzeTrustAnchors matches the configured anchors of a zone against the DNSKEY set the zone
+// publishes, to tell which anchor validation started from. It also reports the events RFC 5011
+// reacts to: keys revoked by the zone, key signing keys that are published but not configured,
+// and configured anchors that the zone no longer publishes. When anchors is empty, the built-in
+// anchors of delv were used and only the signing keys are reported. Every key signing the DNSKEY
+// set is matched, since the set carries a signature of both the old and the new key during a KSK rollover.
+func AnalyzeTrustAnchors(source string, anchors []models.TrustAnchor, zone string, dnskey *dnsrecords.DNSKEYResponse,
+	at time.Time) *models.TrustAnchorReport {
+	report := &models.TrustAnchorReport{Source: source, Zone: zone}
+	for _, anchor := range anchors {
+		if anchor.Zone != zone {
+			continue
+		}
+		if anchor.IsValidAt(at) {
+			report.Anchors = append(report.Anchors, anchor)
+		} else {
+			report.Findings = append(report.Findings, fmt.Sprintf("trust anchor %s is outside its validity period", describeAnchor(anchor)))
+		}
+	}
+
+	if dnskey == nil || len(dnskey.Records) == 0 {
+		report.Findings = append(report.Findings, fmt.Sprintf("the DNSKEY set of zone %s could not be retrieved", zone))
+		return report
+	}
+	for _, rrsig := range allSignatures(dnskey.RRSIG, dnskey.RRSIGs) {
+		if !slices.Contains(report.SigningKeyTags, rrsig.KeyTag) {
+			report.SigningKeyTags = append(report.SigningKeyTags, rrsig.KeyTag)
+		}
+	}
+	slices.Sort(report.SigningKeyTags)
+	if len(anchors) == 0 {
+		return report
+	}
+
+	configured := make(map[int]bool)
+	for _, anchor := range report.Anchors {
+		published := false
+		for i, key := range dnskey.Records {
+			if key.Flags&dnskeyFlagRevoke != 0 {
+				unrevoked := key
+				unrevoked.Flags &^= dnskeyFlagRevoke
+				if anchorMatches(zone, anchor, unrevoked) {
+					configured[i] = true
+					report.Findings = append(report.Findings,
+						fmt.Sprintf("trust anchor %s is revoked by the zone (RFC 5011)", describeAnchor(anchor)))
+				}
+				continue
+			}
+			if !anchorMatches(zone, anchor, key) {
+				continue
+			}
+			published = true
+			configured[i] = true
+			if len(report.SigningKeyTags) == 0 || slices.Contains(report.SigningKeyTags, key.KeyID) {
+				report.UsedAnchors = append(report.UsedAnchors, anchor)
+			}
+		}
+		if !published {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("trust anchor %s does not match any key published in zone %s", describeAnchor(anchor), zone))
+		}
+	}
+
+	for i, key := range dnskey.Records {
+		if configured[i] || key.Flags&dnskeyFlagSEP == 0 || key.Flags&dnskeyFlagRevoke != 0 {
+			continue
+		}
+		report.Findings = append(report.Findings,
+			fmt.Sprintf("KSK %d is published in zone %s but is not a configured trust anchor (RFC 5011 add hold-down)", key.KeyID, zone))
+	}
+	if len(report.UsedAnchors) == 0 {
+		report.Findings = append(report.Findings, fmt.Sprintf("no configured trust anchor matches the key signing the DNSKEY set of zone %s", zone))
+	}
+	return report
+}
+
+func anchorMatches(zone string, anchor models.TrustAnchor, key dnsrecords.DNSKEYRecord) bool {
+	if anchor.DS != nil {
+		return dsMatchesDNSKEY(zone, *anchor.DS, key)
+	}
+	return anchor.DNSKEY != nil && sameDNSKEY(*anchor.DNSKEY, key)
+}
+
+func describeAnchor(anchor models.TrustAnchor) string {
+	switch {
+	case anchor.DS != nil:
+		return fmt.Sprintf("DS %d for %s", anchor.DS.KeyTag, anchor.Zone)
+	case anchor.DNSKEY != nil && anchor.DNSKEY.KeyID != 0:
+		return fmt.Sprintf("DNSKEY %d for %s", anchor.DNSKEY.KeyID, anchor.Zone)
+	case anchor.DNSKEY != nil:
+		return fmt.Sprintf("DNSKEY with algorithm %d for %s", anchor.DNSKEY.Algorithm, anchor.Zone)
+	default:
+		return "for " + anchor.Zone
+	}
+}
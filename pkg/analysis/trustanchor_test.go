@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeTrustAnchorsUsedAnchor(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newKSK := dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 8, PublicKey: "AwEAAaz/tAm8yTn4Mfeh", KeyID: 38696}
+	anchor := rfc4034DS
+	expired := dnsrecords.DSRecord{KeyTag: 19036, Algorithm: 8, DigestType: 2, Digest: "49AAC11D7B6F6446702E54A1607371607A1A41855200FD2CE1CDDE32F24E8FB5"}
+	anchors := []models.TrustAnchor{
+		{Zone: "dskey.example.com.", DS: &anchor},
+		{Zone: "dskey.example.com.", DS: &expired, ValidUntil: now.Add(-time.Hour)},
+		{Zone: "other.example.", DS: &expired},
+	}
+	dnskey := &dnsrecords.DNSKEYResponse{
+		Records: []dnsrecords.DNSKEYRecord{rfc4034Key, newKSK},
+		RRSIG:   &dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", KeyTag: 60485},
+	}
+
+	report := AnalyzeTrustAnchors("anchors.xml", anchors, "dskey.example.com.", dnskey, now)
+
+	if !slices.Equal(report.SigningKeyTags, []uint16{60485}) {
+		t.Errorf("Expected signing key tag 60485, got %v", report.SigningKeyTags)
+	}
+	if len(report.Anchors) != 1 || len(report.UsedAnchors) != 1 || report.UsedAnchors[0].DS.KeyTag != 60485 {
+		t.Errorf("Expected anchor 60485 to be used, got %+v", report.UsedAnchors)
+	}
+	if len(report.Findings) != 2 ||
+		!strings.Contains(report.Findings[0], "outside its validity period") ||
+		!strings.Contains(report.Findings[1], "KSK 38696") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeTrustAnchorsRevokedKey(t *testing.T) {
+	anchor := rfc4034DS
+	revoked := rfc4034Key
+	revoked.Flags |= dnskeyFlagRevoke
+	dnskey := &dnsrecords.DNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{revoked}}
+
+	report := AnalyzeTrustAnchors("anchors.conf", []models.TrustAnchor{{Zone: "dskey.example.com.", DS: &anchor}},
+		"dskey.example.com.", dnskey, time.Now())
+
+	if len(report.UsedAnchors) != 0 {
+		t.Errorf("Expected no usable anchor, got %+v", report.UsedAnchors)
+	}
+	if len(report.Findings) != 3 || !strings.Contains(report.Findings[0], "revoked") {
+		t.Errorf("Unexpected findings %v", report.Findings)
+	}
+}
+
+func TestAnalyzeTrustAnchorsBuiltIn(t *testing.T) {
+	dnskey := &dnsrecords.DNSKEYResponse{
+		Records: []dnsrecords.DNSKEYRecord{rfc4034Key},
+		RRSIG:   &dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", KeyTag: 20326},
+	}
+	report := AnalyzeTrustAnchors("built-in", nil, ".", dnskey, time.Now())
+	if !slices.Equal(report.SigningKeyTags, []uint16{20326}) || len(report.Findings) != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestAnalyzeTrustAnchorsKSKRollover(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldAnchor := rfc4034DS
+	newKSK := dnsrecords.DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 8, PublicKey: "AwEAAaz/tAm8yTn4Mfeh", KeyID: 38696}
+	anchors := []models.TrustAnchor{
+		{Zone: "dskey.example.com.", DS: &oldAnchor},
+		{Zone: "dskey.example.com.", DNSKEY: &newKSK},
+	}
+	for _, order := range [][]uint16{{60485, 38696}, {38696, 60485}} {
+		dnskey := &dnsrecords.DNSKEYResponse{Records: []dnsrecords.DNSKEYRecord{rfc4034Key, newKSK}}
+		for _, keyTag := range order {
+			dnskey.RRSIGs = append(dnskey.RRSIGs, dnsrecords.RRSIGRecord{TypeCovered: "DNSKEY", KeyTag: keyTag})
+		}
+		dnskey.RRSIG = &dnskey.RRSIGs[len(dnskey.RRSIGs)-1]
+
+		report := AnalyzeTrustAnchors("anchors.xml", anchors, "dskey.example.com.", dnskey, now)
+
+		if !slices.Equal(report.SigningKeyTags, []uint16{38696, 60485}) {
+			t.Errorf("Signatures %v: expected both signing key tags, got %v", order, report.SigningKeyTags)
+		}
+		if len(report.UsedAnchors) != 2 || len(report.Findings) != 0 {
+			t.Errorf("Signatures %v: expected both anchors to be used, got %+v, %v", order, report.UsedAnchors, report.Findings)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+)
+
+// zonemdDigestLengths are the lengths, in hexadecimal digits, of the digests of each hash algorithm.
+var zonemdDigestLengths = map[uint8]int{
+	dnsrecords.ZONEMDHashSHA384: 2 * sha512.Size384,
+	dnsrecords.ZONEMDHashSHA512: 2 * sha512.Size,
+}
+
+// AnalyzeZONEMD reports ZONEMD records that recipients of the zone cannot use: unknown schemes
+// and hash algorithms, malformed digests, digests of another zone version than the one served,
+// answers that are not secure, so that the digest only detects accidental corruption, and failed
+// verifications. soaSerial is the serial of the SOA record served for the zone, zero when unknown.
+// verification may be nil.
+func AnalyzeZONEMD(zone string, zonemd *dnsrecords.ZONEMDResponse, soaSerial uint32, status string,
+	verification *models.ZONEMDVerification) *models.ZONEMDReport {
+	report := &models.ZONEMDReport{Zone: normalizeName(zone), Status: status, Verification: verification}
+	if zonemd == nil || len(zonemd.Records) == 0 {
+		return report
+	}
+	report.Records = zonemd.Records
+
+	for _, record := range zonemd.Records {
+		describe := fmt.Sprintf("ZONEMD %d %d %d of %s", record.Serial, record.Scheme, record.HashAlgorithm, report.Zone)
+		if record.SchemeName() == "unknown" {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s has the unknown scheme %d", describe, record.Scheme))
+		}
+		if length, ok := zonemdDigestLengths[record.HashAlgorithm]; !ok {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s has the unknown hash algorithm %d", describe, record.HashAlgorithm))
+		} else if len(record.Digest) != length {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s holds %d hexadecimal digits where %s needs %d",
+				describe, len(record.Digest), record.HashAlgorithmName(), length))
+		}
+		if soaSerial != 0 && record.Serial != soaSerial {
+			report.Findings = append(report.Findings, fmt.Sprintf("%s was computed for serial %d but the zone serves serial %d",
+				describe, record.Serial, soaSerial))
+		}
+	}
+
+	switch status {
+	case VerdictSecure:
+	case VerdictBogus:
+		report.Findings = append(report.Findings, fmt.Sprintf("the ZONEMD records of %s are bogus", report.Zone))
+	default:
+		report.Findings = append(report.Findings, fmt.Sprintf("the ZONEMD records of %s are not DNSSEC-validated and only detect accidental corruption",
+			report.Zone))
+	}
+	if verification != nil && verification.Error != "" {
+		report.Findings = append(report.Findings, fmt.Sprintf("the zone digest of %s could not be verified: %s", report.Zone, verification.Error))
+	}
+	return report
+}
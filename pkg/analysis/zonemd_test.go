@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+	"testing"
+)
+
+const testZONEMDDigest = "C68090D90A7AED716BC459F9340E3D7C1370D4D24B7E2FC3A1DDC0B9A87153B9A9713B3C9AE5CC27777F98B8E730044C"
+
+func TestAnalyzeZONEMDSecure(t *testing.T) {
+	zonemd := &dnsrecords.ZONEMDResponse{Validated: true, Records: []dnsrecords.ZONEMDRecord{
+		{Serial: 2018031900, Scheme: 1, HashAlgorithm: 1, Digest: testZONEMDDigest},
+	}}
+	report := AnalyzeZONEMD("example", zonemd, 2018031900, VerdictSecure, &models.ZONEMDVerification{Matched: true})
+
+	if len(report.Records) != 1 || len(report.Findings) != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestAnalyzeZONEMDProblems(t *testing.T) {
+	zonemd := &dnsrecords.ZONEMDResponse{Records: []dnsrecords.ZONEMDRecord{
+		{Serial: 2018031900, Scheme: 1, HashAlgorithm: 2, Digest: testZONEMDDigest},
+		{Serial: 2018031900, Scheme: 9, HashAlgorithm: 7, Digest: "00"},
+	}}
+	report := AnalyzeZONEMD("example", zonemd, 2018031901, VerdictInsecure,
+		&models.ZONEMDVerification{Error: "the SHA384 digest does not match"})
+
+	expected := []string{
+		"needs 128",
+		"serves serial 2018031901",
+		"unknown scheme 9",
+		"unknown hash algorithm 7",
+		"serves serial 2018031901",
+		"only detect accidental corruption",
+		"could not be verified: the SHA384 digest does not match",
+	}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), report.Findings)
+	}
+	for i, note := range expected {
+		if !strings.Contains(report.Findings[i], note) {
+			t.Errorf("Expected finding %d to mention %q, got %q", i, note, report.Findings[i])
+		}
+	}
+}
+
+func TestAnalyzeZONEMDNone(t *testing.T) {
+	report := AnalyzeZONEMD("example.pt", &dnsrecords.ZONEMDResponse{}, 1, VerdictInsecure, nil)
+
+	if len(report.Records) != 0 || len(report.Findings) != 0 {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"strings"
+)
+
+// ZoneWalk follows the NSEC chain of a zone one record at a time. The caller issues the
+// queries and feeds each NSEC record to Step, which returns the next name to query; this
+// keeps the rate limiting and the transport out of the analysis.
+type ZoneWalk struct {
+	apex     string
+	maxSteps int
+	visited  map[string]bool
+	report   *models.ZoneWalkReport
+	done     bool
+}
+
+func NewZoneWalk(apex string, maxSteps int) *ZoneWalk {
+	apex = normalizeName(apex)
+	return &ZoneWalk{
+		apex:     apex,
+		maxSteps: maxSteps,
+		visited:  map[string]bool{apex: true},
+		report:   &models.ZoneWalkReport{Performed: true},
+	}
+}
+
+// Start returns the first name to query, the apex of the zone.
+func (w *ZoneWalk) Start() string {
+	return w.apex
+}
+
+// Step records the NSEC record found at owner and returns the next name to query.
+// It returns false when the walk is over, either because the chain returned to the apex
+// or because it cannot or must not be followed any further.
+func (w *ZoneWalk) Step(owner string, record *dnsrecords.NSECRecord) (string, bool) {
+	if w.done {
+		return "", false
+	}
+	w.report.Steps++
+
+	if record == nil || record.NextDomainName == "" {
+		return w.stop(fmt.Sprintf("no NSEC record at %s", normalizeName(owner)))
+	}
+	if strings.Contains(record.Types, "NXNAME") {
+		w.report.CompactDenial = true
+	}
+	next := normalizeName(record.NextDomainName)
+	switch {
+	case isSynthesizedName(next):
+		w.report.MinimalNSEC = true
+		return w.stop("the zone synthesizes minimally covering NSEC records")
+	case next == w.apex:
+		w.report.Complete = true
+		return w.stop("the NSEC chain returned to the apex")
+	case !isSubdomain(next, w.apex):
+		return w.stop("the NSEC chain left the zone")
+	case w.visited[next]:
+		return w.stop("the NSEC chain contains a loop")
+	}
+
+	w.visited[next] = true
+	w.report.DiscoveredNames++
+	w.report.Enumerable = true
+	if w.report.Steps >= w.maxSteps {
+		return w.stop(fmt.Sprintf("the step limit of %d was reached", w.maxSteps))
+	}
+	return next, true
+}
+
+// Report returns the outcome of the walk. The denial of existence report of the same
+// assessment, if any, is used to detect minimally covering NSEC records synthesized for
+// the probe name even when the apex record does not reveal them.
+func (w *ZoneWalk) Report(denial *models.DenialOfExistenceReport) *models.ZoneWalkReport {
+	if denial != nil && denial.Response != nil {
+		for _, record := range denial.Response.NSECRecords {
+			if strings.Contains(record.Types, "NXNAME") {
+				w.report.CompactDenial = true
+			}
+			if isMinimalCovering(denial.QueryName, record) {
+				w.report.MinimalNSEC = true
+			}
+		}
+	}
+	return w.report
+}
+
+func (w *ZoneWalk) stop(reason string) (string, bool) {
+	w.done = true
+	w.report.StopReason = reason
+	return "", false
+}
+
+// SkippedZoneWalk returns the report of a walk that was not performed.
+func SkippedZoneWalk(reason string) *models.ZoneWalkReport {
+	return &models.ZoneWalkReport{StopReason: reason}
+}
+
+// isSynthesizedName reports whether a name is an immediate successor synthesized by an
+// online signer, which prefixes the owner name with a label holding a single zero octet.
+func isSynthesizedName(name string) bool {
+	return strings.HasPrefix(name, `\000.`)
+}
+
+// isMinimalCovering reports whether an NSEC record returned for queryName was synthesized
+// for it: either the record is owned by the query name itself ("black lies") or its span
+// ends at the immediate successor of the query name (RFC 4470).
+func isMinimalCovering(queryName string, record dnsrecords.NSECProofRecord) bool {
+	queryName = normalizeName(queryName)
+	return normalizeName(record.OwnerName) == queryName ||
+		normalizeName(record.NextDomainName) == `\000.`+queryName
+}
@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"testing"
+)
+
+func walkChain(walk *ZoneWalk, chain map[string]string) {
+	name, ok := walk.Start(), true
+	for ok {
+		var record *dnsrecords.NSECRecord
+		if next, found := chain[name]; found {
+			record = &dnsrecords.NSECRecord{NextDomainName: next, Types: "A;RRSIG;NSEC"}
+		}
+		name, ok = walk.Step(name, record)
+	}
+}
+
+func TestZoneWalkComplete(t *testing.T) {
+	chain := map[string]string{
+		"example.org":      "mail.example.org.",
+		"mail.example.org": "www.example.org.",
+		"www.example.org":  "example.org.",
+	}
+	walk := NewZoneWalk("example.org", 10)
+	walkChain(walk, chain)
+	report := walk.Report(nil)
+
+	if !report.Complete || !report.Enumerable {
+		t.Errorf("Expected a complete, enumerable walk, got %+v", report)
+	}
+	if report.DiscoveredNames != 2 || report.Steps != 3 {
+		t.Errorf("Expected 2 names in 3 steps, got %d names in %d steps", report.DiscoveredNames, report.Steps)
+	}
+}
+
+func TestZoneWalkStepLimit(t *testing.T) {
+	chain := map[string]string{
+		"example.org":   "a.example.org.",
+		"a.example.org": "b.example.org.",
+		"b.example.org": "c.example.org.",
+		"c.example.org": "example.org.",
+	}
+	walk := NewZoneWalk("example.org", 2)
+	walkChain(walk, chain)
+	report := walk.Report(nil)
+
+	if report.Complete || report.Steps != 2 || report.DiscoveredNames != 2 {
+		t.Errorf("Expected the walk to stop after 2 steps, got %+v", report)
+	}
+	if !report.Enumerable {
+		t.Errorf("Expected the zone to be reported as enumerable")
+	}
+}
+
+func TestZoneWalkBlackLies(t *testing.T) {
+	chain := map[string]string{
+		"example.org": `\000.example.org.`,
+	}
+	walk := NewZoneWalk("example.org", 10)
+	walkChain(walk, chain)
+	report := walk.Report(nil)
+
+	if !report.MinimalNSEC || report.Enumerable || report.DiscoveredNames != 0 {
+		t.Errorf("Expected minimal NSEC to be detected and the zone not enumerable, got %+v", report)
+	}
+}
+
+func TestZoneWalkCompactDenialFromProbe(t *testing.T) {
+	walk := NewZoneWalk("example.org", 10)
+	walkChain(walk, map[string]string{"example.org": `\000.example.org.`})
+	denial := &models.DenialOfExistenceReport{
+		QueryName: "probe.example.org",
+		Response: &dnsrecords.DenialResponse{
+			NSECRecords: []dnsrecords.NSECProofRecord{
+				{OwnerName: "probe.example.org", NextDomainName: `\000.probe.example.org`, Types: "RRSIG;NSEC;NXNAME"},
+			},
+		},
+	}
+	report := walk.Report(denial)
+
+	if !report.MinimalNSEC || !report.CompactDenial || report.Enumerable {
+		t.Errorf("Expected compact denial to be detected and the zone not enumerable, got %+v", report)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+// AliasChainReport represents the CNAME and DNAME records followed from the scanned host to the
+// name that owns its addresses. Websites hosted by content delivery networks are commonly aliases
+// into the zone of the provider, whose DNSSEC deployment then decides whether the addresses of
+// the website can be validated.
+//
+// Fields:
+//
+//	Host: The scanned host name.
+//
+//	Hops: A slice of AliasHop structs, one per alias record followed, and a last one for the
+//	      name that owns the address records.
+//
+//	Target: The name that owns the address records.
+//
+//	Status: The worst DNSSEC status of the hops ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	Findings: Human-readable notes on bogus hops and on signed hosts that alias into unsigned zones.
+type AliasChainReport struct {
+	Host     string
+	Hops     []AliasHop
+	Target   string
+	Status   string
+	Findings []string
+}
+
+// AliasHop represents one name of an alias chain.
+//
+// Fields:
+//
+//	Name: The name, lower-cased and without a trailing dot.
+//
+//	Type: The type of the record owned by the name: "CNAME" or "DNAME" for an alias, or the type
+//	      of the address records for the last hop.
+//
+//	Target: The name the alias points to, empty for the last hop.
+//
+//	Zone: The apex of the zone the name belongs to, empty when it could not be found.
+//
+//	Signed: A boolean flag indicating whether the record carried an RRSIG record. Synthesized
+//	        CNAME records are never signed.
+//
+//	Status: The DNSSEC status of the record as classified from the answer of the resolver
+//	        ("secure", "insecure", "bogus" or "indeterminate").
+type AliasHop struct {
+	Name   string
+	Type   string
+	Target string
+	Zone   string
+	Signed bool
+	Status string
+}
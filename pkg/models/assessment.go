@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
 	"time"
 )
@@ -18,12 +19,89 @@ import (
 //
 //	Url: A string representing the URL associated with the assessment.
 //
-//	Domain: A string representing the domain being assessed.
+//	Domain: A string representing the domain being assessed: the apex of the zone enclosing the
+//	        host of the URL, where zone-level records such as SOA, NS, DNSKEY and DS are queried.
+//	        Internationalized names are kept in A-label form (e.g. "xn--mnchen-3ya.de").
+//
+//	UnicodeDomain: The domain in U-label form (e.g. "münchen.de"), for display.
+//
+//	Host: The host name of the URL in A-label form, where address records are queried. It equals
+//	      Domain when the URL points at the zone apex.
+//
+//	UnicodeHost: The host name in U-label form, for display.
+//
+//	ApexSource: How the zone apex was found: "soa" when it was discovered from the SOA record of
+//	            the enclosing zone, "psl" when the registrable domain of the Public Suffix List was used.
 //
 //	Records: A map where the keys are string identifiers for DNS record types (e.g., "A", "AAAA", "MX"),
 //	         and the values are dnsrecords.DNSRecordResult structs, which contain the results of
 //	         querying each DNS record type.
 //
+//	Score: A pointer to a SecurityScore struct with the numeric score, grade and per-criterion
+//	       breakdown of the assessment. This field is nil until the assessment has been scored.
+//
+//	DenialOfExistence: A pointer to a DenialOfExistenceReport struct describing the negative proof
+//	                   returned for a name that does not exist under the domain.
+//
+//	ZoneWalk: A pointer to a ZoneWalkReport struct describing whether the NSEC chain of the zone
+//	          can be enumerated. This field is nil when zone walking is disabled.
+//
+//	CDS: A pointer to a CDSReport struct describing the CDS/CDNSKEY signalling of the zone and
+//	     whether automated DS maintenance is possible.
+//
+//	KeyLinkage: A pointer to a KeyLinkageReport struct linking each RRSIG record to the DNSKEY
+//	            that produced it, and listing unused keys and signatures made by absent keys.
+//
+//	Rollover: A pointer to a RolloverReport struct with the key rollovers detected by comparing the
+//	          key set with earlier assessments of the domain. It is nil when key history is disabled.
+//
+//	Delegation: A pointer to a DelegationReport struct comparing the delegation at the parent zone
+//	            with the name servers of the zone. It is nil unless the delegation scan is enabled.
+//
+//	Chain: A pointer to a ChainReport struct with the chain of trust evaluated at every zone cut from
+//	       the top-level domain down to the host. It is nil unless the chain scan is enabled.
+//
+//	Aliases: A pointer to an AliasChainReport struct with the CNAME and DNAME records followed from
+//	         the host to its addresses, with the zone and DNSSEC status of every hop. It is nil
+//	         when the host is not an alias.
+//
+//	DANE: A pointer to a DANEReport struct with the TLSA records of the HTTPS endpoint of the host
+//	      and of the SMTP endpoints of the mail exchangers of the domain. It is nil unless the DANE
+//	      scan is enabled.
+//
+//	CAA: A pointer to a CAAReport struct with the CAA record set that applies to the host, found
+//	     by climbing from the host towards the top-level domain, and its DNSSEC status. It is nil
+//	     when the CAA records of the host were not collected.
+//
+//	Mail: A pointer to a MailReport struct with the MX records, the SPF, DMARC, MTA-STS and TLS-RPT
+//	      policies of the domain and the DNSSEC status of each answer. It is nil when the MX
+//	      records of the domain were not collected.
+//
+//	ServiceBinding: A pointer to a ServiceBindingReport struct with the HTTPS records of the host
+//	                and the DNSSEC status of their answer. It is nil when the HTTPS records of the
+//	                host were not collected.
+//
+//	ZONEMD: A pointer to a ZONEMDReport struct with the ZONEMD records of the zone apex, the DNSSEC
+//	        status of their answer and, when enabled, the verification of the digest against a
+//	        copy of the zone. It is nil when the ZONEMD records of the domain were not collected.
+//
+//	Authoritative: A pointer to an AuthoritativeReport struct comparing the SOA and DNSKEY data served
+//	               by each authoritative name server. It is nil unless authoritative querying is enabled.
+//
+//	Resolvers: A pointer to a ResolverComparisonReport struct with the validation verdict of each
+//	           configured resolver. It is nil when no resolvers are configured for comparison.
+//
+//	TrustAnchor: A pointer to a TrustAnchorReport struct describing the trust anchor the validation
+//	             depended on, or the negative trust anchor that disabled it.
+//
+//	ExtendedErrors: A map where the keys are DNS record types and the values are the Extended DNS
+//	                Errors (RFC 8914) the resolver returned for the query of that type. Record types
+//	                without errors are absent.
+//
+//	RecordErrors: A map where the keys are DNS record types and the values describe why the answer
+//	              for that type could not be used, for instance a validation failure, with its
+//	              Extended DNS Errors. Failed types are absent from Records; the scan goes on without them.
+//
 // Constructor:
 //
 //	NewAssessment: Creates and initializes a new instance of Assessment with the specified URL and domain.
@@ -45,16 +123,40 @@ import (
 //	// Mark the assessment as finished
 //	assessment.Finish()
 type Assessment struct {
-	Start   time.Time
-	End     time.Time
-	Url     string
-	Domain  string
-	Records map[string]dnsrecords.DNSRecordResult
+	Start             time.Time
+	End               time.Time
+	Url               string
+	Domain            string
+	UnicodeDomain     string
+	Host              string
+	UnicodeHost       string
+	ApexSource        string
+	Records           map[string]dnsrecords.DNSRecordResult
+	Score             *SecurityScore
+	DenialOfExistence *DenialOfExistenceReport
+	ZoneWalk          *ZoneWalkReport
+	CDS               *CDSReport
+	KeyLinkage        *KeyLinkageReport
+	Rollover          *RolloverReport
+	Delegation        *DelegationReport
+	Chain             *ChainReport
+	Aliases           *AliasChainReport
+	DANE              *DANEReport
+	CAA               *CAAReport
+	Mail              *MailReport
+	ServiceBinding    *ServiceBindingReport
+	ZONEMD            *ZONEMDReport
+	Authoritative     *AuthoritativeReport
+	Resolvers         *ResolverComparisonReport
+	TrustAnchor       *TrustAnchorReport
+	ExtendedErrors    map[string][]dnsrecords.ExtendedDNSError
+	RecordErrors      map[string]string
 }
 
 // NewAssessment creates and initializes a new Assessment instance for a DNS scanning session.
 // This function sets up the assessment with the specified URL and domain and initializes
-// the start time to the current moment. It also prepares an empty map to store DNS record results.
+// the start time to the current moment. It also prepares empty maps to store DNS record results,
+// Extended DNS Errors and record errors.
 //
 // Parameters:
 //
@@ -86,10 +188,15 @@ type Assessment struct {
 //	if the assessment needs to be restarted.
 func NewAssessment(url string, domain string) *Assessment {
 	return &Assessment{
-		Start:   time.Now(),
-		Url:     url,
-		Domain:  domain,
-		Records: make(map[string]dnsrecords.DNSRecordResult),
+		Start:          time.Now(),
+		Url:            url,
+		Domain:         domain,
+		UnicodeDomain:  domain,
+		Host:           domain,
+		UnicodeHost:    domain,
+		Records:        make(map[string]dnsrecords.DNSRecordResult),
+		ExtendedErrors: make(map[string][]dnsrecords.ExtendedDNSError),
+		RecordErrors:   make(map[string]string),
 	}
 }
 
@@ -151,3 +258,41 @@ func (a *Assessment) Begin() {
 func (a *Assessment) Finish() {
 	a.End = time.Now()
 }
+
+// UnmarshalJSON decodes an Assessment encoded with encoding/json, such as a stored assessment.
+// The results of the Records map are decoded into the concrete type produced by the parser of
+// their record type; results of record types without a parser are kept as generic JSON values.
+func (a *Assessment) UnmarshalJSON(data []byte) error {
+	type assessment Assessment
+	var decoded struct {
+		assessment
+		Records map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*a = Assessment(decoded.assessment)
+	a.Records = make(map[string]dnsrecords.DNSRecordResult, len(decoded.Records))
+	for recordType, raw := range decoded.Records {
+		result, ok := dnsrecords.NewDNSRecordResult(recordType)
+		if !ok {
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			a.Records[recordType] = value
+			continue
+		}
+		if err := json.Unmarshal(raw, result); err != nil {
+			return err
+		}
+		a.Records[recordType] = result
+	}
+	if a.ExtendedErrors == nil {
+		a.ExtendedErrors = make(map[string][]dnsrecords.ExtendedDNSError)
+	}
+	if a.RecordErrors == nil {
+		a.RecordErrors = make(map[string]string)
+	}
+	return nil
+}
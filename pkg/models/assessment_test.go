@@ -2,6 +2,8 @@ package models
 
 import (
 	"bou.ke/monkey"
+	"encoding/json"
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
 	"testing"
 	"time"
 )
@@ -63,3 +65,37 @@ func TestAssessmentFinish(t *testing.T) {
 
 	monkey.Unpatch(time.Now)
 }
+
+func TestAssessmentJSONRoundTrip(t *testing.T) {
+	assessment := NewAssessment("https://example.com", "example.com")
+	assessment.Records["DNSKEY"] = &dnsrecords.DNSKEYResponse{
+		Records:   []dnsrecords.DNSKEYRecord{{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "AA==", KeyID: 2371}},
+		Validated: true,
+	}
+	assessment.Records["DS"] = &dnsrecords.DSResponse{Validated: true}
+	assessment.Records["FUTURE"] = map[string]interface{}{"Validated": true}
+	assessment.Score = &SecurityScore{Score: 90, Grade: "A"}
+
+	data, err := json.Marshal(assessment)
+	if err != nil {
+		t.Fatalf("Marshal: unexpected error: %v", err)
+	}
+	var decoded Assessment
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+
+	dnskey, ok := decoded.Records["DNSKEY"].(*dnsrecords.DNSKEYResponse)
+	if !ok || len(dnskey.Records) != 1 || dnskey.Records[0].KeyID != 2371 || !dnskey.Validated {
+		t.Errorf("Expected the DNSKEY result to be decoded, got %#v", decoded.Records["DNSKEY"])
+	}
+	if _, ok := decoded.Records["DS"].(*dnsrecords.DSResponse); !ok {
+		t.Errorf("Expected the DS result to be decoded, got %#v", decoded.Records["DS"])
+	}
+	if _, ok := decoded.Records["FUTURE"].(map[string]interface{}); !ok {
+		t.Errorf("Expected an unknown result to be kept as JSON, got %#v", decoded.Records["FUTURE"])
+	}
+	if decoded.Domain != "example.com" || decoded.Score == nil || decoded.Score.Grade != "A" || !decoded.Start.Equal(assessment.Start) {
+		t.Errorf("Expected the other fields to be decoded, got %+v", decoded)
+	}
+}
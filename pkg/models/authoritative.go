@@ -0,0 +1,83 @@
+package models
+
+// AuthoritativeReport represents the comparison of the data served by each authoritative name
+// server of a zone. Querying every server directly, instead of going through a recursive
+// resolver, reveals servers that serve stale or unsigned data.
+//
+// Fields:
+//
+//	Servers: A slice of AuthoritativeServer structs, one per name server address. IPv4 and IPv6
+//	         addresses of the same name server are queried and reported separately.
+//
+//	SerialsConsistent: A boolean flag indicating whether every server serves the same SOA serial.
+//
+//	DNSKEYConsistent: A boolean flag indicating whether every server serves the same DNSKEY set.
+//
+//	SignaturesConsistent: A boolean flag indicating whether every server serves signed SOA and
+//	                      DNSKEY records with the same signatures (key tags and inceptions).
+//
+//	Consistent: A boolean flag indicating whether every server responded and no discrepancy was found.
+//
+//	Findings: Human-readable notes describing every discrepancy found.
+type AuthoritativeReport struct {
+	Servers              []AuthoritativeServer
+	SerialsConsistent    bool
+	DNSKEYConsistent     bool
+	SignaturesConsistent bool
+	Consistent           bool
+	Findings             []string
+}
+
+// AuthoritativeServer represents the SOA and DNSKEY data served by a single authoritative
+// name server address.
+//
+// Fields:
+//
+//	NameServer: The host name of the name server.
+//
+//	Address: The IPv4 or IPv6 address that was queried.
+//
+//	Responded: A boolean flag indicating whether the server answered both queries authoritatively.
+//
+//	Error: A description of the failure when the server did not respond.
+//
+//	SOASerial: The serial of the SOA record served.
+//
+//	DNSKEYKeyIDs: The key tags of the DNSKEY records served, in ascending order.
+//
+//	SOASigned: A boolean flag indicating whether the SOA record was served with its RRSIG.
+//
+//	DNSKEYSigned: A boolean flag indicating whether the DNSKEY set was served with its RRSIG.
+//
+//	SOASignatures: The key tag and inception of every SOA signature served, sorted by key tag
+//	               and then inception.
+//
+//	DNSKEYSignatures: The key tag and inception of every DNSKEY signature served, sorted the same way.
+//	                  The KSK and ZSK signatures of a set usually have different inceptions.
+//
+//	Discrepancies: Human-readable notes describing how this server differs from the others.
+type AuthoritativeServer struct {
+	NameServer       string
+	Address          string
+	Responded        bool
+	Error            string
+	SOASerial        uint32
+	DNSKEYKeyIDs     []uint16
+	SOASigned        bool
+	DNSKEYSigned     bool
+	SOASignatures    []SignatureInception
+	DNSKEYSignatures []SignatureInception
+	Discrepancies    []string
+}
+
+// SignatureInception identifies a signature served by an authoritative server.
+//
+// Fields:
+//
+//	KeyTag: The key tag of the key that made the signature.
+//
+//	Inception: The inception time of the signature, as a UNIX timestamp.
+type SignatureInception struct {
+	KeyTag    uint16
+	Inception uint32
+}
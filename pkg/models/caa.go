@@ -0,0 +1,42 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// CAAReport represents the CAA records that certification authorities would apply to the scanned
+// host. RFC 8659 makes the relevant record set the one found at the host or, when there is none,
+// at the closest ancestor that has one. Certification authorities are asked to validate CAA
+// answers with DNSSEC where the zone is signed, so an unsigned or bogus answer weakens the control
+// the records give over certificate issuance.
+//
+// Fields:
+//
+//	Host: The scanned host name.
+//
+//	RelevantName: The name that holds the relevant record set, empty when no name from the host
+//	              up to the top-level domain has CAA records.
+//
+//	Records: The relevant CAA record set.
+//
+//	Validated: A boolean flag indicating whether the answer holding the relevant record set was
+//	           validated with DNSSEC by the resolver used for the scan.
+//
+//	Status: The DNSSEC status of the relevant answer as classified from the answer of the resolver
+//	        ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	Issuers: The issuer domain names allowed to issue certificates. The slice is empty when the
+//	         records forbid every issuer or when there are no "issue" records.
+//
+//	WildcardIssuers: The issuer domain names allowed to issue wildcard certificates, which are
+//	                 taken from the "issue" records when there are no "issuewild" records.
+//
+//	Findings: Human-readable notes on missing, unvalidated and unusable CAA records.
+type CAAReport struct {
+	Host            string
+	RelevantName    string
+	Records         []dnsrecords.CAARecord
+	Validated       bool
+	Status          string
+	Issuers         []string
+	WildcardIssuers []string
+	Findings        []string
+}
@@ -0,0 +1,42 @@
+package models
+
+// CDSReport represents the evaluation of the CDS and CDNSKEY records a child zone publishes
+// to signal DS changes to its parent (RFC 7344, RFC 8078). It tells whether the signalling
+// is consistent with the keys of the zone and the DS records of the parent, and whether a
+// parent that processes CDS could maintain the DS records automatically.
+//
+// Fields:
+//
+//	CDSPresent: A boolean flag indicating whether CDS records are published.
+//
+//	CDNSKEYPresent: A boolean flag indicating whether CDNSKEY records are published.
+//
+//	Validated: A boolean flag indicating whether the published signalling records were validated.
+//
+//	DeleteRequest: A boolean flag indicating whether the zone asks the parent to remove its DS records.
+//
+//	InSyncWithDS: A boolean flag indicating whether the CDS records equal the DS records at the
+//	              parent, meaning no change is currently requested.
+//
+//	CDSMatchesDNSKEY: A boolean flag indicating whether every CDS record references a published DNSKEY.
+//
+//	CDNSKEYMatchesDNSKEY: A boolean flag indicating whether every CDNSKEY record is a published DNSKEY.
+//
+//	Consistent: A boolean flag indicating whether the CDS and CDNSKEY record sets signal the same keys.
+//
+//	AutomationReady: A boolean flag indicating whether the signalling is complete and correct
+//	                 enough for a parent to update the DS records automatically.
+//
+//	Findings: Human-readable notes describing missing, inconsistent or unusable signalling.
+type CDSReport struct {
+	CDSPresent           bool
+	CDNSKEYPresent       bool
+	Validated            bool
+	DeleteRequest        bool
+	InSyncWithDS         bool
+	CDSMatchesDNSKEY     bool
+	CDNSKEYMatchesDNSKEY bool
+	Consistent           bool
+	AutomationReady      bool
+	Findings             []string
+}
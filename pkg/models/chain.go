@@ -0,0 +1,63 @@
+package models
+
+// ChainReport represents the chain of trust from the top-level domain down to the scanned host,
+// evaluated at every zone cut on the way. It shows where a chain that is intact at the apex of
+// an institution breaks in a delegated subzone, such as a departmental zone.
+//
+// Fields:
+//
+//	Host: The host name the chain leads to.
+//
+//	Links: A slice of ChainLink structs, one per zone cut, ordered from the top-level domain
+//	       down to the zone enclosing the host.
+//
+//	Status: The status of the zone enclosing the host ("secure", "insecure" or "bogus").
+//
+//	BrokenAt: The first zone whose parent publishes DS records that do not lead to a validated
+//	          DNSKEY set, or empty when the chain is not broken.
+//
+//	Findings: Human-readable notes on broken links, islands of security and zones below a
+//	          secure apex that are not secure.
+type ChainReport struct {
+	Host     string
+	Links    []ChainLink
+	Status   string
+	BrokenAt string
+	Findings []string
+}
+
+// ChainLink represents one zone cut of the chain of trust.
+//
+// Fields:
+//
+//	Zone: The name of the zone.
+//
+//	ParentZone: The name of the zone that delegates it, or "." for a top-level domain.
+//
+//	DSPresent: A boolean flag indicating whether the parent publishes DS records for the zone.
+//
+//	DSKeyTags: The key tags of the DS records.
+//
+//	DNSKEYPresent: A boolean flag indicating whether the zone publishes DNSKEY records.
+//
+//	KeyTags: The key tags of the DNSKEY records.
+//
+//	DSMatchesKey: A boolean flag indicating whether at least one DS record is the digest of one of
+//	              the DNSKEY records.
+//
+//	Validated: A boolean flag indicating whether the resolver validated the DNSKEY set.
+//
+//	Status: The status of the zone ("secure", "insecure" or "bogus"). A zone is secure when its
+//	        parent is secure, the parent publishes DS records and the DNSKEY set validates; it is
+//	        insecure when the parent is insecure or publishes no DS record; and bogus otherwise.
+type ChainLink struct {
+	Zone          string
+	ParentZone    string
+	DSPresent     bool
+	DSKeyTags     []uint16
+	DNSKEYPresent bool
+	KeyTags       []uint16
+	DSMatchesKey  bool
+	Validated     bool
+	Status        string
+}
@@ -0,0 +1,64 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// DANEReport represents the TLSA records published for the services of the scanned host: its
+// HTTPS endpoint and the SMTP endpoints of the mail exchangers of its domain. DNSSEC is a
+// prerequisite of DANE, so TLSA records show whether a signed zone is also used to authenticate
+// TLS services.
+//
+// Fields:
+//
+//	Endpoints: A slice of TLSAEndpoint structs, one per TLSA name queried.
+//
+//	Findings: Human-readable notes on TLSA records that DANE clients cannot use.
+type DANEReport struct {
+	Endpoints []TLSAEndpoint
+	Findings  []string
+}
+
+// TLSAEndpoint represents the TLSA records of one TLS service.
+//
+// Fields:
+//
+//	Name: The TLSA owner name, such as "_443._tcp.www.example.com".
+//
+//	Host: The host name of the service.
+//
+//	Port: The TCP port of the service.
+//
+//	Service: The service reached on the port: "https" or "smtp".
+//
+//	Records: The TLSA records found at the name. The slice is empty when there are none.
+//
+//	Status: The DNSSEC status of the TLSA answer as classified from the answer of the resolver
+//	        ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	Usable: A boolean flag indicating whether DANE clients would use the records, that is, whether
+//	        records are published and the answer is secure.
+//
+//	Certificate: The result of matching the records against the certificate chain presented by
+//	             the service. This field is nil when no comparison was made.
+type TLSAEndpoint struct {
+	Name        string
+	Host        string
+	Port        int
+	Service     string
+	Records     []dnsrecords.TLSARecord
+	Status      string
+	Usable      bool
+	Certificate *TLSACertificateCheck
+}
+
+// TLSACertificateCheck represents the comparison of TLSA records with the certificate chain
+// presented by a service.
+//
+// Fields:
+//
+//	Matched: A boolean flag indicating whether at least one TLSA record matches the chain.
+//
+//	Error: The reason the chain could not be retrieved, empty when the comparison was made.
+type TLSACertificateCheck struct {
+	Matched bool
+	Error   string
+}
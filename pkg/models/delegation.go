@@ -0,0 +1,77 @@
+package models
+
+// DelegationReport represents the comparison between the delegation of a zone, as published
+// by its parent, and the zone itself. Inconsistent delegations are a frequent cause of DNSSEC
+// breakage, since they make resolvers reach servers with different or missing data.
+//
+// Fields:
+//
+//	ParentZone: The name of the parent zone that holds the delegation.
+//
+//	ParentNS: The name servers listed in the referral from the parent zone.
+//
+//	ChildNS: The name servers listed in the NS record set at the apex of the zone.
+//
+//	MissingAtChild: Name servers listed by the parent but not by the zone.
+//
+//	MissingAtParent: Name servers listed by the zone but not by the parent.
+//
+//	Glue: A slice of GlueCheck structs comparing the glue addresses from the parent with the
+//	      addresses the name servers actually resolve to.
+//
+//	NameServers: A slice of NameServerCheck structs, one per name server address, describing
+//	             whether the server answers authoritatively for the zone.
+//
+//	LameServers: The names of the name servers with at least one lame address.
+//
+//	Consistent: A boolean flag indicating whether no discrepancy was found.
+//
+//	Findings: Human-readable notes describing every discrepancy found.
+type DelegationReport struct {
+	ParentZone      string
+	ParentNS        []string
+	ChildNS         []string
+	MissingAtChild  []string
+	MissingAtParent []string
+	Glue            []GlueCheck
+	NameServers     []NameServerCheck
+	LameServers     []string
+	Consistent      bool
+	Findings        []string
+}
+
+// GlueCheck represents the comparison of the glue addresses of a name server with its addresses.
+//
+// Fields:
+//
+//	NameServer: The host name of the name server.
+//
+//	GlueAddresses: The A and AAAA addresses provided as glue by the parent zone.
+//
+//	Addresses: The A and AAAA addresses the name server resolves to.
+//
+//	Matches: A boolean flag indicating whether both address sets are equal.
+type GlueCheck struct {
+	NameServer    string
+	GlueAddresses []string
+	Addresses     []string
+	Matches       bool
+}
+
+// NameServerCheck represents the probe of a single name server address for the zone.
+//
+// Fields:
+//
+//	NameServer: The host name of the name server.
+//
+//	Address: The IPv4 or IPv6 address that was probed.
+//
+//	Lame: A boolean flag indicating whether the server failed to answer authoritatively for the zone.
+//
+//	Reason: A human-readable explanation of why the server is considered lame.
+type NameServerCheck struct {
+	NameServer string
+	Address    string
+	Lame       bool
+	Reason     string
+}
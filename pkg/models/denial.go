@@ -0,0 +1,52 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// DenialOfExistenceReport represents the evaluation of the negative proof returned by a zone
+// for a name that does not exist. It records the denial method and its parameters, and whether
+// the proof is complete as required by RFC 4035 (NSEC) or RFC 5155 (NSEC3).
+//
+// Fields:
+//
+//	QueryName: The deliberately non-existent name that was queried.
+//
+//	Method: The denial of existence method in use ("NSEC3", "NSEC" or "none").
+//
+//	NXDomain: A boolean flag indicating whether the response reported that the name does not exist.
+//
+//	Validated: A boolean flag indicating whether the negative response was fully validated.
+//
+//	HashAlgorithm, Iterations, Salt, OptOut: The NSEC3 parameters observed in the proof.
+//	                                         These fields are zero-valued when NSEC3 is not in use.
+//
+//	ClosestEncloser: The closest existing ancestor of the query name, as established by the proof.
+//
+//	ClosestEncloserProven: Whether a record matching the closest encloser was present.
+//
+//	NextCloserCovered: Whether the name one label below the closest encloser, towards the
+//	                   query name, was covered by a record.
+//
+//	WildcardCovered: Whether the wildcard at the closest encloser was covered by a record.
+//
+//	ProofComplete: Whether all elements of the proof were present.
+//
+//	Findings: Human-readable notes about missing proof elements and questionable parameters.
+//
+//	Response: A pointer to the parsed DenialResponse the report was built from.
+type DenialOfExistenceReport struct {
+	QueryName             string
+	Method                string
+	NXDomain              bool
+	Validated             bool
+	HashAlgorithm         uint8
+	Iterations            uint16
+	Salt                  string
+	OptOut                bool
+	ClosestEncloser       string
+	ClosestEncloserProven bool
+	NextCloserCovered     bool
+	WildcardCovered       bool
+	ProofComplete         bool
+	Findings              []string
+	Response              *dnsrecords.DenialResponse
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// AssessmentDiff represents the differences between two assessments of the same domain, from
+// the point of view of the DNSSEC deployment. It is used to notify institutions of changes
+// between scans.
+//
+// Fields:
+//
+//	Domain: The assessed domain.
+//
+//	PreviousStart: The start time of the earlier assessment.
+//
+//	CurrentStart: The start time of the later assessment.
+//
+//	PreviousStatus: The DNSSEC status of the earlier assessment ("secure", "insecure" or "bogus").
+//
+//	CurrentStatus: The DNSSEC status of the later assessment.
+//
+//	KeysAdded: The key tags of the DNSKEY records published only in the later assessment.
+//
+//	KeysRemoved: The key tags of the DNSKEY records published only in the earlier assessment.
+//
+//	AlgorithmsAdded: The DNSKEY algorithms used only in the later assessment.
+//
+//	AlgorithmsRemoved: The DNSKEY algorithms used only in the earlier assessment.
+//
+//	DSAdded: The key tags of the DS records present only in the later assessment.
+//
+//	DSRemoved: The key tags of the DS records present only in the earlier assessment.
+//
+//	PreviousDenial: The denial of existence method of the earlier assessment ("NSEC", "NSEC3"
+//	                or "none"), empty when it was not evaluated.
+//
+//	CurrentDenial: The denial of existence method of the later assessment.
+//
+//	PreviousGrade: The grade of the earlier assessment, empty when it was not scored.
+//
+//	CurrentGrade: The grade of the later assessment.
+//
+//	NewFindings: Findings reported by the later assessment but not by the earlier one.
+//
+//	ResolvedFindings: Findings reported by the earlier assessment but not by the later one.
+type AssessmentDiff struct {
+	Domain            string
+	PreviousStart     time.Time
+	CurrentStart      time.Time
+	PreviousStatus    string
+	CurrentStatus     string
+	KeysAdded         []uint16
+	KeysRemoved       []uint16
+	AlgorithmsAdded   []uint8
+	AlgorithmsRemoved []uint8
+	DSAdded           []uint16
+	DSRemoved         []uint16
+	PreviousDenial    string
+	CurrentDenial     string
+	PreviousGrade     string
+	CurrentGrade      string
+	NewFindings       []string
+	ResolvedFindings  []string
+}
+
+// StatusChanged reports whether the DNSSEC status changed between the assessments.
+func (d *AssessmentDiff) StatusChanged() bool {
+	return d.PreviousStatus != d.CurrentStatus
+}
+
+// DenialChanged reports whether the denial of existence method changed, such as an NSEC to
+// NSEC3 transition. A method that was not evaluated in one of the assessments is not a change.
+func (d *AssessmentDiff) DenialChanged() bool {
+	return d.PreviousDenial != "" && d.CurrentDenial != "" && d.PreviousDenial != d.CurrentDenial
+}
+
+// HasChanges reports whether the assessments differ in any of the compared aspects.
+func (d *AssessmentDiff) HasChanges() bool {
+	return d.StatusChanged() || d.DenialChanged() || d.PreviousGrade != d.CurrentGrade ||
+		len(d.KeysAdded) > 0 || len(d.KeysRemoved) > 0 ||
+		len(d.AlgorithmsAdded) > 0 || len(d.AlgorithmsRemoved) > 0 ||
+		len(d.DSAdded) > 0 || len(d.DSRemoved) > 0 ||
+		len(d.NewFindings) > 0 || len(d.ResolvedFindings) > 0
+}
@@ -53,6 +53,14 @@ func (r *AAAARecord) String() string {
 //
 //	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
 //	       AAAA record set. This field is nil if DNSSEC is not used or if the record is not signed.
+//	       When the set carries several signatures, it holds the last one.
+//
+//	RRSIGs: A slice with every RRSIG record covering the AAAA record set. The set is signed by more
+//	        than one key during key and algorithm rollovers.
+//
+//	Aliases: A slice of AliasRecord structs with the CNAME and DNAME records followed from the
+//	         queried name to the name that owns the AAAA records, in order. It is empty when the
+//	         queried name is not an alias.
 //
 //	RawResponse: A string containing the raw textual response received from the DNS server,
 //	             which can be useful for logging, debugging, or other diagnostic purposes.
@@ -60,6 +68,8 @@ type AAAAResponse struct {
 	Records     []AAAARecord
 	Validated   bool
 	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
+	Aliases     []AliasRecord
 	RawResponse string
 }
 
@@ -136,9 +146,16 @@ func (r *AAAAResponse) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 
+	aliases, err := ParseAliases(lines)
+	if err != nil {
+		return nil, err
+	}
+	r.Aliases = aliases
+
 	return r, nil
 }
 
@@ -173,8 +190,17 @@ func (r *AAAAResponse) Compare(b *AAAAResponse) bool {
 			return false
 		}
 	}
+	if len(r.Aliases) != len(b.Aliases) {
+		return false
+	}
+	for i := range r.Aliases {
+		if !r.Aliases[i].Compare(&b.Aliases[i]) {
+			return false
+		}
+	}
 	return r.Validated == b.Validated &&
 		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
 		r.RawResponse == b.RawResponse
 }
 
@@ -38,6 +38,7 @@ func TestNewAAAARecordOK(t *testing.T) {
 		t.Fatalf("Result is not a *AAAAResponse")
 	}
 
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
 	if !aaaaRecord.Compare(expected) {
 		t.Errorf("Parsed record %+v does not match expected %+v", aaaaRecord, expected)
 	}
@@ -53,6 +53,14 @@ func (r *ARecord) String() string {
 //
 //	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
 //	       A record set. This field is nil if DNSSEC is not used or if the record is not signed.
+//	       When the set carries several signatures, it holds the last one.
+//
+//	RRSIGs: A slice with every RRSIG record covering the A record set. The set is signed by more
+//	        than one key during key and algorithm rollovers.
+//
+//	Aliases: A slice of AliasRecord structs with the CNAME and DNAME records followed from the
+//	         queried name to the name that owns the A records, in order. It is empty when the
+//	         queried name is not an alias.
 //
 //	RawResponse: A string containing the raw textual response received from the DNS server,
 //	             which can be useful for logging, debugging, or other diagnostic purposes.
@@ -60,6 +68,8 @@ type AResponse struct {
 	Records     []ARecord
 	Validated   bool
 	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
+	Aliases     []AliasRecord
 	RawResponse string
 }
 
@@ -137,9 +147,16 @@ func (r *AResponse) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 
+	aliases, err := ParseAliases(lines)
+	if err != nil {
+		return nil, err
+	}
+	r.Aliases = aliases
+
 	return r, nil
 }
 
@@ -174,8 +191,17 @@ func (r *AResponse) Compare(b *AResponse) bool {
 			return false
 		}
 	}
+	if len(r.Aliases) != len(b.Aliases) {
+		return false
+	}
+	for i := range r.Aliases {
+		if !r.Aliases[i].Compare(&b.Aliases[i]) {
+			return false
+		}
+	}
 	return r.Validated == b.Validated &&
 		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
 		r.RawResponse == b.RawResponse
 }
 
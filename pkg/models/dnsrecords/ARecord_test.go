@@ -38,6 +38,7 @@ func TestNewARecordOK(t *testing.T) {
 		t.Fatalf("Result is not a *AResponse")
 	}
 
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
 	if !aRecord.Compare(expected) {
 		t.Errorf("Parsed record %+v does not match expected %+v", aRecord, expected)
 	}
@@ -73,9 +74,82 @@ func TestNewARecordNoDNSSEC(t *testing.T) {
 	}
 }
 
+func TestNewARecordAliasChain(t *testing.T) {
+	response := aliasAResponse
+
+	expected := &AResponse{
+		Records: []ARecord{
+			{
+				IPv4:        "192.0.2.10",
+				OriginalTTL: 60,
+			},
+		},
+		Validated: false,
+		Aliases: []AliasRecord{
+			{
+				Type:        "DNAME",
+				Owner:       "old.example.pt",
+				Target:      "example.pt",
+				OriginalTTL: 3600,
+				RRSIG: &RRSIGRecord{
+					TypeCovered: "DNAME",
+					Algorithm:   13,
+					Labels:      3,
+					OriginalTTL: 3600,
+					Expiration:  1704931200,
+					Inception:   1703116800,
+					KeyTag:      2371,
+					SignerName:  "example.pt",
+					Signature:   "c2lnbmF0dXJl",
+				},
+			},
+			{
+				Type:        "CNAME",
+				Owner:       "www.old.example.pt",
+				Target:      "www.example.pt",
+				OriginalTTL: 3600,
+			},
+			{
+				Type:        "CNAME",
+				Owner:       "www.example.pt",
+				Target:      "site.cdn-provider.net",
+				OriginalTTL: 300,
+				RRSIG: &RRSIGRecord{
+					TypeCovered: "CNAME",
+					Algorithm:   13,
+					Labels:      3,
+					OriginalTTL: 300,
+					Expiration:  1704931200,
+					Inception:   1703116800,
+					KeyTag:      2371,
+					SignerName:  "example.pt",
+					Signature:   "c2lnbmF0dXJl",
+				},
+			},
+		},
+		RawResponse: response,
+	}
+	result, err := (&AResponse{}).Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse A record: %v", err)
+	}
+
+	if !result.(*AResponse).Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", result, expected)
+	}
+}
+
 const goodAResponse = `; fully validated
 ipb.pt.                 21600   IN      A       193.136.195.224
 ipb.pt.                 21600   IN      RRSIG   A 7 2 86400 20240111000000 20231221000000 45269 ipb.pt. I3qvkVcnFSqPHb4QrSFWCphRQSqOqLi1LM8gQdBtMGiWdPvBhRNI5Kxm +xgX/F443DIVuzFWbIhPYNnInT/OgWHPUF+UkbtpYopS0lOD8mJJ5e26 PFQb65Jw9rgJAEomjA3dQa6D67mut7KtFgIapUtXOVUYLET9NJwv1Q2H 4gs=`
 
 const unsignedAResponse = `; unsigned answer
 ipp.pt.                 600     IN      A       193.136.58.74`
+
+const aliasAResponse = `; unsigned answer
+old.example.pt.         3600    IN      DNAME   example.pt.
+old.example.pt.         3600    IN      RRSIG   DNAME 13 3 3600 20240111000000 20231221000000 2371 example.pt. c2lnbmF0dXJl
+www.old.example.pt.     3600    IN      CNAME   www.example.pt.
+www.example.pt.         300     IN      CNAME   site.cdn-provider.net.
+www.example.pt.         300     IN      RRSIG   CNAME 13 3 300 20240111000000 20231221000000 2371 example.pt. c2lnbmF0dXJl
+site.cdn-provider.net.  60      IN      A       192.0.2.10`
@@ -0,0 +1,108 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AliasRecord represents a CNAME or DNAME record met while resolving a name. A CNAME record
+// redirects a single name; a DNAME record redirects every name below its owner, and resolvers
+// answer with the DNAME record followed by the CNAME record synthesized from it.
+//
+// Fields:
+//
+//	Type: The record type, "CNAME" or "DNAME".
+//
+//	Owner: The owner name of the record, lower-cased and without a trailing dot.
+//
+//	Target: The name the record points to, lower-cased and without a trailing dot.
+//
+//	OriginalTTL: The TTL of the record in seconds.
+//
+//	RRSIG: A pointer to the RRSIGRecord covering the record, or nil when it is unsigned, as
+//	       synthesized CNAME records are.
+type AliasRecord struct {
+	Type        string
+	Owner       string
+	Target      string
+	OriginalTTL uint32
+	RRSIG       *RRSIGRecord
+}
+
+var (
+	aliasRegex      = regexp.MustCompile(`\bIN\s+(CNAME|DNAME)\b`)
+	aliasRRSIGRegex = regexp.MustCompile(`\bRRSIG\s+(CNAME|DNAME)\b`)
+)
+
+// ParseAliases extracts the CNAME and DNAME records, in the order they appear, from the lines of
+// a 'delv' response, and links each of them to the RRSIG record that covers it.
+//
+// Parameters:
+//
+//	lines: The lines of the raw textual response from the 'delv' command-line tool.
+//
+// Returns:
+//
+//	[]AliasRecord: The alias records of the response, or nil when the name is not an alias.
+//
+//	error: An error object describing a record line that could not be parsed.
+func ParseAliases(lines []string) ([]AliasRecord, error) {
+	var aliases []AliasRecord
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), ";") {
+			continue
+		}
+		if aliasRegex.MatchString(line) {
+			parts := strings.Fields(line)
+			if len(parts) < 5 {
+				return nil, fmt.Errorf("invalid alias r: %s", line)
+			}
+			ttl, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL '%s' in alias r: %v", parts[1], err)
+			}
+			aliases = append(aliases, AliasRecord{
+				Type:        parts[3],
+				Owner:       aliasName(parts[0]),
+				Target:      aliasName(parts[4]),
+				OriginalTTL: uint32(ttl),
+			})
+		} else if aliasRRSIGRegex.MatchString(line) {
+			rrsigRecord, err := (&RRSIGRecord{}).Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			owner := aliasName(strings.Fields(line)[0])
+			for i := range aliases {
+				if aliases[i].Owner == owner && aliases[i].Type == rrsigRecord.(*RRSIGRecord).TypeCovered {
+					aliases[i].RRSIG = rrsigRecord.(*RRSIGRecord)
+				}
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// Compare checks the equality between two instances of AliasRecord.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *AliasRecord) Compare(b *AliasRecord) bool {
+	return r.Type == b.Type && r.Owner == b.Owner && r.Target == b.Target && r.OriginalTTL == b.OriginalTTL &&
+		r.RRSIG.Compare(b.RRSIG)
+}
+
+// String returns a formatted string representation of the AliasRecord.
+func (r *AliasRecord) String() string {
+	return fmt.Sprintf("%s %s %s (TTL %d seconds, signed: %v)", r.Owner, r.Type, r.Target, r.OriginalTTL, r.RRSIG != nil)
+}
+
+func aliasName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
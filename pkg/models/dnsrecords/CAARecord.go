@@ -0,0 +1,160 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// caaCriticalFlag is the Issuer Critical flag of a CAA record (RFC 8659, Section 4.1).
+const caaCriticalFlag = 128
+
+// CAARecord represents a single CAA (Certification Authority Authorization) record, which states
+// the certification authorities allowed to issue certificates for a domain (RFC 8659).
+//
+// Fields:
+//
+//	Flags: An unsigned 8-bit integer with the flags of the record. Only the Issuer Critical
+//	       flag (128) is defined: a CA must not issue when it does not understand the tag.
+//
+//	Tag: The property tag, lower-cased, such as "issue", "issuewild" or "iodef".
+//
+//	Value: The property value without the surrounding quotes, such as "letsencrypt.org".
+type CAARecord struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// IsCritical reports whether the Issuer Critical flag of the record is set.
+func (r *CAARecord) IsCritical() bool {
+	return r.Flags&caaCriticalFlag != 0
+}
+
+// String returns a formatted string representation of the CAARecord.
+func (r *CAARecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"CAARecord:\n"+
+			"  Flags: %d\n"+
+			"  Tag: %s\n"+
+			"  Value: %s\n",
+		r.Flags,
+		r.Tag,
+		r.Value,
+	)
+}
+
+// CAAResponse represents the complete response for a CAA query.
+//
+// Fields:
+//
+//	Records: A slice of CAARecord structs. The slice is empty when the name holds no CAA records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       CAA record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type CAAResponse struct {
+	Records     []CAARecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses the raw output of a 'delv' CAA query and creates a new CAAResponse struct.
+// A name without CAA records, whether it does not exist or holds other types only, yields an
+// empty response rather than an error, since CAA records are optional and looked up by climbing
+// the tree of names.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*CAAResponse: A pointer to a CAAResponse struct with the parsed CAA records, the
+//	              validation status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record could
+//	       not be parsed.
+func (r *CAAResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = CAAResponse{RawResponse: response}
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	caaRegex := regexp.MustCompile(`\bIN\s+CAA\s+(\S+)\s+(\S+)\s+(.*)$`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+CAA\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if match := caaRegex.FindStringSubmatch(line); match != nil {
+			flags, err := strconv.ParseUint(match[1], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid flags '%s' in CAA r: %v", match[1], err)
+			}
+			value := strings.TrimSpace(match[3])
+			value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+			r.Records = append(r.Records, CAARecord{Flags: uint8(flags), Tag: strings.ToLower(match[2]), Value: value})
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// Compare checks the equality between two instances of CAARecord.
+//
+// Parameters:
+// - b: A reference to another instance of CAARecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *CAARecord) Compare(b *CAARecord) bool {
+	return r.Flags == b.Flags &&
+		r.Tag == b.Tag &&
+		r.Value == b.Value
+}
+
+// Compare checks the equality between two instances of CAAResponse.
+//
+// Parameters:
+// - b: A reference to another instance of CAAResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *CAAResponse) Compare(b *CAAResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
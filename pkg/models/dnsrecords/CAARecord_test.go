@@ -0,0 +1,96 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewCAAResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      CAA     0 issue "letsencrypt.org"
+example.com.            3600    IN      CAA     0 issuewild ";"
+example.com.            3600    IN      CAA     128 iodef "mailto:security@example.com"
+example.com.            3600    IN      RRSIG   CAA 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &CAAResponse{
+		Records: []CAARecord{
+			{Flags: 0, Tag: "issue", Value: "letsencrypt.org"},
+			{Flags: 0, Tag: "issuewild", Value: ";"},
+			{Flags: 128, Tag: "iodef", Value: "mailto:security@example.com"},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "CAA",
+			Algorithm:   13,
+			Labels:      2,
+			OriginalTTL: 3600,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &CAAResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse CAA record: %v", err)
+	}
+	caaResponse, ok := result.(*CAAResponse)
+	if !ok {
+		t.Fatalf("Result is not a *CAAResponse")
+	}
+
+	if !caaResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", caaResponse, expected)
+	}
+	if caaResponse.Records[0].IsCritical() || !caaResponse.Records[2].IsCritical() {
+		t.Errorf("Unexpected critical flags in %+v", caaResponse.Records)
+	}
+}
+
+func TestNewCAAResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; www.example.com.              3600    IN      \-CAA   ;-$NXRRSET`
+	r := &CAAResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty CAA response, got error: %v", err)
+	}
+	caaResponse := result.(*CAAResponse)
+	if len(caaResponse.Records) != 0 || !caaResponse.Validated {
+		t.Errorf("Expected a validated, empty CAA response, got %+v", caaResponse)
+	}
+}
+
+func TestNewCAAResponseInvalidFlags(t *testing.T) {
+	response := `example.com.            3600    IN      CAA     300 issue "letsencrypt.org"`
+	r := &CAAResponse{}
+	if _, err := r.Parse(response); err == nil {
+		t.Errorf("Expected an error for invalid flags")
+	}
+}
+
+func TestNewCAAResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+example.com.            3600    IN      CAA     0 issue "letsencrypt.org"
+example.com.            3600    IN      CAA     0 issuewild ";"
+example.com.            3600    IN      RRSIG   CAA 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	unsigned := `; unsigned answer
+example.org.            3600    IN      CAA     0 issue "pki.goog"`
+	r := &CAAResponse{}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse CAA record: %v", err)
+	}
+	result, err := r.Parse(unsigned)
+	if err != nil {
+		t.Fatalf("Failed to parse CAA record: %v", err)
+	}
+	expected := &CAAResponse{
+		Records:     []CAARecord{{Flags: 0, Tag: "issue", Value: "pki.goog"}},
+		RawResponse: unsigned,
+	}
+	if caaResponse := result.(*CAAResponse); !caaResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", caaResponse)
+	}
+}
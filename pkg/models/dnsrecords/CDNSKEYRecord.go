@@ -0,0 +1,214 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CDNSKEYResponse represents the complete response for a CDNSKEY (Child DNSKEY) query.
+// CDNSKEY records are published by the child zone to signal the keys from which the parent
+// should derive its DS records (RFC 7344), or that the DS records should be removed (RFC 8078).
+// A CDNSKEY record has the same format as a DNSKEY record, so the records are stored as DNSKEYRecord values.
+//
+// Fields:
+//
+//	Records: A slice of DNSKEYRecord structs, each representing an individual CDNSKEY record.
+//	         The slice is empty when the zone does not publish CDNSKEY records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative,
+//	           has been validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       CDNSKEY record set. This field is nil if the record set is absent or not signed.
+//	       When the set carries several signatures, it holds the last one.
+//
+//	RRSIGs: A slice with every RRSIG record covering the CDNSKEY record set. The set is signed by more
+//	        than one key during key and algorithm rollovers.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type CDNSKEYResponse struct {
+	Records     []DNSKEYRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses a raw DNS response string and creates a new CDNSKEYResponse struct.
+// This function is designed to work with the output of the 'delv' command-line tool for CDNSKEY
+// queries. Since most zones do not publish CDNSKEY records, a response stating that the name has
+// no CDNSKEY records is not an error and yields an empty CDNSKEYResponse. The key type, algorithm
+// name and key id comments are optional; when they are missing the key type is derived from the flags.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//	          This response should be the result of a CDNSKEY query for a specific domain.
+//
+// Return Value:
+//
+//	*CDNSKEYResponse: A pointer to a CDNSKEYResponse struct that contains the parsed CDNSKEY records,
+//	                  the validation status, the associated RRSIG record (if available), and the raw response.
+//
+//	error: An error object that indicates any issues encountered during the parsing of the
+//	       response string. If the parsing is successful, the error is nil.
+//
+// Example Usage:
+//
+//	cdnskeyResponse, err := (&CDNSKEYResponse{}).Parse(rawDelvResponse)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	// Compare cdnskeyResponse against the DNSKEY records of the zone
+func (r *CDNSKEYResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = CDNSKEYResponse{RawResponse: response}
+	if isNoDataResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	cdnskeyRegex := regexp.MustCompile(`\bIN\s+CDNSKEY\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+CDNSKEY\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if cdnskeyRegex.MatchString(line) {
+			record, comment, _ := strings.Cut(line, ";")
+			parts := strings.Fields(record)
+			if len(parts) < 8 {
+				return nil, fmt.Errorf("invalid CDNSKEY r: %s", line)
+			}
+			cdnskeyRecord := &DNSKEYRecord{}
+
+			flags, err := strconv.ParseUint(parts[4], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid flags '%s' in CDNSKEY r: %v", parts[4], err)
+			}
+			cdnskeyRecord.Flags = uint16(flags)
+
+			protocol, err := strconv.ParseUint(parts[5], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid protocol '%s' in CDNSKEY r: %v", parts[5], err)
+			}
+			cdnskeyRecord.Protocol = uint8(protocol)
+
+			algorithm, err := strconv.ParseUint(parts[6], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid algorithm '%s' in CDNSKEY r: %v", parts[6], err)
+			}
+			cdnskeyRecord.Algorithm = uint8(algorithm)
+			cdnskeyRecord.PublicKey = strings.Join(parts[7:], "")
+
+			switch cdnskeyRecord.Flags {
+			case 257:
+				cdnskeyRecord.KeyType = "KSK"
+			case 256:
+				cdnskeyRecord.KeyType = "ZSK"
+			}
+			for _, c := range strings.Split(comment, ";") {
+				if strings.Contains(c, "alg =") {
+					cdnskeyRecord.AlgorithmName = strings.TrimSpace(strings.Split(c, "=")[1])
+				} else if strings.Contains(c, "key id =") {
+					keyID, err := strconv.ParseUint(strings.TrimSpace(strings.Split(c, "=")[1]), 10, 16)
+					if err != nil {
+						return nil, fmt.Errorf("invalid key id '%s' in CDNSKEY r: %v", strings.TrimSpace(strings.Split(c, "=")[1]), err)
+					}
+					cdnskeyRecord.KeyID = uint16(keyID)
+				}
+			}
+
+			if keyTag, err := cdnskeyRecord.ComputeKeyTag(); err == nil {
+				cdnskeyRecord.KeyID = keyTag
+			}
+
+			r.Records = append(r.Records, *cdnskeyRecord)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
+		}
+	}
+	return r, nil
+}
+
+// IsDeleteRequest reports whether the CDNSKEY record set asks the parent to remove the DS records.
+// RFC 8078 defines the delete request as a single CDNSKEY record "0 3 0 AA==".
+func (r *CDNSKEYResponse) IsDeleteRequest() bool {
+	for _, record := range r.Records {
+		if record.Algorithm == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare checks the equality between two instances of CDNSKEYResponse.
+// This function is useful for testing and validation purposes.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *CDNSKEYResponse) Compare(b *CDNSKEYResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
+		r.RawResponse == b.RawResponse
+}
+
+// String returns a formatted string representation of the CDNSKEYResponse.
+// It provides a human-readable view of the response, including the records, validation status, and raw response.
+func (r *CDNSKEYResponse) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	var recordsStr []string
+	for _, record := range r.Records {
+		recordsStr = append(recordsStr, record.String())
+	}
+
+	validatedStr := "No"
+	if r.Validated {
+		validatedStr = "Yes"
+	}
+
+	rrsigStr := "<null>"
+	if r.RRSIG != nil {
+		rrsigStr = r.RRSIG.String()
+	}
+
+	return fmt.Sprintf(
+		"CDNSKEYResponse:\n"+
+			"  Records:\n    %s\n"+
+			"  Validated: %s\n"+
+			"  RRSIG: %s\n"+
+			"  Raw Response: %s\n",
+		strings.Join(recordsStr, "\n    "),
+		validatedStr,
+		rrsigStr,
+		r.RawResponse,
+	)
+}
@@ -0,0 +1,96 @@
+package dnsrecords
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCDNSKEYResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      CDNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+ KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==
+example.com.            3600    IN      CDNSKEY 256 3 13 oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8 KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA== ; ZSK; alg = ECDSAP256SHA256 ; key id = 34505`
+	r := &CDNSKEYResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse CDNSKEY record: %v", err)
+	}
+	cdnskeyResponse, ok := result.(*CDNSKEYResponse)
+	if !ok {
+		t.Fatalf("Result is not a *CDNSKEYResponse")
+	}
+
+	expected := []DNSKEYRecord{
+		{
+			Flags:     257,
+			Protocol:  3,
+			Algorithm: 13,
+			PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==",
+			KeyType:   "KSK",
+			KeyID:     2371,
+		},
+		{
+			Flags:         256,
+			Protocol:      3,
+			Algorithm:     13,
+			PublicKey:     "oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA==",
+			KeyType:       "ZSK",
+			AlgorithmName: "ECDSAP256SHA256",
+			KeyID:         34505,
+		},
+	}
+	if len(cdnskeyResponse.Records) != len(expected) {
+		t.Fatalf("Expected %d CDNSKEY records, got %d", len(expected), len(cdnskeyResponse.Records))
+	}
+	for i := range expected {
+		if !cdnskeyResponse.Records[i].Compare(&expected[i]) {
+			t.Errorf("Parsed record %+v does not match expected %+v", cdnskeyResponse.Records[i], expected[i])
+		}
+	}
+	if !cdnskeyResponse.Validated {
+		t.Errorf("Expected the CDNSKEY response to be validated")
+	}
+}
+
+func TestNewCDNSKEYResponseDelete(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      CDNSKEY 0 3 0 AA==`
+	r := &CDNSKEYResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse CDNSKEY record: %v", err)
+	}
+	if !result.(*CDNSKEYResponse).IsDeleteRequest() {
+		t.Errorf("Expected a delete request")
+	}
+}
+
+func TestNewCDNSKEYResponseFailure(t *testing.T) {
+	response := `;; resolution failed: SERVFAIL`
+	r := &CDNSKEYResponse{}
+	result, err := r.Parse(response)
+	if result != nil {
+		t.Fatalf("Expected nil CDNSKEY response, got %+v", result)
+	}
+	if err == nil || !strings.Contains(err.Error(), "resolution failed") {
+		t.Errorf("Expected error to contain 'resolution failed', got: %v", err)
+	}
+}
+
+func TestNewCDNSKEYResponseParseTwice(t *testing.T) {
+	first := `; fully validated
+example.com.            3600    IN      CDNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+ KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==`
+	second := `; fully validated
+example.org.            3600    IN      CDNSKEY 0 3 0 AA==`
+	r := &CDNSKEYResponse{}
+	if _, err := r.Parse(first); err != nil {
+		t.Fatalf("Failed to parse CDNSKEY record: %v", err)
+	}
+	result, err := r.Parse(second)
+	if err != nil {
+		t.Fatalf("Failed to parse CDNSKEY record: %v", err)
+	}
+	cdnskeyResponse := result.(*CDNSKEYResponse)
+	if len(cdnskeyResponse.Records) != 1 || !cdnskeyResponse.IsDeleteRequest() {
+		t.Errorf("Expected only the delete request of the second response, got %+v", cdnskeyResponse.Records)
+	}
+}
@@ -0,0 +1,191 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CDSResponse represents the complete response for a CDS (Child DS) query.
+// CDS records are published by the child zone to signal to the parent which DS records
+// it should hold (RFC 7344), or that the DS records should be removed (RFC 8078).
+// A CDS record has the same format as a DS record, so the records are stored as DSRecord values.
+//
+// Fields:
+//
+//	Records: A slice of DSRecord structs, each representing an individual CDS record.
+//	         The slice is empty when the zone does not publish CDS records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative,
+//	           has been validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       CDS record set. This field is nil if the record set is absent or not signed.
+//	       When the set carries several signatures, it holds the last one.
+//
+//	RRSIGs: A slice with every RRSIG record covering the CDS record set. The set is signed by more
+//	        than one key during key and algorithm rollovers.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type CDSResponse struct {
+	Records     []DSRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses a raw DNS response string and creates a new CDSResponse struct.
+// This function is designed to work with the output of the 'delv' command-line tool for CDS
+// queries. Since most zones do not publish CDS records, a response stating that the name has
+// no CDS records is not an error and yields an empty CDSResponse.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//	          This response should be the result of a CDS query for a specific domain.
+//
+// Return Value:
+//
+//	*CDSResponse: A pointer to a CDSResponse struct that contains the parsed CDS records,
+//	              the validation status, the associated RRSIG record (if available), and the raw response.
+//
+//	error: An error object that indicates any issues encountered during the parsing of the
+//	       response string. If the parsing is successful, the error is nil.
+//
+// Example Usage:
+//
+//	cdsResponse, err := (&CDSResponse{}).Parse(rawDelvResponse)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	// Compare cdsResponse against the DS records of the parent zone
+func (r *CDSResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = CDSResponse{RawResponse: response}
+	if isNoDataResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	cdsRegex := regexp.MustCompile(`\bIN\s+CDS\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+CDS\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if cdsRegex.MatchString(line) {
+			cdsRecord := &DSRecord{}
+			parts := strings.Fields(line)
+			if len(parts) < 8 {
+				return nil, fmt.Errorf("invalid CDS r format: %s", line)
+			}
+
+			keyTag, err := strconv.ParseUint(parts[4], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key tag '%s' in CDS r: %v", parts[4], err)
+			}
+			cdsRecord.KeyTag = uint16(keyTag)
+
+			algorithm, err := strconv.ParseUint(parts[5], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid algorithm '%s' in CDS r: %v", parts[5], err)
+			}
+			cdsRecord.Algorithm = uint8(algorithm)
+
+			digestType, err := strconv.ParseUint(parts[6], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid digest type '%s' in CDS r: %v", parts[6], err)
+			}
+			cdsRecord.DigestType = uint8(digestType)
+
+			cdsRecord.Digest = strings.Join(parts[7:], "")
+
+			r.Records = append(r.Records, *cdsRecord)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
+		}
+	}
+	return r, nil
+}
+
+// IsDeleteRequest reports whether the CDS record set asks the parent to remove the DS records.
+// RFC 8078 defines the delete request as a single CDS record "0 0 0 00".
+func (r *CDSResponse) IsDeleteRequest() bool {
+	for _, record := range r.Records {
+		if record.Algorithm == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare checks the equality between two instances of CDSResponse.
+// This function is useful for testing and validation purposes.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *CDSResponse) Compare(b *CDSResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
+		r.RawResponse == b.RawResponse
+}
+
+// String returns a formatted string representation of the CDSResponse.
+// It provides a human-readable view of the response, including the CDS records, validation status, and raw response.
+func (r *CDSResponse) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	var recordsStr []string
+	for _, record := range r.Records {
+		recordsStr = append(recordsStr, record.String())
+	}
+
+	validatedStr := "No"
+	if r.Validated {
+		validatedStr = "Yes"
+	}
+
+	rrsigStr := "<null>"
+	if r.RRSIG != nil {
+		rrsigStr = r.RRSIG.String()
+	}
+
+	return fmt.Sprintf(
+		"CDSResponse:\n"+
+			"  Records:\n    %s\n"+
+			"  Validated: %s\n"+
+			"  RRSIG: %s\n"+
+			"  Raw Response: %s\n",
+		strings.Join(recordsStr, "\n    "),
+		validatedStr,
+		rrsigStr,
+		r.RawResponse,
+	)
+}
@@ -0,0 +1,95 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewCDSResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      CDS     60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B83 83F6A1E4469DA50A
+example.com.            3600    IN      RRSIG   CDS 5 2 3600 20240111000000 20231221000000 60485 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &CDSResponse{
+		Records: []DSRecord{
+			{KeyTag: 60485, Algorithm: 5, DigestType: 2, Digest: "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "CDS",
+			Algorithm:   5,
+			Labels:      2,
+			OriginalTTL: 3600,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      60485,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &CDSResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse CDS record: %v", err)
+	}
+	cdsResponse, ok := result.(*CDSResponse)
+	if !ok {
+		t.Fatalf("Result is not a *CDSResponse")
+	}
+
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
+	if !cdsResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", cdsResponse, expected)
+	}
+	if cdsResponse.IsDeleteRequest() {
+		t.Errorf("Expected no delete request")
+	}
+}
+
+func TestNewCDSResponseDelete(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      CDS     0 0 0 00`
+	r := &CDSResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse CDS record: %v", err)
+	}
+	if !result.(*CDSResponse).IsDeleteRequest() {
+		t.Errorf("Expected a delete request")
+	}
+}
+
+func TestNewCDSResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; example.com.                  3600    IN      \-CDS   ;-$NXRRSET`
+	r := &CDSResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty CDS response, got error: %v", err)
+	}
+	cdsResponse := result.(*CDSResponse)
+	if len(cdsResponse.Records) != 0 || !cdsResponse.Validated {
+		t.Errorf("Expected a validated, empty CDS response, got %+v", cdsResponse)
+	}
+}
+
+func TestNewCDSResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+example.com.            3600    IN      CDS     60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B83 83F6A1E4469DA50A
+example.com.            3600    IN      RRSIG   CDS 5 2 3600 20240111000000 20231221000000 60485 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	noData := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; example.org.                  3600    IN      \-CDS   ;-$NXRRSET`
+	r := &CDSResponse{}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse CDS record: %v", err)
+	}
+	result, err := r.Parse(noData)
+	if err != nil {
+		t.Fatalf("Expected an empty CDS response, got error: %v", err)
+	}
+	expected := &CDSResponse{Validated: true, RawResponse: noData}
+	if cdsResponse := result.(*CDSResponse); !cdsResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", cdsResponse)
+	}
+}
@@ -1,6 +1,8 @@
 package dnsrecords
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -32,6 +34,7 @@ import (
 //
 //	KeyID: An unsigned 16-bit integer representing the DNSKEY record's identification value,
 //	       often used to reference this key in related DNSSEC records like DS or RRSIG.
+//	       The parser computes it from the RDATA of the record (see ComputeKeyTag).
 type DNSKEYRecord struct {
 	Flags         uint16
 	Protocol      uint8
@@ -68,6 +71,50 @@ func (r *DNSKEYRecord) String() string {
 	)
 }
 
+// RData returns the wire format RDATA of the DNSKEY record (RFC 4034, Section 2.1): the flags,
+// protocol and algorithm fields followed by the decoded public key.
+func (r *DNSKEYRecord) RData() ([]byte, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(r.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+	rdata := make([]byte, 4, 4+len(publicKey))
+	binary.BigEndian.PutUint16(rdata, r.Flags)
+	rdata[2] = r.Protocol
+	rdata[3] = r.Algorithm
+	return append(rdata, publicKey...), nil
+}
+
+// ComputeKeyTag computes the key tag of the DNSKEY record from its RDATA, using the algorithm of
+// RFC 4034, Appendix B. Keys of algorithm 1 (RSA/MD5) use the most significant 16 bits of the
+// last 24 bits of the modulus instead of the checksum.
+//
+// The key tag is what RRSIG and DS records use to reference a key. It is not unique: two keys
+// of a zone may share a tag, so matches must also compare the algorithm and, where possible,
+// the key itself.
+func (r *DNSKEYRecord) ComputeKeyTag() (uint16, error) {
+	rdata, err := r.RData()
+	if err != nil {
+		return 0, err
+	}
+	if r.Algorithm == 1 {
+		if len(rdata) < 7 {
+			return 0, fmt.Errorf("public key too short for algorithm 1")
+		}
+		return binary.BigEndian.Uint16(rdata[len(rdata)-3:]), nil
+	}
+	var accumulator uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			accumulator += uint32(b)
+		} else {
+			accumulator += uint32(b) << 8
+		}
+	}
+	accumulator += accumulator >> 16 & 0xFFFF
+	return uint16(accumulator & 0xFFFF), nil
+}
+
 // DNSKEYResponse represents the complete response for a DNSKEY query.
 // It includes a collection of DNSKEY records, the validation status of the response,
 // any associated RRSIG record, and the raw response received from the DNS server.
@@ -83,6 +130,10 @@ func (r *DNSKEYRecord) String() string {
 //
 //	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
 //	       DNSKEY record set. This field is nil if DNSSEC is not used or if the record is not signed.
+//	       When the set carries several signatures, it holds the last one.
+//
+//	RRSIGs: A slice with every RRSIG record covering the DNSKEY record set. The set is signed by
+//	        more than one key during rollovers and in zones that separate KSK and ZSK roles.
 //
 //	RawResponse: A string containing the raw textual response received from the DNS server,
 //	             useful for logging, debugging, or other diagnostic purposes.
@@ -90,6 +141,7 @@ type DNSKEYResponse struct {
 	Records     []DNSKEYRecord
 	Validated   bool
 	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
 	RawResponse string
 }
 
@@ -147,7 +199,8 @@ func (r *DNSKEYResponse) Parse(response string) (DNSRecordResult, error) {
 			r.Validated = false
 		} else if dnsKeyRegex.MatchString(line) {
 			dnskeyRecord := &DNSKEYRecord{}
-			parts := strings.Fields(line)
+			record, comment, _ := strings.Cut(line, ";")
+			parts := strings.Fields(record)
 			if len(parts) < 8 {
 				return nil, fmt.Errorf("invalid DNSKEY r: %s", line)
 			}
@@ -169,44 +222,27 @@ func (r *DNSKEYResponse) Parse(response string) (DNSRecordResult, error) {
 				return nil, fmt.Errorf("invalid algorithm '%s' in DNSKEY r: %v", parts[6], err)
 			}
 			dnskeyRecord.Algorithm = uint8(algorithm)
+			dnskeyRecord.PublicKey = strings.Join(parts[7:], "")
 
-			comments := strings.Split(strings.Join(parts[7:], " "), ";")
-			if len(comments) > 1 {
-				for _, comment := range comments[1:] {
-					if strings.Contains(comment, "alg =") {
-						dnskeyRecord.AlgorithmName = strings.TrimSpace(strings.Split(comment, "=")[1])
-					} else if strings.Contains(comment, "key id =") {
-						keyID, err := strconv.ParseUint(strings.TrimSpace(strings.Split(comment, "=")[1]), 10, 16)
-						if err != nil {
-							return nil, fmt.Errorf("invalid key id '%s' in DNSKEY r: %v", strings.TrimSpace(strings.Split(comment, "=")[1]), err)
-						}
-						dnskeyRecord.KeyID = uint16(int(keyID))
-					} else if strings.Contains(comment, "ZSK") || strings.Contains(comment, "KSK") {
-						keyTypeParts := strings.Fields(comment)
-						if len(keyTypeParts) > 0 {
-							dnskeyRecord.KeyType = keyTypeParts[0]
-						} else {
-							return nil, fmt.Errorf("invalid key type '%s' in DNSKEY r: %v", comment, err)
-						}
-					}
-				}
-			} else {
-				return nil, fmt.Errorf("invalid DNSKEY r: %s", line)
+			keyTag, err := dnskeyRecord.ComputeKeyTag()
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key in DNSKEY r: %v", err)
 			}
+			dnskeyRecord.KeyID = keyTag
 
-			semicolonIndex := strings.Index(line, ";")
-			if semicolonIndex == -1 {
-				return nil, fmt.Errorf("missing ';' in DNSKEY r: %s", line)
+			if dnskeyRecord.Flags&0x0001 != 0 {
+				dnskeyRecord.KeyType = "KSK"
+			} else {
+				dnskeyRecord.KeyType = "ZSK"
 			}
-
-			publicKeyParts := parts[7:]
-			for i, part := range parts {
-				if i >= 7 && strings.Contains(part, ";") {
-					publicKeyParts = parts[7:i]
-					break
+			for _, c := range strings.Split(comment, ";") {
+				if strings.Contains(c, "alg =") {
+					dnskeyRecord.AlgorithmName = strings.TrimSpace(strings.Split(c, "=")[1])
+				} else if keyTypeParts := strings.Fields(c); len(keyTypeParts) > 0 &&
+					(keyTypeParts[0] == "ZSK" || keyTypeParts[0] == "KSK") {
+					dnskeyRecord.KeyType = keyTypeParts[0]
 				}
 			}
-			dnskeyRecord.PublicKey = strings.Join(publicKeyParts, "")
 
 			r.Records = append(r.Records, *dnskeyRecord)
 		} else if rrsigRegex.MatchString(line) {
@@ -216,6 +252,7 @@ func (r *DNSKEYResponse) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 	return r, nil
@@ -258,6 +295,14 @@ func (r *DNSKEYResponse) Compare(b *DNSKEYResponse) bool {
 			return false
 		}
 	}
+	if len(r.RRSIGs) != len(b.RRSIGs) {
+		return false
+	}
+	for i := range r.RRSIGs {
+		if !r.RRSIGs[i].Compare(&b.RRSIGs[i]) {
+			return false
+		}
+	}
 	return r.Validated == b.Validated &&
 		r.RRSIG.Compare(b.RRSIG) &&
 		r.RawResponse == b.RawResponse
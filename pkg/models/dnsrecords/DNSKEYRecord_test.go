@@ -40,6 +40,19 @@ func TestNewDNSKEYRecordOK(t *testing.T) {
 			SignerName:  "ipb.pt",
 			Signature:   "D8Rtw6kkAXMQpUjwwjFp7s5zx+4ocz8+0D7natTPc7yxsZIaE+k4Eud3iqL4o8jRGgyqGRDsbxRUQx1dB4ivbxyrQe+TnYMm1lOZPQIt9zKfTt/3UegBL2hWVa+5StWMtsfDTFTuhQI4kkJ01aIKpVi7++B4dXVjOQA8ydMNgNzErUMFe+NNpdE5ddrTWRWS9aH6jewKohhf1lNU0WkR8NjWtCIQqFdkcDd5AIHXJ5yKjyOjC/2A+9ZxELqRSTPo3SKnSMRCQO9yR5v5EJh7k7GYm0rFzN2D2EkIlqi19MPHBwzBHf/GBLCL5tiQjxo+ZqxOPUv3Dp4Bm5LHNVt3cg==",
 		},
+		RRSIGs: []RRSIGRecord{
+			{
+				TypeCovered: "DNSKEY",
+				Algorithm:   7,
+				Labels:      2,
+				OriginalTTL: 86400,
+				Expiration:  1704326400,
+				Inception:   1702512000,
+				KeyTag:      4410,
+				SignerName:  "ipb.pt",
+				Signature:   "D8Rtw6kkAXMQpUjwwjFp7s5zx+4ocz8+0D7natTPc7yxsZIaE+k4Eud3iqL4o8jRGgyqGRDsbxRUQx1dB4ivbxyrQe+TnYMm1lOZPQIt9zKfTt/3UegBL2hWVa+5StWMtsfDTFTuhQI4kkJ01aIKpVi7++B4dXVjOQA8ydMNgNzErUMFe+NNpdE5ddrTWRWS9aH6jewKohhf1lNU0WkR8NjWtCIQqFdkcDd5AIHXJ5yKjyOjC/2A+9ZxELqRSTPo3SKnSMRCQO9yR5v5EJh7k7GYm0rFzN2D2EkIlqi19MPHBwzBHf/GBLCL5tiQjxo+ZqxOPUv3Dp4Bm5LHNVt3cg==",
+			},
+		},
 		RawResponse: response,
 	}
 	r := &DNSKEYResponse{}
@@ -82,3 +95,61 @@ const badDNSKeyResponse = `;; resolution failed: ncache nxrrset
 ; negative response, unsigned answer
 ; ipp.pt.                       600     IN      \-DNSKEY ;-$NXRRSET
 ; ipp.pt. SOA dns1.ipp.pt. core.ipp.pt. 2023112101 7200 7200 1209600 86400`
+
+func TestDNSKEYRecordComputeKeyTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   DNSKEYRecord
+		expected uint16
+	}{
+		{
+			name: "RFC 4034 example key",
+			record: DNSKEYRecord{Flags: 256, Protocol: 3, Algorithm: 5,
+				PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="},
+			expected: 60485,
+		},
+		{
+			name:     "ECDSA key",
+			record:   DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="},
+			expected: 2371,
+		},
+		{
+			name:     "RSA/MD5 key uses the modulus",
+			record:   DNSKEYRecord{Flags: 256, Protocol: 3, Algorithm: 1, PublicKey: "AQOqu8w="},
+			expected: 0xAABB,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyTag, err := test.record.ComputeKeyTag()
+			if err != nil {
+				t.Fatalf("ComputeKeyTag: unexpected error: %v", err)
+			}
+			if keyTag != test.expected {
+				t.Errorf("Expected key tag %d, got %d", test.expected, keyTag)
+			}
+		})
+	}
+
+	if _, err := (&DNSKEYRecord{PublicKey: "not base64!"}).ComputeKeyTag(); err == nil {
+		t.Errorf("Expected an error for an invalid public key")
+	}
+}
+
+func TestNewDNSKEYRecordWithoutComments(t *testing.T) {
+	response := `; fully validated
+dskey.example.com.      86400   IN      DNSKEY  256 3 5 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822a J5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw==
+dskey.example.com.      86400   IN      RRSIG   DNSKEY 5 3 86400 20240104000000 20231214000000 60485 dskey.example.com. AAAA
+dskey.example.com.      86400   IN      RRSIG   DNSKEY 5 3 86400 20240104000000 20231214000000 4410 dskey.example.com. BBBB`
+	result, err := (&DNSKEYResponse{}).Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse DNSKEY record: %v", err)
+	}
+	dnskey := result.(*DNSKEYResponse)
+	if len(dnskey.Records) != 1 || dnskey.Records[0].KeyID != 60485 || dnskey.Records[0].KeyType != "ZSK" {
+		t.Errorf("Expected ZSK 60485, got %+v", dnskey.Records)
+	}
+	if len(dnskey.RRSIGs) != 2 || dnskey.RRSIGs[0].KeyTag != 60485 || dnskey.RRSIG.KeyTag != 4410 {
+		t.Errorf("Expected both signatures to be kept, got %+v", dnskey.RRSIGs)
+	}
+}
@@ -0,0 +1,178 @@
+package dnsrecords
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NSECProofRecord represents an NSEC record returned as part of a negative proof.
+// Unlike NSECRecord, which describes the NSEC record queried at a name, it keeps the
+// owner name so that the span it covers can be evaluated.
+//
+// Fields:
+//
+//	OwnerName: The owner name of the NSEC record, marking the start of the covered span.
+//
+//	NextDomainName: The next owner name in canonical order, marking the end of the covered span.
+//
+//	Types: A string listing the types of DNS resource records that exist at the owner name.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct containing the signature covering this record.
+//	       This field may be nil if the signature was not present in the response.
+type NSECProofRecord struct {
+	OwnerName      string
+	NextDomainName string
+	Types          string
+	RRSIG          *RRSIGRecord
+}
+
+// DenialResponse represents the response to a query for a name that is not expected to exist.
+// It collects the NSEC and NSEC3 records, with their signatures, that the zone returns
+// to prove the non-existence of the name.
+//
+// Fields:
+//
+//	QueryName: The name that was queried, as it appears in the response.
+//
+//	NXDomain: A boolean flag indicating whether the response reported that the name does not exist.
+//
+//	Validated: A boolean flag indicating whether the negative response was fully validated.
+//
+//	NSEC3Records: A slice of NSEC3Record structs returned as part of the proof.
+//
+//	NSECRecords: A slice of NSECProofRecord structs returned as part of the proof.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type DenialResponse struct {
+	QueryName    string
+	NXDomain     bool
+	Validated    bool
+	NSEC3Records []NSEC3Record
+	NSECRecords  []NSECProofRecord
+	RawResponse  string
+}
+
+// Parse parses a raw DNS response string and creates a new DenialResponse struct.
+// This function is designed to work with the output of the 'delv' command-line tool for
+// queries of names that do not exist. Unlike the other parsers, a failed resolution is the
+// expected outcome, so "resolution failed" does not produce an error; delv prints the records
+// of the negative proof as comments, which are parsed along with any regular record lines.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//	          This response should be the result of a query for a non-existent name.
+//
+// Return Value:
+//
+//	*DenialResponse: A pointer to a DenialResponse struct that contains the NSEC and NSEC3 records
+//	                 of the proof, each linked to its RRSIG record, and the validation status.
+//	                 Signatures printed in short form are linked as RRSIG records that only
+//	                 hold the covered type.
+//
+//	error: An error object that indicates any issues encountered during the parsing of the
+//	       response string. If the parsing is successful, the error is nil.
+//
+// Example Usage:
+//
+//	denialResponse, err := (&DenialResponse{}).Parse(rawDelvResponse)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	// Use denialResponse to evaluate the denial of existence proof
+func (r *DenialResponse) Parse(response string) (DNSRecordResult, error) {
+	if strings.TrimSpace(response) == "" {
+		return nil, errors.New("empty response")
+	}
+	r.RawResponse = response
+	signatures := make(map[string]*RRSIGRecord)
+
+	for _, line := range strings.Split(response, "\n") {
+		if strings.HasPrefix(line, ";") && strings.Contains(line, "fully validated") {
+			r.Validated = true
+		}
+		if strings.Contains(strings.ToUpper(line), "NXDOMAIN") {
+			r.NXDomain = true
+		}
+
+		parts := recordFields(line)
+		if len(parts) < 5 {
+			continue
+		}
+		switch parts[3] {
+		case "NSEC3":
+			record, err := parseNSEC3Fields(parts)
+			if err != nil {
+				return nil, err
+			}
+			r.NSEC3Records = append(r.NSEC3Records, *record)
+		case "NSEC":
+			r.NSECRecords = append(r.NSECRecords, NSECProofRecord{
+				OwnerName:      strings.TrimSuffix(parts[0], "."),
+				NextDomainName: strings.TrimSuffix(parts[4], "."),
+				Types:          strings.Join(parts[5:], ";"),
+			})
+		case "RRSIG":
+			if parts[4] != "NSEC3" && parts[4] != "NSEC" {
+				continue
+			}
+			owner := parts[4] + " " + strings.ToLower(strings.TrimSuffix(parts[0], "."))
+			if len(parts) < 13 || parts[5] == "..." {
+				// delv prints the signatures of a negative proof in short form, with the data
+				// elided ("; owner RRSIG NSEC3 ..."). The signature is known to exist, but only
+				// the type it covers can be recorded.
+				signatures[owner] = &RRSIGRecord{TypeCovered: parts[4]}
+				continue
+			}
+			rrsigRecord, err := (&RRSIGRecord{}).Parse(strings.Join(parts, " "))
+			if err != nil {
+				return nil, err
+			}
+			signatures[owner] = rrsigRecord.(*RRSIGRecord)
+		default:
+			if strings.HasPrefix(parts[3], `\-`) && r.QueryName == "" {
+				r.QueryName = strings.TrimSuffix(parts[0], ".")
+			}
+		}
+	}
+
+	for i := range r.NSEC3Records {
+		r.NSEC3Records[i].RRSIG = signatures["NSEC3 "+strings.ToLower(r.NSEC3Records[i].OwnerName)]
+	}
+	for i := range r.NSECRecords {
+		r.NSECRecords[i].RRSIG = signatures["NSEC "+strings.ToLower(r.NSECRecords[i].OwnerName)]
+	}
+	return r, nil
+}
+
+// String returns a formatted string representation of the DenialResponse.
+// It provides a human-readable view of the proof records and the validation status.
+func (r *DenialResponse) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	var recordsStr []string
+	for _, record := range r.NSEC3Records {
+		recordsStr = append(recordsStr, record.String())
+	}
+	for _, record := range r.NSECRecords {
+		recordsStr = append(recordsStr, fmt.Sprintf("NSECProofRecord: %s -> %s (%s)",
+			record.OwnerName, record.NextDomainName, record.Types))
+	}
+
+	return fmt.Sprintf(
+		"DenialResponse:\n"+
+			"  Query Name: %s\n"+
+			"  NXDOMAIN: %t\n"+
+			"  Validated: %t\n"+
+			"  Records:\n    %s\n"+
+			"  Raw Response: %s\n",
+		r.QueryName,
+		r.NXDomain,
+		r.Validated,
+		strings.Join(recordsStr, "\n    "),
+		r.RawResponse,
+	)
+}
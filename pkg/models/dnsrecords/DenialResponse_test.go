@@ -0,0 +1,138 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewDenialResponseNSEC3(t *testing.T) {
+	response := nsec3DenialResponse
+	r := &DenialResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse denial response: %v", err)
+	}
+	denialResponse, ok := result.(*DenialResponse)
+	if !ok {
+		t.Fatalf("Result is not a *DenialResponse")
+	}
+
+	if denialResponse.QueryName != "a.c.x.w.example" {
+		t.Errorf("Expected query name a.c.x.w.example, got %s", denialResponse.QueryName)
+	}
+	if !denialResponse.NXDomain || !denialResponse.Validated {
+		t.Errorf("Expected a validated NXDOMAIN response, got NXDomain=%t Validated=%t",
+			denialResponse.NXDomain, denialResponse.Validated)
+	}
+	if len(denialResponse.NSEC3Records) != 3 {
+		t.Fatalf("Expected 3 NSEC3 records, got %d", len(denialResponse.NSEC3Records))
+	}
+
+	expected := &NSEC3Record{
+		OwnerName:       "b4um86eghhds6nea196smvmlo4ors995.example",
+		HashAlgorithm:   1,
+		Flags:           1,
+		OptOut:          true,
+		Iterations:      12,
+		Salt:            "AABBCCDD",
+		NextHashedOwner: "GJEQE526PLBF1G8MKLP59ENFD789NJGI",
+		Types:           "MX;RRSIG",
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "NSEC3",
+			Algorithm:   5,
+			Labels:      2,
+			OriginalTTL: 3600,
+			Expiration:  1136073600,
+			Inception:   1128297600,
+			KeyTag:      40430,
+			SignerName:  "example",
+			Signature:   "ZkPG3M32lmoHM6pa3D6gZFGB/rhL//Bs3Omh5u4m/CUiwtblEVOaAKKZd7S959OeiX43aLX3pOv0TSTyiTxIZg==",
+		},
+	}
+	if !denialResponse.NSEC3Records[1].Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", denialResponse.NSEC3Records[1], expected)
+	}
+	if denialResponse.NSEC3Records[0].RRSIG != nil {
+		t.Errorf("Expected no RRSIG for %s", denialResponse.NSEC3Records[0].OwnerName)
+	}
+}
+
+func TestNewDenialResponseNSEC(t *testing.T) {
+	response := `;; resolution failed: ncache nxdomain
+; negative response, fully validated
+; nothere.example.org.          3600    IN      \-A     ;-$NXDOMAIN
+; example.org. SOA ns1.example.org. hostmaster.example.org. 2024010101 7200 3600 1209600 3600
+; example.org. NSEC mail.example.org. A NS SOA RRSIG NSEC DNSKEY
+; mail.example.org. NSEC www.example.org. A RRSIG NSEC`
+
+	r := &DenialResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse denial response: %v", err)
+	}
+	denialResponse := result.(*DenialResponse)
+
+	expected := []NSECProofRecord{
+		{OwnerName: "example.org", NextDomainName: "mail.example.org", Types: "A;NS;SOA;RRSIG;NSEC;DNSKEY"},
+		{OwnerName: "mail.example.org", NextDomainName: "www.example.org", Types: "A;RRSIG;NSEC"},
+	}
+	if len(denialResponse.NSECRecords) != len(expected) {
+		t.Fatalf("Expected %d NSEC records, got %d", len(expected), len(denialResponse.NSECRecords))
+	}
+	for i := range expected {
+		if denialResponse.NSECRecords[i] != expected[i] {
+			t.Errorf("Parsed record %+v does not match expected %+v", denialResponse.NSECRecords[i], expected[i])
+		}
+	}
+}
+
+func TestNewDenialResponseEmpty(t *testing.T) {
+	r := &DenialResponse{}
+	result, err := r.Parse("")
+	if err == nil {
+		t.Fatalf("Expected error for empty response, got nil")
+	}
+	if result != nil {
+		t.Fatalf("Expected nil denial response, got %+v", result)
+	}
+}
+
+// nsec3DenialResponse follows the name error example of RFC 5155, Appendix B.1, as printed by delv.
+const nsec3DenialResponse = `;; resolution failed: ncache nxdomain
+; negative response, fully validated
+; a.c.x.w.example.              3600    IN      \-A     ;-$NXDOMAIN
+; example. SOA ns1.example. bugs.x.w.example. 1 3600 300 3600000 3600
+; 0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.example. NSEC3 1 1 12 aabbccdd 2T7B4G4VSA5SMI47K61MV5BV1A22BOJR NS SOA MX RRSIG DNSKEY NSEC3PARAM
+; b4um86eghhds6nea196smvmlo4ors995.example. NSEC3 1 1 12 aabbccdd GJEQE526PLBF1G8MKLP59ENFD789NJGI MX RRSIG
+; b4um86eghhds6nea196smvmlo4ors995.example. RRSIG NSEC3 5 2 3600 20060101000000 20051003000000 40430 example. ZkPG3M32lmoHM6pa3D6gZFGB/rhL//Bs3Omh5u4m/CUiwtblEVOaAKKZ d7S959OeiX43aLX3pOv0TSTyiTxIZg==
+; 35mthgpgcu1qg68fab165klnsnk3dpvl.example. NSEC3 1 1 12 aabbccdd B4UM86EGHHDS6NEA196SMVMLO4ORS995 NS DS RRSIG`
+
+func TestNewDenialResponseShortFormSignatures(t *testing.T) {
+	response := `;; resolution failed: ncache nxdomain
+; negative response, fully validated
+; dnssec-probe-3f9a1c2b7d4e.uminho.pt.  300     IN      \-ANY   ;-$NXDOMAIN
+; uminho.pt. SOA dns.uminho.pt. hostmaster.uminho.pt. 2024010501 14400 3600 1209600 300
+; uminho.pt. RRSIG SOA ...
+; 0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.uminho.pt. RRSIG NSEC3 ...
+; 0p9mhaveqvm6t7vbl5lop2u3t2rp3tom.uminho.pt. NSEC3 1 0 0 - 0P9MHAVEQVM6T7VBL5LOP2U3T2RP3TOO NS SOA MX TXT RRSIG DNSKEY NSEC3PARAM
+; k1qf3o1hs0ta8lhqd8otq2ss0fgmnp5o.uminho.pt. RRSIG NSEC3 ...
+; k1qf3o1hs0ta8lhqd8otq2ss0fgmnp5o.uminho.pt. NSEC3 1 0 0 - K1QF3O1HS0TA8LHQD8OTQ2SS0FGMNP60 A RRSIG
+; v7ju0hqpg1ra5bvt85o0e19dq9s8ahlb.uminho.pt. RRSIG NSEC3 ...
+; v7ju0hqpg1ra5bvt85o0e19dq9s8ahlb.uminho.pt. NSEC3 1 0 0 - V7JU0HQPG1RA5BVT85O0E19DQ9S8AHLD CNAME RRSIG`
+	r := &DenialResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse denial response with short form signatures: %v", err)
+	}
+	denialResponse := result.(*DenialResponse)
+	if !denialResponse.NXDomain || !denialResponse.Validated || denialResponse.QueryName != "dnssec-probe-3f9a1c2b7d4e.uminho.pt" {
+		t.Errorf("Expected a validated NXDOMAIN response for the probe, got %+v", denialResponse)
+	}
+	if len(denialResponse.NSEC3Records) != 3 {
+		t.Fatalf("Expected 3 NSEC3 records, got %d", len(denialResponse.NSEC3Records))
+	}
+	for _, record := range denialResponse.NSEC3Records {
+		if record.RRSIG == nil || record.RRSIG.TypeCovered != "NSEC3" {
+			t.Errorf("Expected NSEC3 record %s to be linked to its signature, got %+v", record.OwnerName, record.RRSIG)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package dnsrecords
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DigResponse represents a DNS message as printed by the 'dig' command-line tool.
+// Unlike delv, dig does not validate answers; it is used to send non-recursive queries to
+// specific servers, such as the authoritative servers of a zone or its parent, and to inspect
+// the header and every section of the response.
+//
+// Fields:
+//
+//	Status: The response code reported in the header (e.g., "NOERROR", "NXDOMAIN", "REFUSED").
+//
+//	Flags: The header flags set in the response (e.g., "qr", "aa", "rd", "ra", "ad").
+//
+//	Answer: The record lines of the answer section.
+//
+//	Authority: The record lines of the authority section.
+//
+//	Additional: The record lines of the additional section.
+//
+//	ExtendedErrors: The Extended DNS Error options (RFC 8914) of the OPT pseudo-section.
+//
+//	RawResponse: A string containing the raw textual output of dig.
+type DigResponse struct {
+	Status         string
+	Flags          []string
+	Answer         []string
+	Authority      []string
+	Additional     []string
+	ExtendedErrors []ExtendedDNSError
+	RawResponse    string
+}
+
+// Parse parses the raw output of a single dig query and creates a new DigResponse struct.
+// An error is returned when dig did not receive a response, for instance because the server
+// timed out or could not be reached.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual output of the 'dig' command-line tool.
+//
+// Return Value:
+//
+//	*DigResponse: A pointer to a DigResponse struct with the header fields and the record
+//	              lines of each section.
+//
+//	error: An error object that indicates that no DNS message could be found in the output.
+//
+// Example Usage:
+//
+//	digResponse, err := (&DigResponse{}).Parse(rawDigOutput)
+//	if err != nil {
+//	    // The server did not answer
+//	}
+//	if digResponse.HasFlag("aa") {
+//	    // The answer is authoritative
+//	}
+func (r *DigResponse) Parse(response string) (DNSRecordResult, error) {
+	r.RawResponse = response
+	var section *[]string
+	headerFound := false
+
+	for _, line := range strings.Split(response, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, ";; ->>HEADER<<-"):
+			headerFound = true
+			for _, field := range strings.Split(trimmed, ",") {
+				if _, value, ok := strings.Cut(field, "status:"); ok {
+					r.Status = strings.TrimSpace(value)
+				}
+			}
+		case strings.HasPrefix(trimmed, ";; flags:"):
+			flags, _, _ := strings.Cut(strings.TrimPrefix(trimmed, ";; flags:"), ";")
+			r.Flags = strings.Fields(flags)
+		case trimmed == ";; ANSWER SECTION:":
+			section = &r.Answer
+		case trimmed == ";; AUTHORITY SECTION:":
+			section = &r.Authority
+		case trimmed == ";; ADDITIONAL SECTION:":
+			section = &r.Additional
+		case strings.HasPrefix(trimmed, "; EDE:"):
+			if ede, ok := parseExtendedDNSError(trimmed); ok {
+				r.ExtendedErrors = append(r.ExtendedErrors, ede)
+			}
+		case trimmed == "" || strings.HasPrefix(trimmed, ";"):
+			if trimmed == "" {
+				section = nil
+			}
+		case section != nil:
+			*section = append(*section, trimmed)
+		}
+	}
+
+	if !headerFound {
+		firstLine := strings.TrimSpace(strings.Split(strings.TrimSpace(response), "\n")[0])
+		for _, line := range strings.Split(response, "\n") {
+			if strings.Contains(line, "timed out") || strings.Contains(line, "no servers could be reached") ||
+				strings.Contains(line, "communications error") {
+				return nil, fmt.Errorf("no response: %s", strings.TrimSpace(strings.TrimLeft(line, "; ")))
+			}
+		}
+		if firstLine == "" {
+			return nil, errors.New("no response: empty output")
+		}
+		return nil, fmt.Errorf("no response: %s", firstLine)
+	}
+	return r, nil
+}
+
+// HasFlag reports whether the given header flag is set in the response.
+func (r *DigResponse) HasFlag(flag string) bool {
+	for _, f := range r.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordsOfType returns the fields of the record lines of a section whose type is recordType.
+// The fields follow the "owner TTL class TYPE RDATA" layout.
+func RecordsOfType(section []string, recordType string) [][]string {
+	var records [][]string
+	for _, line := range section {
+		parts := recordFields(line)
+		if len(parts) >= 5 && parts[3] == recordType {
+			records = append(records, parts)
+		}
+	}
+	return records
+}
+
+// String returns a formatted string representation of the DigResponse.
+func (r *DigResponse) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"DigResponse:\n"+
+			"  Status: %s\n"+
+			"  Flags: %s\n"+
+			"  Answer:\n    %s\n"+
+			"  Authority:\n    %s\n"+
+			"  Additional:\n    %s\n"+
+			"  Extended Errors: %v\n",
+		r.Status,
+		strings.Join(r.Flags, " "),
+		strings.Join(r.Answer, "\n    "),
+		strings.Join(r.Authority, "\n    "),
+		strings.Join(r.Additional, "\n    "),
+		r.ExtendedErrors,
+	)
+}
@@ -0,0 +1,116 @@
+package dnsrecords
+
+import (
+	"strings"
+	"testing"
+)
+
+const referralDigResponse = `
+; <<>> DiG 9.18.19 <<>> @192.5.6.30 example.com NS +norec +time=2 +tries=1
+; (1 server found)
+;; global options: +cmd
+;; Got answer:
+;; ->>HEADER<<- opcode: QUERY, status: NOERROR, id: 4012
+;; flags: qr; QUERY: 1, ANSWER: 0, AUTHORITY: 2, ADDITIONAL: 3
+
+;; OPT PSEUDOSECTION:
+; EDNS: version: 0, flags:; udp: 1232
+;; QUESTION SECTION:
+;example.com.			IN	NS
+
+;; AUTHORITY SECTION:
+example.com.		172800	IN	NS	ns1.example.com.
+example.com.		172800	IN	NS	ns2.example.net.
+
+;; ADDITIONAL SECTION:
+ns1.example.com.	172800	IN	A	192.0.2.53
+ns1.example.com.	172800	IN	AAAA	2001:db8::53
+
+;; Query time: 12 msec
+;; SERVER: 192.5.6.30#53(192.5.6.30) (UDP)
+;; WHEN: Thu Dec 21 10:00:00 UTC 2023
+;; MSG SIZE  rcvd: 135
+`
+
+func TestNewDigResponseReferral(t *testing.T) {
+	result, err := (&DigResponse{}).Parse(referralDigResponse)
+	if err != nil {
+		t.Fatalf("Failed to parse dig response: %v", err)
+	}
+	digResponse, ok := result.(*DigResponse)
+	if !ok {
+		t.Fatalf("Result is not a *DigResponse")
+	}
+
+	if digResponse.Status != "NOERROR" {
+		t.Errorf("Expected status NOERROR, got %s", digResponse.Status)
+	}
+	if !digResponse.HasFlag("qr") || digResponse.HasFlag("aa") {
+		t.Errorf("Unexpected flags %v", digResponse.Flags)
+	}
+	if len(digResponse.Answer) != 0 || len(digResponse.Authority) != 2 || len(digResponse.Additional) != 2 {
+		t.Errorf("Unexpected sections: %s", digResponse)
+	}
+
+	ns := RecordsOfType(digResponse.Authority, "NS")
+	if len(ns) != 2 || ns[1][4] != "ns2.example.net." {
+		t.Errorf("Unexpected NS records %v", ns)
+	}
+	aaaa := RecordsOfType(digResponse.Additional, "AAAA")
+	if len(aaaa) != 1 || aaaa[0][0] != "ns1.example.com." || aaaa[0][4] != "2001:db8::53" {
+		t.Errorf("Unexpected AAAA records %v", aaaa)
+	}
+}
+
+func TestNewDigResponseTimeout(t *testing.T) {
+	response := `
+; <<>> DiG 9.18.19 <<>> @192.0.2.1 example.com SOA +norec +time=2 +tries=1
+; (1 server found)
+;; global options: +cmd
+;; connection timed out; no servers could be reached
+`
+	_, err := (&DigResponse{}).Parse(response)
+	if err == nil || !strings.HasPrefix(err.Error(), "no response") {
+		t.Errorf("Expected a no response error, got %v", err)
+	}
+}
+
+const bogusDigResponse = `
+; <<>> DiG 9.18.19 <<>> @1.1.1.1 dnssec-failed.org A +dnssec +time=2 +tries=1
+; (1 server found)
+;; global options: +cmd
+;; Got answer:
+;; ->>HEADER<<- opcode: QUERY, status: SERVFAIL, id: 51034
+;; flags: qr rd ra; QUERY: 1, ANSWER: 0, AUTHORITY: 0, ADDITIONAL: 1
+
+;; OPT PSEUDOSECTION:
+; EDNS: version: 0, flags: do; udp: 1232
+; EDE: 9 (DNSKEY Missing): (no SEP matching the DS found for dnssec-failed.org.)
+;; QUESTION SECTION:
+;dnssec-failed.org.		IN	A
+
+;; Query time: 20 msec
+;; SERVER: 1.1.1.1#53(1.1.1.1) (UDP)
+;; WHEN: Thu Dec 21 10:00:00 UTC 2023
+;; MSG SIZE  rcvd: 103
+`
+
+func TestNewDigResponseExtendedErrors(t *testing.T) {
+	result, err := (&DigResponse{}).Parse(bogusDigResponse)
+	if err != nil {
+		t.Fatalf("Failed to parse dig response: %v", err)
+	}
+	digResponse := result.(*DigResponse)
+	if digResponse.Status != "SERVFAIL" {
+		t.Errorf("Expected status SERVFAIL, got %s", digResponse.Status)
+	}
+	expected := ExtendedDNSError{
+		Code:     9,
+		Name:     "DNSKEY Missing",
+		Category: EDECategoryDNSSEC,
+		Text:     "no SEP matching the DS found for dnssec-failed.org.",
+	}
+	if len(digResponse.ExtendedErrors) != 1 || digResponse.ExtendedErrors[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, digResponse.ExtendedErrors)
+	}
+}
@@ -0,0 +1,150 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Categories of Extended DNS Errors, used to compare failures across resolvers.
+const (
+	EDECategoryDNSSEC     = "dnssec"
+	EDECategoryCache      = "cache"
+	EDECategoryPolicy     = "policy"
+	EDECategoryResolution = "resolution"
+	EDECategoryOther      = "other"
+)
+
+var extendedErrorNames = map[uint16]string{
+	0:  "Other Error",
+	1:  "Unsupported DNSKEY Algorithm",
+	2:  "Unsupported DS Digest Type",
+	3:  "Stale Answer",
+	4:  "Forged Answer",
+	5:  "DNSSEC Indeterminate",
+	6:  "DNSSEC Bogus",
+	7:  "Signature Expired",
+	8:  "Signature Not Yet Valid",
+	9:  "DNSKEY Missing",
+	10: "RRSIGs Missing",
+	11: "No Zone Key Bit Set",
+	12: "NSEC Missing",
+	13: "Cached Error",
+	14: "Not Ready",
+	15: "Blocked",
+	16: "Censored",
+	17: "Filtered",
+	18: "Prohibited",
+	19: "Stale NXDomain Answer",
+	20: "Not Authoritative",
+	21: "Not Supported",
+	22: "No Reachable Authority",
+	23: "Network Error",
+	24: "Invalid Data",
+	25: "Signature Expired before Valid",
+	26: "Too Early",
+	27: "Unsupported NSEC3 Iterations Value",
+	28: "Unable to conform to policy",
+	29: "Synthesized",
+	30: "Invalid Query Type",
+}
+
+var extendedErrorCategories = map[uint16]string{
+	1:  EDECategoryDNSSEC,
+	2:  EDECategoryDNSSEC,
+	3:  EDECategoryCache,
+	4:  EDECategoryPolicy,
+	5:  EDECategoryDNSSEC,
+	6:  EDECategoryDNSSEC,
+	7:  EDECategoryDNSSEC,
+	8:  EDECategoryDNSSEC,
+	9:  EDECategoryDNSSEC,
+	10: EDECategoryDNSSEC,
+	11: EDECategoryDNSSEC,
+	12: EDECategoryDNSSEC,
+	13: EDECategoryCache,
+	14: EDECategoryResolution,
+	15: EDECategoryPolicy,
+	16: EDECategoryPolicy,
+	17: EDECategoryPolicy,
+	18: EDECategoryPolicy,
+	19: EDECategoryCache,
+	20: EDECategoryResolution,
+	21: EDECategoryResolution,
+	22: EDECategoryResolution,
+	23: EDECategoryResolution,
+	24: EDECategoryResolution,
+	25: EDECategoryDNSSEC,
+	26: EDECategoryResolution,
+	27: EDECategoryDNSSEC,
+	28: EDECategoryPolicy,
+	29: EDECategoryOther,
+	30: EDECategoryResolution,
+}
+
+var extendedErrorRegex = regexp.MustCompile(`^;\s*EDE:\s*(\d+)(?:\s*\(([^)]*)\))?(?::\s*(.*))?$`)
+
+// ExtendedDNSError represents an Extended DNS Error option (RFC 8914) returned by a resolver,
+// which explains why a query failed or why an answer is unusual.
+//
+// Fields:
+//
+//	Code: The INFO-CODE of the error, as registered by IANA (e.g., 6 for "DNSSEC Bogus").
+//
+//	Name: The registered name of the code.
+//
+//	Category: The class of failure the code belongs to: "dnssec", "cache", "policy",
+//	          "resolution" or "other". It gives a resolver-independent failure taxonomy.
+//
+//	Text: The optional EXTRA-TEXT of the option, a free-form explanation from the resolver.
+type ExtendedDNSError struct {
+	Code     uint16
+	Name     string
+	Category string
+	Text     string
+}
+
+// NewExtendedDNSError creates an ExtendedDNSError with the registered name and category of the code.
+func NewExtendedDNSError(code uint16, text string) ExtendedDNSError {
+	name, ok := extendedErrorNames[code]
+	if !ok {
+		name = fmt.Sprintf("Unassigned %d", code)
+	}
+	category, ok := extendedErrorCategories[code]
+	if !ok {
+		category = EDECategoryOther
+	}
+	return ExtendedDNSError{Code: code, Name: name, Category: category, Text: text}
+}
+
+// parseExtendedDNSError parses an EDE line of the OPT pseudo-section printed by dig
+// (e.g. "; EDE: 7 (Signature Expired): (expired signature)"). It returns false for other lines.
+func parseExtendedDNSError(line string) (ExtendedDNSError, bool) {
+	matches := extendedErrorRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return ExtendedDNSError{}, false
+	}
+	code, err := strconv.ParseUint(matches[1], 10, 16)
+	if err != nil {
+		return ExtendedDNSError{}, false
+	}
+	text := strings.TrimSpace(matches[3])
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		text = text[1 : len(text)-1]
+	}
+	return NewExtendedDNSError(uint16(code), text), true
+}
+
+// IsDNSSECFailure reports whether the error is a DNSSEC validation failure.
+func (e ExtendedDNSError) IsDNSSECFailure() bool {
+	return e.Category == EDECategoryDNSSEC
+}
+
+// String returns a formatted string representation of the ExtendedDNSError.
+func (e ExtendedDNSError) String() string {
+	if e.Text == "" {
+		return fmt.Sprintf("EDE %d (%s)", e.Code, e.Name)
+	}
+	return fmt.Sprintf("EDE %d (%s): %s", e.Code, e.Name, e.Text)
+}
@@ -0,0 +1,32 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestParseExtendedDNSError(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected ExtendedDNSError
+		ok       bool
+	}{
+		{"; EDE: 7 (Signature Expired): (signature expired on 20231201000000)",
+			ExtendedDNSError{Code: 7, Name: "Signature Expired", Category: EDECategoryDNSSEC, Text: "signature expired on 20231201000000"}, true},
+		{"; EDE: 22 (No Reachable Authority)",
+			ExtendedDNSError{Code: 22, Name: "No Reachable Authority", Category: EDECategoryResolution}, true},
+		{"; EDE: 3 (Stale Answer): (stale data)",
+			ExtendedDNSError{Code: 3, Name: "Stale Answer", Category: EDECategoryCache, Text: "stale data"}, true},
+		{"; EDE: 49152",
+			ExtendedDNSError{Code: 49152, Name: "Unassigned 49152", Category: EDECategoryOther}, true},
+		{"; EDNS: version: 0, flags: do; udp: 1232", ExtendedDNSError{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			ede, ok := parseExtendedDNSError(tc.line)
+			if ok != tc.ok || ede != tc.expected {
+				t.Errorf("parseExtendedDNSError(%q) = %+v, %v; expected %+v, %v", tc.line, ede, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MXRecord represents a single MX (Mail Exchange) record, which names a host that accepts mail
+// for the domain (RFC 5321).
+//
+// Fields:
+//
+//	Preference: An unsigned 16-bit integer; exchangers with lower values are tried first.
+//
+//	Exchange: The host name of the mail exchanger, lower-cased and without the trailing dot. It is
+//	          empty for a null MX record (RFC 7505), which states that the domain accepts no mail.
+//
+//	OriginalTTL: An unsigned 32-bit integer indicating the original time-to-live (TTL) value
+//	             of the MX record.
+type MXRecord struct {
+	Preference  uint16
+	Exchange    string
+	OriginalTTL uint32
+}
+
+// IsNullMX reports whether the record is a null MX record (RFC 7505).
+func (r *MXRecord) IsNullMX() bool {
+	return r.Preference == 0 && r.Exchange == ""
+}
+
+// String returns a formatted string representation of the MXRecord.
+func (r *MXRecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"MXRecord:\n"+
+			"  Preference: %d\n"+
+			"  Exchange: %s\n"+
+			"  Original TTL: %d seconds\n",
+		r.Preference,
+		r.Exchange,
+		r.OriginalTTL,
+	)
+}
+
+// MXResponse represents the complete response for an MX query.
+//
+// Fields:
+//
+//	Records: A slice of MXRecord structs. The slice is empty when the domain has no MX records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       MX record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type MXResponse struct {
+	Records     []MXRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Exchangers returns the host names of the mail exchangers, leaving out a null MX record.
+func (r *MXResponse) Exchangers() []string {
+	var exchangers []string
+	for _, record := range r.Records {
+		if !record.IsNullMX() {
+			exchangers = append(exchangers, record.Exchange)
+		}
+	}
+	return exchangers
+}
+
+// Parse parses the raw output of a 'delv' MX query and creates a new MXResponse struct.
+// A domain without MX records yields an empty response rather than an error.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*MXResponse: A pointer to an MXResponse struct with the parsed MX records, the
+//	             validation status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record could
+//	       not be parsed.
+func (r *MXResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = MXResponse{RawResponse: response}
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	mxRegex := regexp.MustCompile(`\bIN\s+MX\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+MX\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if mxRegex.MatchString(line) {
+			parts := strings.Fields(line)
+			if len(parts) < 6 {
+				return nil, fmt.Errorf("invalid MX r format: %s", line)
+			}
+
+			ttl, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL '%s' in MX r: %v", parts[1], err)
+			}
+
+			preference, err := strconv.ParseUint(parts[4], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid preference '%s' in MX r: %v", parts[4], err)
+			}
+
+			r.Records = append(r.Records, MXRecord{
+				Preference:  uint16(preference),
+				Exchange:    strings.ToLower(strings.TrimSuffix(parts[5], ".")),
+				OriginalTTL: uint32(ttl),
+			})
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// Compare checks the equality between two instances of MXRecord.
+//
+// Parameters:
+// - b: A reference to another instance of MXRecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *MXRecord) Compare(b *MXRecord) bool {
+	return r.Preference == b.Preference &&
+		r.Exchange == b.Exchange &&
+		r.OriginalTTL == b.OriginalTTL
+}
+
+// Compare checks the equality between two instances of MXResponse.
+//
+// Parameters:
+// - b: A reference to another instance of MXResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *MXResponse) Compare(b *MXResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
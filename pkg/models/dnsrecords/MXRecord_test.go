@@ -0,0 +1,97 @@
+package dnsrecords
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewMXResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      MX      10 Mail.example.com.
+example.com.            3600    IN      MX      20 backup.example.net.
+example.com.            3600    IN      RRSIG   MX 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &MXResponse{
+		Records: []MXRecord{
+			{Preference: 10, Exchange: "mail.example.com", OriginalTTL: 3600},
+			{Preference: 20, Exchange: "backup.example.net", OriginalTTL: 3600},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "MX",
+			Algorithm:   13,
+			Labels:      2,
+			OriginalTTL: 3600,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &MXResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse MX record: %v", err)
+	}
+	mxResponse, ok := result.(*MXResponse)
+	if !ok {
+		t.Fatalf("Result is not a *MXResponse")
+	}
+
+	if !mxResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", mxResponse, expected)
+	}
+	if !slices.Equal(mxResponse.Exchangers(), []string{"mail.example.com", "backup.example.net"}) {
+		t.Errorf("Unexpected exchangers %v", mxResponse.Exchangers())
+	}
+}
+
+func TestNewMXResponseNullMX(t *testing.T) {
+	response := `; unsigned answer
+example.com.            3600    IN      MX      0 .`
+	r := &MXResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse MX record: %v", err)
+	}
+	mxResponse := result.(*MXResponse)
+	if len(mxResponse.Records) != 1 || !mxResponse.Records[0].IsNullMX() || len(mxResponse.Exchangers()) != 0 {
+		t.Errorf("Expected a single null MX record, got %+v", mxResponse.Records)
+	}
+}
+
+func TestNewMXResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; example.com.                  3600    IN      \-MX    ;-$NXRRSET`
+	r := &MXResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty MX response, got error: %v", err)
+	}
+	if mxResponse := result.(*MXResponse); len(mxResponse.Records) != 0 || !mxResponse.Validated {
+		t.Errorf("Expected a validated, empty MX response, got %+v", mxResponse)
+	}
+}
+
+func TestNewMXResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+example.com.            3600    IN      MX      10 mail.example.com.
+example.com.            3600    IN      RRSIG   MX 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	noData := `;; resolution failed: ncache nxrrset
+; negative response, unsigned answer
+; example.org.                  3600    IN      \-MX    ;-$NXRRSET`
+	r := &MXResponse{}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse MX record: %v", err)
+	}
+	result, err := r.Parse(noData)
+	if err != nil {
+		t.Fatalf("Expected an empty MX response, got error: %v", err)
+	}
+	expected := &MXResponse{RawResponse: noData}
+	if mxResponse := result.(*MXResponse); !mxResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", mxResponse)
+	}
+}
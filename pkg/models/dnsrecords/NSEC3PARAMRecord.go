@@ -33,6 +33,9 @@ import (
 //
 //	RRSIG: A pointer to an RRSIGRecord struct containing the DNSSEC signature for the NSEC3PARAM record.
 //	       This field may be nil if DNSSEC is not used or if the record is not signed.
+//	       When the record carries several signatures, it holds the last one.
+//	RRSIGs: A slice with every RRSIG record covering the NSEC3PARAM record. The record is signed by more
+//	        than one key during key and algorithm rollovers.
 //	RawResponse: The raw text of the DNS response containing the NSEC3PARAM (NSEC3 Parameters) record.
 type NSEC3PARAMRecord struct {
 	TTL           uint32
@@ -42,6 +45,7 @@ type NSEC3PARAMRecord struct {
 	SaltLength    uint8
 	Validated     bool
 	RRSIG         *RRSIGRecord
+	RRSIGs        []RRSIGRecord
 	RawResponse   string
 }
 
@@ -142,6 +146,7 @@ func (r *NSEC3PARAMRecord) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 	return r, nil
@@ -164,6 +169,7 @@ func (r *NSEC3PARAMRecord) Compare(b *NSEC3PARAMRecord) bool {
 		r.SaltLength == b.SaltLength &&
 		r.Validated == b.Validated &&
 		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
 		r.RawResponse == b.RawResponse
 }
 
@@ -38,6 +38,7 @@ func TestNewNSEC3ParamRecordGoodResponse(t *testing.T) {
 		t.Fatalf("Result is not a *NSEC3PARAMRecord")
 	}
 
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
 	if !nsecRecord.Compare(expected) {
 		t.Errorf("Parsed record %+v does not match expected %+v", nsecRecord, expected)
 	}
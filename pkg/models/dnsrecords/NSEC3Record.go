@@ -0,0 +1,150 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NSEC3OptOutFlag is the bit of the NSEC3 Flags field that marks an opt-out span (RFC 5155, Section 3.1.2.1).
+const NSEC3OptOutFlag = 0x01
+
+// NSEC3Record represents a single NSEC3 (Hashed Next SECure) resource record in DNSSEC.
+// NSEC3 records provide authenticated denial of existence using hashed owner names,
+// and they are returned in the authority section of negative responses from NSEC3-signed zones.
+//
+// Fields:
+//
+//	OwnerName: The owner name of the record, made of the hashed owner label followed by the zone name.
+//
+//	HashAlgorithm: An unsigned 8-bit integer identifying the hash algorithm (1 = SHA-1).
+//
+//	Flags: An unsigned 8-bit integer containing the NSEC3 flags.
+//
+//	OptOut: A boolean flag indicating whether the opt-out flag is set, meaning the span may
+//	        cover unsigned delegations.
+//
+//	Iterations: An unsigned 16-bit integer representing the number of additional hash iterations.
+//
+//	Salt: The hexadecimal salt appended to the name before hashing; empty when no salt is used.
+//
+//	NextHashedOwner: The base32hex-encoded hash of the next owner name in hash order.
+//
+//	Types: A string listing the types of DNS resource records that exist at the original owner name.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct containing the signature covering this record.
+//	       This field may be nil if the signature was not present in the response.
+type NSEC3Record struct {
+	OwnerName       string
+	HashAlgorithm   uint8
+	Flags           uint8
+	OptOut          bool
+	Iterations      uint16
+	Salt            string
+	NextHashedOwner string
+	Types           string
+	RRSIG           *RRSIGRecord
+}
+
+// parseNSEC3Fields populates an NSEC3Record from the fields of a record line, as returned by recordFields.
+func parseNSEC3Fields(parts []string) (*NSEC3Record, error) {
+	if len(parts) < 9 {
+		return nil, fmt.Errorf("invalid NSEC3 r: %s", strings.Join(parts, " "))
+	}
+	record := &NSEC3Record{OwnerName: strings.TrimSuffix(parts[0], ".")}
+
+	hashAlgorithm, err := strconv.ParseUint(parts[4], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Hash Algorithm '%s' in NSEC3 r: %v", parts[4], err)
+	}
+	record.HashAlgorithm = uint8(hashAlgorithm)
+
+	flags, err := strconv.ParseUint(parts[5], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Flags '%s' in NSEC3 r: %v", parts[5], err)
+	}
+	record.Flags = uint8(flags)
+	record.OptOut = record.Flags&NSEC3OptOutFlag != 0
+
+	iterations, err := strconv.ParseUint(parts[6], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Iterations '%s' in NSEC3 r: %v", parts[6], err)
+	}
+	record.Iterations = uint16(iterations)
+
+	if parts[7] != "-" {
+		record.Salt = strings.ToUpper(parts[7])
+	}
+	record.NextHashedOwner = strings.ToUpper(parts[8])
+	record.Types = strings.Join(parts[9:], ";")
+
+	return record, nil
+}
+
+// HashedOwner returns the hashed label of the owner name in upper case, as used for hash order comparisons.
+func (r *NSEC3Record) HashedOwner() string {
+	label, _, _ := strings.Cut(r.OwnerName, ".")
+	return strings.ToUpper(label)
+}
+
+// Zone returns the name of the zone the record belongs to, that is, the owner name without the hashed label.
+func (r *NSEC3Record) Zone() string {
+	_, zone, _ := strings.Cut(r.OwnerName, ".")
+	return zone
+}
+
+// Compare checks the equality between two instances of NSEC3Record.
+// This function is useful for testing and validation purposes.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *NSEC3Record) Compare(b *NSEC3Record) bool {
+	return r.OwnerName == b.OwnerName &&
+		r.HashAlgorithm == b.HashAlgorithm &&
+		r.Flags == b.Flags &&
+		r.OptOut == b.OptOut &&
+		r.Iterations == b.Iterations &&
+		r.Salt == b.Salt &&
+		r.NextHashedOwner == b.NextHashedOwner &&
+		r.Types == b.Types &&
+		r.RRSIG.Compare(b.RRSIG)
+}
+
+// String returns a formatted string representation of the NSEC3Record.
+// This method provides a readable view of the NSEC3 record's hashing parameters and next hashed owner.
+func (r *NSEC3Record) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	rrsigStr := "<null>"
+	if r.RRSIG != nil {
+		rrsigStr = r.RRSIG.String()
+	}
+
+	return fmt.Sprintf(
+		"NSEC3Record:\n"+
+			"  Owner Name: %s\n"+
+			"  Hash Algorithm: %d\n"+
+			"  Flags: %d\n"+
+			"  Opt-Out: %t\n"+
+			"  Iterations: %d\n"+
+			"  Salt: %s\n"+
+			"  Next Hashed Owner: %s\n"+
+			"  Types: %s\n"+
+			"  RRSIG: %s\n",
+		r.OwnerName,
+		r.HashAlgorithm,
+		r.Flags,
+		r.OptOut,
+		r.Iterations,
+		r.Salt,
+		r.NextHashedOwner,
+		r.Types,
+		rrsigStr,
+	)
+}
@@ -29,6 +29,9 @@ import (
 //
 //	RRSIG: A pointer to an RRSIGRecord struct containing the DNSSEC signature for the NSEC record.
 //	       This field may be nil if DNSSEC is not used or if the record is not signed.
+//	       When the record carries several signatures, it holds the last one.
+//	RRSIGs: A slice with every RRSIG record covering the NSEC record. The record is signed by more
+//	        than one key during key and algorithm rollovers.
 //	RawResponse: The raw text of the DNS response containing the NSEC (Next SECure) record.
 type NSECRecord struct {
 	TTL            uint32
@@ -36,6 +39,7 @@ type NSECRecord struct {
 	Types          string
 	Validated      bool
 	RRSIG          *RRSIGRecord
+	RRSIGs         []RRSIGRecord
 	RawResponse    string
 }
 
@@ -112,6 +116,7 @@ func (r *NSECRecord) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 	return r, nil
@@ -131,6 +136,7 @@ func (r *NSECRecord) Compare(b *NSECRecord) bool {
 		r.Types == b.Types &&
 		r.Validated == b.Validated &&
 		r.RRSIG.Compare(b.RRSIG) &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
 		r.RawResponse == b.RawResponse
 }
 
@@ -35,6 +35,7 @@ func TestNewNSECRecordGoodResponse(t *testing.T) {
 		t.Fatalf("Result is not a *NSECRecord")
 	}
 
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
 	if !nsecRecord.Compare(expected) {
 		t.Errorf("Parsed record %+v does not match expected %+v", nsecRecord, expected)
 	}
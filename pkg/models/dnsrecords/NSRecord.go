@@ -0,0 +1,211 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NSRecord represents a single NS (Name Server) resource record in the Domain Name System (DNS).
+// An NS record delegates a zone to an authoritative name server.
+//
+// Fields:
+//
+//	NameServer: The host name of the authoritative name server, without the trailing dot.
+//
+//	OriginalTTL: An unsigned 32-bit integer indicating the original time-to-live (TTL) value
+//	             of the NS record.
+type NSRecord struct {
+	NameServer  string
+	OriginalTTL uint32
+}
+
+// String returns a formatted string representation of the NSRecord.
+// This method implements the fmt.Stringer interface for pretty-printing the record.
+func (r *NSRecord) String() string {
+	return fmt.Sprintf(
+		"NSRecord:\n"+
+			"  Name Server: %s\n"+
+			"  Original TTL: %d seconds\n",
+		r.NameServer,
+		r.OriginalTTL,
+	)
+}
+
+// NSResponse represents the complete response for an NS record query.
+// It includes the NS records of the zone apex as served by the child zone, the validation
+// status of the response, any associated RRSIG record, and the raw response.
+//
+// Fields:
+//
+//	Records: A slice of NSRecord structs, each representing an individual NS record.
+//
+//	Validated: A boolean flag indicating whether the NS records have been validated
+//	           using DNSSEC validation procedures. True if validated, false otherwise.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       NS record set. This field is nil if DNSSEC is not used or if the record is not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type NSResponse struct {
+	Records     []NSRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses a raw DNS response string and creates a new NSResponse struct.
+// This function is designed to work with the output of the 'delv' command-line tool
+// for NS queries.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//	          This response should be the result of an NS query for a specific domain.
+//
+// Return Value:
+//
+//	*NSResponse: A pointer to an NSResponse struct that contains the parsed NS records,
+//	             the validation status, the associated RRSIG record (if available), and the raw response.
+//
+//	error: An error object that indicates any issues encountered during the parsing of the
+//	       response string. If the parsing is successful, the error is nil. If parsing fails,
+//	       the error provides details about the cause of the failure.
+//
+// Note:
+//
+//	A name that exists but is not a zone apex has no NS records. Such a response is not
+//	treated as an error; an NSResponse without records is returned instead.
+//
+// Example Usage:
+//
+//	nsResponse, err := (&NSResponse{}).Parse(rawDelvResponse)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	// Use nsResponse.Records to check the delegation of the zone
+func (r *NSResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	r.RawResponse = response
+	if isNoDataResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	nsRegex := regexp.MustCompile(`\bIN\s+NS\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+NS\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if nsRegex.MatchString(line) {
+			nsRecord := &NSRecord{}
+			parts := strings.Fields(line)
+			if len(parts) < 5 {
+				return nil, fmt.Errorf("invalid NS r: %s", line)
+			}
+			ttl, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL '%s' in NS r: %v", parts[1], err)
+			}
+			nsRecord.OriginalTTL = uint32(ttl)
+
+			nsRecord.NameServer = strings.ToLower(strings.TrimSuffix(parts[4], "."))
+			r.Records = append(r.Records, *nsRecord)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+
+	return r, nil
+}
+
+// NameServers returns the host names of the name servers in the response.
+func (r *NSResponse) NameServers() []string {
+	var names []string
+	for _, record := range r.Records {
+		names = append(names, record.NameServer)
+	}
+	return names
+}
+
+// Compare checks the equality between two instances of NSRecord.
+// This function is useful for testing and validation purposes.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *NSRecord) Compare(b *NSRecord) bool {
+	return r.NameServer == b.NameServer
+}
+
+// Compare checks the equality between two instances of NSResponse.
+// This function is useful for testing and validation purposes.
+//
+// Parameters:
+// - b: A reference to another instance for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *NSResponse) Compare(b *NSResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
+
+// String returns a formatted string representation of the NSResponse.
+// This method implements the fmt.Stringer interface for pretty-printing the response.
+func (r *NSResponse) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	var recordsStr []string
+	for _, record := range r.Records {
+		recordsStr = append(recordsStr, record.String())
+	}
+
+	validatedStr := "No"
+	if r.Validated {
+		validatedStr = "Yes"
+	}
+
+	rrsigStr := "<null>"
+	if r.RRSIG != nil {
+		rrsigStr = r.RRSIG.String()
+	}
+
+	return fmt.Sprintf(
+		"NSResponse:\n"+
+			"  Records:\n    %s\n"+
+			"  Validated: %s\n"+
+			"  RRSIG: %s\n"+
+			"  Raw Response: %s\n",
+		strings.Join(recordsStr, "\n    "),
+		validatedStr,
+		rrsigStr,
+		r.RawResponse,
+	)
+}
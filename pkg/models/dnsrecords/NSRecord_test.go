@@ -0,0 +1,67 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewNSResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            86400   IN      NS      a.iana-servers.net.
+example.com.            86400   IN      NS      B.IANA-SERVERS.NET.
+example.com.            86400   IN      RRSIG   NS 13 2 86400 20240111000000 20231221000000 60485 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &NSResponse{
+		Records: []NSRecord{
+			{NameServer: "a.iana-servers.net", OriginalTTL: 86400},
+			{NameServer: "b.iana-servers.net", OriginalTTL: 86400},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "NS",
+			Algorithm:   13,
+			Labels:      2,
+			OriginalTTL: 86400,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      60485,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &NSResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse NS record: %v", err)
+	}
+	nsResponse, ok := result.(*NSResponse)
+	if !ok {
+		t.Fatalf("Result is not a *NSResponse")
+	}
+
+	if !nsResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", nsResponse, expected)
+	}
+}
+
+func TestNewNSResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; www.example.com.              3600    IN      \-NS    ;-$NXRRSET`
+	r := &NSResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty NS response, got error: %v", err)
+	}
+	if len(result.(*NSResponse).NameServers()) != 0 {
+		t.Errorf("Expected no name servers, got %+v", result)
+	}
+}
+
+func TestNewNSResponseFailed(t *testing.T) {
+	response := `;; resolution failed: SERVFAIL`
+	r := &NSResponse{}
+	_, err := r.Parse(response)
+	if err == nil {
+		t.Errorf("Expected an error for a failed resolution")
+	}
+}
@@ -161,6 +161,19 @@ func (r *RRSIGRecord) Compare(b *RRSIGRecord) bool {
 		r.Signature == b.Signature
 }
 
+// compareRRSIGs reports whether two slices hold equal RRSIG records in the same order.
+func compareRRSIGs(a, b []RRSIGRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Compare(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // String returns a formatted string representation of the RRSIGRecord.
 // This method implements the fmt.Stringer interface so that the RRSIGRecord
 // is printed in a human-readable form, rather than just displaying the pointer.
@@ -30,6 +30,9 @@ import (
 //	           validated, false otherwise.
 //	RRSIG: Pointer to an RRSIGRecord struct, which contains the DNSSEC signature for this SOA record.
 //	       This field is nil if DNSSEC is not used or if the record is not signed.
+//	       When the record carries several signatures, it holds the last one.
+//	RRSIGs: A slice with every RRSIG record covering the SOA record. The record is signed by more
+//	        than one key during key and algorithm rollovers.
 //	RawResponse: The raw text of the DNS response containing the SOA record.
 type SOARecord struct {
 	PrimaryNS   string
@@ -41,6 +44,7 @@ type SOARecord struct {
 	Minimum     uint32
 	Validated   bool
 	RRSIG       *RRSIGRecord
+	RRSIGs      []RRSIGRecord
 	RawResponse string
 }
 
@@ -146,6 +150,7 @@ func (r *SOARecord) Parse(response string) (DNSRecordResult, error) {
 				return nil, err
 			}
 			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+			r.RRSIGs = append(r.RRSIGs, *r.RRSIG)
 		}
 	}
 
@@ -174,6 +179,7 @@ func (r *SOARecord) Compare(b *SOARecord) bool {
 		r.Minimum == b.Minimum &&
 		r.Validated == b.Validated &&
 		rrsigEqual &&
+		compareRRSIGs(r.RRSIGs, b.RRSIGs) &&
 		r.RawResponse == b.RawResponse
 }
 
@@ -42,6 +42,7 @@ uminho.pt.              14400   IN      RRSIG   SOA 5 2 14400 20240114000002 202
 		t.Fatalf("Result is not a *SOARecord")
 	}
 
+	expected.RRSIGs = []RRSIGRecord{*expected.RRSIG}
 	if !soaRecord.Compare(expected) {
 		t.Errorf("Parsed record %+v does not match expected %+v", soaRecord, expected)
 	}
@@ -105,3 +106,22 @@ func TestNewSOARecordResolutionFailed(t *testing.T) {
 		t.Errorf("Expected error to contain 'resolution failed', got: %v", err)
 	}
 }
+
+func TestNewSOARecordSeveralSignatures(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      SOA     ns1.example.com. hostmaster.example.com. 2024010101 7200 3600 1209600 3600
+example.com.            3600    IN      RRSIG   SOA 8 2 3600 20240111000000 20231221000000 20326 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=
+example.com.            3600    IN      RRSIG   SOA 13 2 3600 20240111000000 20231221000000 38696 example.com. oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8 KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA==`
+	r := &SOARecord{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse SOA record: %v", err)
+	}
+	soaRecord := result.(*SOARecord)
+	if len(soaRecord.RRSIGs) != 2 || soaRecord.RRSIGs[0].KeyTag != 20326 || soaRecord.RRSIGs[1].KeyTag != 38696 {
+		t.Errorf("Expected both signatures of the SOA record, got %+v", soaRecord.RRSIGs)
+	}
+	if soaRecord.RRSIG == nil || soaRecord.RRSIG.KeyTag != 38696 {
+		t.Errorf("Expected RRSIG to hold the last signature, got %+v", soaRecord.RRSIG)
+	}
+}
@@ -0,0 +1,329 @@
+package dnsrecords
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SVCBRecord represents a single SVCB or HTTPS record (RFC 9460), which tells clients how to
+// reach a service: the protocols it speaks, its port, the addresses it can be reached at and the
+// configuration for Encrypted Client Hello. These parameters steer the connection before TLS
+// authenticates it, so their integrity depends on DNSSEC.
+//
+// Fields:
+//
+//	Priority: An unsigned 16-bit integer. Zero marks an alias mode record; other values order
+//	          the service mode records, lower values first.
+//
+//	Target: The target name, lower-cased and without the trailing dot. It is "." when the
+//	        service is provided by the owner name itself.
+//
+//	Mandatory: The keys of the parameters a client must understand to use the record.
+//
+//	ALPN: The application protocol identifiers supported by the service, such as "h2" or "h3".
+//
+//	NoDefaultALPN: A boolean flag indicating that the default protocol of the scheme ("http/1.1"
+//	               for HTTPS) is not supported.
+//
+//	Port: The TCP or UDP port of the service, zero when the default port applies.
+//
+//	IPv4Hint: The IPv4 addresses the service can be reached at.
+//
+//	IPv6Hint: The IPv6 addresses the service can be reached at.
+//
+//	ECH: The base64 encoded ECHConfigList used for Encrypted Client Hello, empty when absent.
+//
+//	OtherParams: The parameters without a dedicated field, keyed by their name as printed,
+//	             such as "dohpath" or "key65000".
+type SVCBRecord struct {
+	Priority      uint16
+	Target        string
+	Mandatory     []string
+	ALPN          []string
+	NoDefaultALPN bool
+	Port          uint16
+	IPv4Hint      []string
+	IPv6Hint      []string
+	ECH           string
+	OtherParams   map[string]string
+}
+
+// IsAliasMode reports whether the record is an alias mode record, which points to another name
+// for the service instead of describing it.
+func (r *SVCBRecord) IsAliasMode() bool {
+	return r.Priority == 0
+}
+
+// String returns a formatted string representation of the SVCBRecord.
+func (r *SVCBRecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"SVCBRecord:\n"+
+			"  Priority: %d\n"+
+			"  Target: %s\n"+
+			"  Mandatory: %s\n"+
+			"  ALPN: %s\n"+
+			"  No Default ALPN: %t\n"+
+			"  Port: %d\n"+
+			"  IPv4 Hint: %s\n"+
+			"  IPv6 Hint: %s\n"+
+			"  ECH: %s\n"+
+			"  Other Params: %v\n",
+		r.Priority,
+		r.Target,
+		strings.Join(r.Mandatory, ","),
+		strings.Join(r.ALPN, ","),
+		r.NoDefaultALPN,
+		r.Port,
+		strings.Join(r.IPv4Hint, ","),
+		strings.Join(r.IPv6Hint, ","),
+		r.ECH,
+		r.OtherParams,
+	)
+}
+
+// SVCBResponse represents the complete response for an SVCB or HTTPS query.
+//
+// Fields:
+//
+//	RecordType: The queried type, "SVCB" or "HTTPS". It defaults to "SVCB" when empty.
+//
+//	Records: A slice of SVCBRecord structs. The slice is empty when the name holds no records
+//	         of the queried type.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type SVCBResponse struct {
+	RecordType  string
+	Records     []SVCBRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses the raw output of a 'delv' SVCB or HTTPS query and creates a new SVCBResponse
+// struct. A name without such records yields an empty response rather than an error, since most
+// hosts publish none.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*SVCBResponse: A pointer to an SVCBResponse struct with the parsed records, the validation
+//	               status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record or one
+//	       of its parameters could not be parsed.
+func (r *SVCBResponse) Parse(response string) (DNSRecordResult, error) {
+	recordType := r.RecordType
+	if recordType == "" {
+		recordType = "SVCB"
+	}
+	lines := strings.Split(response, "\n")
+	*r = SVCBResponse{RecordType: recordType, RawResponse: response}
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	recordRegex := regexp.MustCompile(`\bIN\s+` + r.RecordType + `\s+(.*)$`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+` + r.RecordType + `\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if match := recordRegex.FindStringSubmatch(line); match != nil && !strings.HasPrefix(strings.TrimSpace(line), ";") {
+			record, err := parseSVCBData(match[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s r: %s: %v", r.RecordType, line, err)
+			}
+			r.Records = append(r.Records, *record)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// parseSVCBData parses the data of an SVCB or HTTPS record in presentation format, such as
+// `1 . alpn="h3,h2" ipv4hint=192.0.2.1`.
+func parseSVCBData(data string) (*SVCBRecord, error) {
+	fields := svcbFields(data)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("missing priority or target")
+	}
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority '%s': %v", fields[0], err)
+	}
+	record := &SVCBRecord{Priority: uint16(priority), Target: strings.ToLower(fields[1])}
+	if record.Target != "." {
+		record.Target = strings.TrimSuffix(record.Target, ".")
+	}
+	for _, param := range fields[2:] {
+		key, value, _ := strings.Cut(param, "=")
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(key) {
+		case "mandatory":
+			record.Mandatory = svcbList(value)
+		case "alpn":
+			record.ALPN = svcbList(value)
+		case "no-default-alpn":
+			record.NoDefaultALPN = true
+		case "port":
+			port, err := strconv.ParseUint(value, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port '%s': %v", value, err)
+			}
+			record.Port = uint16(port)
+		case "ipv4hint":
+			for _, address := range svcbList(value) {
+				if ip := net.ParseIP(address); ip == nil || ip.To4() == nil {
+					return nil, fmt.Errorf("invalid ipv4hint '%s'", address)
+				}
+				record.IPv4Hint = append(record.IPv4Hint, address)
+			}
+		case "ipv6hint":
+			for _, address := range svcbList(value) {
+				if ip := net.ParseIP(address); ip == nil || ip.To4() != nil {
+					return nil, fmt.Errorf("invalid ipv6hint '%s'", address)
+				}
+				record.IPv6Hint = append(record.IPv6Hint, address)
+			}
+		case "ech":
+			if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+				return nil, fmt.Errorf("invalid ech '%s': %v", value, err)
+			}
+			record.ECH = value
+		default:
+			if record.OtherParams == nil {
+				record.OtherParams = make(map[string]string)
+			}
+			record.OtherParams[strings.ToLower(key)] = value
+		}
+	}
+	return record, nil
+}
+
+// svcbFields splits record data on white space outside double quotes.
+func svcbFields(data string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '\\' && i+1 < len(data):
+			field.WriteByte(c)
+			field.WriteByte(data[i+1])
+			i++
+		case c == '"':
+			quoted = !quoted
+			field.WriteByte(c)
+		case !quoted && (c == ' ' || c == '\t'):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// svcbList splits a comma-separated parameter value, keeping commas escaped with a backslash.
+func svcbList(value string) []string {
+	var items []string
+	var item strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			item.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			items = append(items, item.String())
+			item.Reset()
+		default:
+			item.WriteByte(value[i])
+		}
+	}
+	return append(items, item.String())
+}
+
+// Compare checks the equality between two instances of SVCBRecord.
+//
+// Parameters:
+// - b: A reference to another instance of SVCBRecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *SVCBRecord) Compare(b *SVCBRecord) bool {
+	if len(r.OtherParams) != len(b.OtherParams) {
+		return false
+	}
+	for key, value := range r.OtherParams {
+		if other, ok := b.OtherParams[key]; !ok || other != value {
+			return false
+		}
+	}
+	return r.Priority == b.Priority &&
+		r.Target == b.Target &&
+		strings.Join(r.Mandatory, ",") == strings.Join(b.Mandatory, ",") &&
+		strings.Join(r.ALPN, ",") == strings.Join(b.ALPN, ",") &&
+		r.NoDefaultALPN == b.NoDefaultALPN &&
+		r.Port == b.Port &&
+		strings.Join(r.IPv4Hint, ",") == strings.Join(b.IPv4Hint, ",") &&
+		strings.Join(r.IPv6Hint, ",") == strings.Join(b.IPv6Hint, ",") &&
+		r.ECH == b.ECH
+}
+
+// Compare checks the equality between two instances of SVCBResponse.
+//
+// Parameters:
+// - b: A reference to another instance of SVCBResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *SVCBResponse) Compare(b *SVCBResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.RecordType == b.RecordType &&
+		r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
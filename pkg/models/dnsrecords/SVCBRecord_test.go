@@ -0,0 +1,112 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewHTTPSResponseOK(t *testing.T) {
+	response := `; fully validated
+www.example.com.        300     IN      HTTPS   1 . alpn="h3,h2" ipv4hint=192.0.2.1,192.0.2.2 ech=AEX+DQBBpQAgACBNhA== ipv6hint=2001:db8::1
+www.example.com.        300     IN      HTTPS   2 Svc.Example.net. port=8443 no-default-alpn alpn=h2 mandatory=alpn,port key65000="x y"
+www.example.com.        300     IN      RRSIG   HTTPS 13 3 300 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &SVCBResponse{
+		RecordType: "HTTPS",
+		Records: []SVCBRecord{
+			{Priority: 1, Target: ".", ALPN: []string{"h3", "h2"}, IPv4Hint: []string{"192.0.2.1", "192.0.2.2"},
+				ECH: "AEX+DQBBpQAgACBNhA==", IPv6Hint: []string{"2001:db8::1"}},
+			{Priority: 2, Target: "svc.example.net", Port: 8443, NoDefaultALPN: true, ALPN: []string{"h2"},
+				Mandatory: []string{"alpn", "port"}, OtherParams: map[string]string{"key65000": "x y"}},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "HTTPS",
+			Algorithm:   13,
+			Labels:      3,
+			OriginalTTL: 300,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &SVCBResponse{RecordType: "HTTPS"}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse HTTPS record: %v", err)
+	}
+	httpsResponse, ok := result.(*SVCBResponse)
+	if !ok {
+		t.Fatalf("Result is not a *SVCBResponse")
+	}
+
+	if !httpsResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", httpsResponse, expected)
+	}
+}
+
+func TestNewHTTPSResponseAliasMode(t *testing.T) {
+	response := `; unsigned answer
+example.com.            300     IN      HTTPS   0 pool.example.net.`
+	r := &SVCBResponse{RecordType: "HTTPS"}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse HTTPS record: %v", err)
+	}
+	httpsResponse := result.(*SVCBResponse)
+	if len(httpsResponse.Records) != 1 || !httpsResponse.Records[0].IsAliasMode() || httpsResponse.Records[0].Target != "pool.example.net" {
+		t.Errorf("Expected an alias mode record, got %+v", httpsResponse.Records)
+	}
+	if httpsResponse.Validated {
+		t.Errorf("Expected an unvalidated answer")
+	}
+}
+
+func TestNewSVCBResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; _dns.example.com.             300     IN      \-SVCB  ;-$NXRRSET`
+	r := &SVCBResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty SVCB response, got error: %v", err)
+	}
+	if svcbResponse := result.(*SVCBResponse); len(svcbResponse.Records) != 0 || !svcbResponse.Validated || svcbResponse.RecordType != "SVCB" {
+		t.Errorf("Expected a validated, empty SVCB response, got %+v", svcbResponse)
+	}
+}
+
+func TestNewHTTPSResponseInvalidParams(t *testing.T) {
+	for _, params := range []string{"port=http", "ipv4hint=2001:db8::1", "ipv6hint=192.0.2.1", "ech=!!!"} {
+		response := `www.example.com.        300     IN      HTTPS   1 . ` + params
+		r := &SVCBResponse{RecordType: "HTTPS"}
+		if _, err := r.Parse(response); err == nil {
+			t.Errorf("Expected an error for %s", params)
+		}
+	}
+}
+
+func TestNewHTTPSResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+www.example.com.        300     IN      HTTPS   1 . alpn="h3,h2" ech=AEX+DQBBpQAgACBNhA==
+www.example.com.        300     IN      RRSIG   HTTPS 13 3 300 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	unsigned := `; unsigned answer
+www.example.org.        300     IN      HTTPS   1 . alpn=h2`
+	r := &SVCBResponse{RecordType: "HTTPS"}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse HTTPS record: %v", err)
+	}
+	result, err := r.Parse(unsigned)
+	if err != nil {
+		t.Fatalf("Failed to parse HTTPS record: %v", err)
+	}
+	expected := &SVCBResponse{
+		RecordType:  "HTTPS",
+		Records:     []SVCBRecord{{Priority: 1, Target: ".", ALPN: []string{"h2"}}},
+		RawResponse: unsigned,
+	}
+	if httpsResponse := result.(*SVCBResponse); !httpsResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", httpsResponse)
+	}
+}
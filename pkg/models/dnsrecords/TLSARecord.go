@@ -0,0 +1,242 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TLSA certificate usages (RFC 6698, Section 2.1.1 and RFC 7218).
+const (
+	TLSAUsagePKIXTA = 0
+	TLSAUsagePKIXEE = 1
+	TLSAUsageDANETA = 2
+	TLSAUsageDANEEE = 3
+)
+
+// TLSA selectors (RFC 6698, Section 2.1.2 and RFC 7218).
+const (
+	TLSASelectorCert = 0
+	TLSASelectorSPKI = 1
+)
+
+// TLSA matching types (RFC 6698, Section 2.1.3 and RFC 7218).
+const (
+	TLSAMatchingFull   = 0
+	TLSAMatchingSHA256 = 1
+	TLSAMatchingSHA512 = 2
+)
+
+// TLSARecord represents a single TLSA record, which associates a TLS server certificate or
+// public key with the domain name where the record is found (RFC 6698). TLSA records are only
+// meaningful when they are validated with DNSSEC, which makes them the main use of DNSSEC
+// beyond the protection of address records (DANE).
+//
+// Fields:
+//
+//	Usage: The certificate usage: 0 (PKIX-TA), 1 (PKIX-EE), 2 (DANE-TA) or 3 (DANE-EE).
+//
+//	Selector: The part of the certificate matched: 0 (full certificate) or 1 (SubjectPublicKeyInfo).
+//
+//	MatchingType: How the data is presented: 0 (exact match), 1 (SHA-256 hash) or 2 (SHA-512 hash).
+//
+//	CertificateData: The upper-case hexadecimal certificate association data.
+type TLSARecord struct {
+	Usage           uint8
+	Selector        uint8
+	MatchingType    uint8
+	CertificateData string
+}
+
+// UsageName returns the mnemonic of the certificate usage defined in RFC 7218, or "unknown".
+func (r *TLSARecord) UsageName() string {
+	switch r.Usage {
+	case TLSAUsagePKIXTA:
+		return "PKIX-TA"
+	case TLSAUsagePKIXEE:
+		return "PKIX-EE"
+	case TLSAUsageDANETA:
+		return "DANE-TA"
+	case TLSAUsageDANEEE:
+		return "DANE-EE"
+	default:
+		return "unknown"
+	}
+}
+
+// SelectorName returns the mnemonic of the selector defined in RFC 7218, or "unknown".
+func (r *TLSARecord) SelectorName() string {
+	switch r.Selector {
+	case TLSASelectorCert:
+		return "Cert"
+	case TLSASelectorSPKI:
+		return "SPKI"
+	default:
+		return "unknown"
+	}
+}
+
+// MatchingTypeName returns the mnemonic of the matching type defined in RFC 7218, or "unknown".
+func (r *TLSARecord) MatchingTypeName() string {
+	switch r.MatchingType {
+	case TLSAMatchingFull:
+		return "Full"
+	case TLSAMatchingSHA256:
+		return "SHA2-256"
+	case TLSAMatchingSHA512:
+		return "SHA2-512"
+	default:
+		return "unknown"
+	}
+}
+
+// String returns a formatted string representation of the TLSARecord.
+func (r *TLSARecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"TLSARecord:\n"+
+			"  Usage: %d (%s)\n"+
+			"  Selector: %d (%s)\n"+
+			"  Matching Type: %d (%s)\n"+
+			"  Certificate Data: %s\n",
+		r.Usage, r.UsageName(),
+		r.Selector, r.SelectorName(),
+		r.MatchingType, r.MatchingTypeName(),
+		r.CertificateData,
+	)
+}
+
+// TLSAResponse represents the complete response for a TLSA query, such as one for
+// "_443._tcp.www.example.com".
+//
+// Fields:
+//
+//	Records: A slice of TLSARecord structs. The slice is empty when the name holds no TLSA records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       TLSA record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type TLSAResponse struct {
+	Records     []TLSARecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses the raw output of a 'delv' TLSA query and creates a new TLSAResponse struct.
+// A name without TLSA records, whether it does not exist or holds other types only, yields an
+// empty response rather than an error, since most services publish no TLSA records.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*TLSAResponse: A pointer to a TLSAResponse struct with the parsed TLSA records, the
+//	               validation status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record could
+//	       not be parsed.
+func (r *TLSAResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	r.RawResponse = response
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	tlsaRegex := regexp.MustCompile(`\bIN\s+TLSA\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+TLSA\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if tlsaRegex.MatchString(line) {
+			tlsaRecord := &TLSARecord{}
+			parts := strings.Fields(line)
+			if len(parts) < 8 {
+				return nil, fmt.Errorf("invalid TLSA r format: %s", line)
+			}
+
+			usage, err := strconv.ParseUint(parts[4], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid usage '%s' in TLSA r: %v", parts[4], err)
+			}
+			tlsaRecord.Usage = uint8(usage)
+
+			selector, err := strconv.ParseUint(parts[5], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector '%s' in TLSA r: %v", parts[5], err)
+			}
+			tlsaRecord.Selector = uint8(selector)
+
+			matchingType, err := strconv.ParseUint(parts[6], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid matching type '%s' in TLSA r: %v", parts[6], err)
+			}
+			tlsaRecord.MatchingType = uint8(matchingType)
+
+			tlsaRecord.CertificateData = strings.ToUpper(strings.Join(parts[7:], ""))
+
+			r.Records = append(r.Records, *tlsaRecord)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// Compare checks the equality between two instances of TLSARecord.
+//
+// Parameters:
+// - b: A reference to another instance of TLSARecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *TLSARecord) Compare(b *TLSARecord) bool {
+	return r.Usage == b.Usage &&
+		r.Selector == b.Selector &&
+		r.MatchingType == b.MatchingType &&
+		r.CertificateData == b.CertificateData
+}
+
+// Compare checks the equality between two instances of TLSAResponse.
+//
+// Parameters:
+// - b: A reference to another instance of TLSAResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *TLSAResponse) Compare(b *TLSAResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
@@ -0,0 +1,82 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewTLSAResponseOK(t *testing.T) {
+	response := `; fully validated
+_443._tcp.example.com.  3600    IN      TLSA    3 1 1 8BD1DA95272F7FA4FFB24137FC0ED03AEE67E5C4D8B3C50734E2 CB7C3B2C5B6B
+_443._tcp.example.com.  3600    IN      RRSIG   TLSA 13 4 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &TLSAResponse{
+		Records: []TLSARecord{
+			{Usage: 3, Selector: 1, MatchingType: 1, CertificateData: "8BD1DA95272F7FA4FFB24137FC0ED03AEE67E5C4D8B3C50734E2CB7C3B2C5B6B"},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "TLSA",
+			Algorithm:   13,
+			Labels:      4,
+			OriginalTTL: 3600,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &TLSAResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse TLSA record: %v", err)
+	}
+	tlsaResponse, ok := result.(*TLSAResponse)
+	if !ok {
+		t.Fatalf("Result is not a *TLSAResponse")
+	}
+
+	if !tlsaResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", tlsaResponse, expected)
+	}
+	record := tlsaResponse.Records[0]
+	if record.UsageName() != "DANE-EE" || record.SelectorName() != "SPKI" || record.MatchingTypeName() != "SHA2-256" {
+		t.Errorf("Unexpected mnemonics %s %s %s", record.UsageName(), record.SelectorName(), record.MatchingTypeName())
+	}
+}
+
+func TestNewTLSAResponseNoData(t *testing.T) {
+	for _, response := range []string{
+		`;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; _443._tcp.example.com.        3600    IN      \-TLSA  ;-$NXRRSET`,
+		`;; resolution failed: ncache nxdomain
+; negative response, unsigned answer
+; _443._tcp.example.com.        3600    IN      \-ANY   ;-$NXDOMAIN`,
+	} {
+		r := &TLSAResponse{}
+		result, err := r.Parse(response)
+		if err != nil {
+			t.Fatalf("Expected an empty TLSA response, got error: %v", err)
+		}
+		if tlsaResponse := result.(*TLSAResponse); len(tlsaResponse.Records) != 0 {
+			t.Errorf("Expected an empty TLSA response, got %+v", tlsaResponse)
+		}
+	}
+}
+
+func TestNewTLSAResponseFailure(t *testing.T) {
+	response := `;; resolution failed: SERVFAIL`
+	r := &TLSAResponse{}
+	if _, err := r.Parse(response); err == nil {
+		t.Errorf("Expected an error for a failed resolution")
+	}
+}
+
+func TestNewTLSAResponseInvalidUsage(t *testing.T) {
+	response := `_443._tcp.example.com.  3600    IN      TLSA    x 1 1 8BD1DA95`
+	r := &TLSAResponse{}
+	if _, err := r.Parse(response); err == nil {
+		t.Errorf("Expected an error for an invalid usage")
+	}
+}
@@ -0,0 +1,186 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TXTRecord represents a single TXT record. Policies published in TXT records, such as SPF and
+// DMARC, are read from the concatenation of the character strings of the record (RFC 7208,
+// Section 3.3).
+//
+// Fields:
+//
+//	Text: The character strings of the record, unescaped and concatenated.
+//
+//	OriginalTTL: An unsigned 32-bit integer indicating the original time-to-live (TTL) value
+//	             of the TXT record.
+type TXTRecord struct {
+	Text        string
+	OriginalTTL uint32
+}
+
+// String returns a formatted string representation of the TXTRecord.
+func (r *TXTRecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"TXTRecord:\n"+
+			"  Text: %s\n"+
+			"  Original TTL: %d seconds\n",
+		r.Text,
+		r.OriginalTTL,
+	)
+}
+
+// TXTResponse represents the complete response for a TXT query.
+//
+// Fields:
+//
+//	Records: A slice of TXTRecord structs. The slice is empty when the name holds no TXT records.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       TXT record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type TXTResponse struct {
+	Records     []TXTRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses the raw output of a 'delv' TXT query and creates a new TXTResponse struct.
+// A name without TXT records, whether it does not exist or holds other types only, yields an
+// empty response rather than an error, since policy names such as "_dmarc" are probed.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*TXTResponse: A pointer to a TXTResponse struct with the parsed TXT records, the
+//	              validation status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record could
+//	       not be parsed.
+func (r *TXTResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = TXTResponse{RawResponse: response}
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	txtRegex := regexp.MustCompile(`^(\S+)\s+(\d+)\s+IN\s+TXT\s+(.*)$`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+TXT\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if match := txtRegex.FindStringSubmatch(line); match != nil {
+			ttl, err := strconv.ParseUint(match[2], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL '%s' in TXT r: %v", match[2], err)
+			}
+			text, err := characterStrings(match[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid TXT r format: %s: %v", line, err)
+			}
+			r.Records = append(r.Records, TXTRecord{Text: text, OriginalTTL: uint32(ttl)})
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// characterStrings unescapes and concatenates the character strings of TXT record data in
+// presentation format, such as `"v=spf1 " "-all"`. Backslash escapes are either a single
+// character or three decimal digits.
+func characterStrings(data string) (string, error) {
+	var text strings.Builder
+	quoted := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\':
+			if i+3 < len(data) && isDigit(data[i+1]) && isDigit(data[i+2]) && isDigit(data[i+3]) {
+				value, _ := strconv.Atoi(data[i+1 : i+4])
+				if value > 255 {
+					return "", fmt.Errorf("invalid escape '\\%s'", data[i+1:i+4])
+				}
+				text.WriteByte(byte(value))
+				i += 3
+			} else if i+1 < len(data) {
+				text.WriteByte(data[i+1])
+				i++
+			}
+		case !quoted && (c == ' ' || c == '\t'):
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if quoted {
+		return "", fmt.Errorf("unterminated character string")
+	}
+	return text.String(), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Compare checks the equality between two instances of TXTRecord.
+//
+// Parameters:
+// - b: A reference to another instance of TXTRecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *TXTRecord) Compare(b *TXTRecord) bool {
+	return r.Text == b.Text &&
+		r.OriginalTTL == b.OriginalTTL
+}
+
+// Compare checks the equality between two instances of TXTResponse.
+//
+// Parameters:
+// - b: A reference to another instance of TXTResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *TXTResponse) Compare(b *TXTResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
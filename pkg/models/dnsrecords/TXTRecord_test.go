@@ -0,0 +1,89 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewTXTResponseOK(t *testing.T) {
+	response := `; fully validated
+example.com.            3600    IN      TXT     "v=spf1 ip4:192.0.2.0/24 " "include:_spf.example.net -all"
+example.com.            3600    IN      TXT     "google-site-verification=abc\"def\059"
+example.com.            3600    IN      RRSIG   TXT 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &TXTResponse{
+		Records: []TXTRecord{
+			{Text: "v=spf1 ip4:192.0.2.0/24 include:_spf.example.net -all", OriginalTTL: 3600},
+			{Text: `google-site-verification=abc"def;`, OriginalTTL: 3600},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "TXT",
+			Algorithm:   13,
+			Labels:      2,
+			OriginalTTL: 3600,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example.com",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &TXTResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse TXT record: %v", err)
+	}
+	txtResponse, ok := result.(*TXTResponse)
+	if !ok {
+		t.Fatalf("Result is not a *TXTResponse")
+	}
+
+	if !txtResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", txtResponse, expected)
+	}
+}
+
+func TestNewTXTResponseNXDomain(t *testing.T) {
+	response := `;; resolution failed: ncache nxdomain
+; negative response, fully validated
+; _dmarc.example.com.           3600    IN      \-ANY   ;-$NXDOMAIN`
+	r := &TXTResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty TXT response, got error: %v", err)
+	}
+	if txtResponse := result.(*TXTResponse); len(txtResponse.Records) != 0 || !txtResponse.Validated {
+		t.Errorf("Expected a validated, empty TXT response, got %+v", txtResponse)
+	}
+}
+
+func TestNewTXTResponseUnterminated(t *testing.T) {
+	response := `example.com.            3600    IN      TXT     "v=spf1 -all`
+	r := &TXTResponse{}
+	if _, err := r.Parse(response); err == nil {
+		t.Errorf("Expected an error for an unterminated character string")
+	}
+}
+
+func TestNewTXTResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+example.com.            3600    IN      TXT     "v=spf1 -all"
+example.com.            3600    IN      RRSIG   TXT 13 2 3600 20240111000000 20231221000000 2371 example.com. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	unsigned := `; unsigned answer
+_dmarc.example.org.     300     IN      TXT     "v=DMARC1; p=reject"`
+	r := &TXTResponse{}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse TXT record: %v", err)
+	}
+	result, err := r.Parse(unsigned)
+	if err != nil {
+		t.Fatalf("Failed to parse TXT record: %v", err)
+	}
+	expected := &TXTResponse{
+		Records:     []TXTRecord{{Text: "v=DMARC1; p=reject", OriginalTTL: 300}},
+		RawResponse: unsigned,
+	}
+	if txtResponse := result.(*TXTResponse); !txtResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", txtResponse)
+	}
+}
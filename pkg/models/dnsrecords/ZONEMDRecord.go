@@ -0,0 +1,204 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ZONEMD schemes and hash algorithms (RFC 8976, Sections 5.2 and 5.3).
+const (
+	ZONEMDSchemeSimple = 1
+	ZONEMDHashSHA384   = 1
+	ZONEMDHashSHA512   = 2
+)
+
+// ZONEMDRecord represents a single ZONEMD record, which publishes a message digest of the whole
+// zone at its apex (RFC 8976). Recipients of the zone, such as secondary servers or local copies
+// of the root zone, can verify its integrity with it; under DNSSEC the digest itself is signed.
+//
+// Fields:
+//
+//	Serial: The serial number of the SOA record of the zone version the digest was computed for.
+//
+//	Scheme: The method used to collect the zone data for the digest: 1 (SIMPLE).
+//
+//	HashAlgorithm: The hash algorithm: 1 (SHA-384) or 2 (SHA-512).
+//
+//	Digest: The upper-case hexadecimal digest of the zone.
+type ZONEMDRecord struct {
+	Serial        uint32
+	Scheme        uint8
+	HashAlgorithm uint8
+	Digest        string
+}
+
+// SchemeName returns the mnemonic of the scheme defined in RFC 8976, or "unknown".
+func (r *ZONEMDRecord) SchemeName() string {
+	if r.Scheme == ZONEMDSchemeSimple {
+		return "SIMPLE"
+	}
+	return "unknown"
+}
+
+// HashAlgorithmName returns the mnemonic of the hash algorithm defined in RFC 8976, or "unknown".
+func (r *ZONEMDRecord) HashAlgorithmName() string {
+	switch r.HashAlgorithm {
+	case ZONEMDHashSHA384:
+		return "SHA384"
+	case ZONEMDHashSHA512:
+		return "SHA512"
+	default:
+		return "unknown"
+	}
+}
+
+// String returns a formatted string representation of the ZONEMDRecord.
+func (r *ZONEMDRecord) String() string {
+	if r == nil {
+		return "<null>"
+	}
+
+	return fmt.Sprintf(
+		"ZONEMDRecord:\n"+
+			"  Serial: %d\n"+
+			"  Scheme: %d (%s)\n"+
+			"  Hash Algorithm: %d (%s)\n"+
+			"  Digest: %s\n",
+		r.Serial,
+		r.Scheme, r.SchemeName(),
+		r.HashAlgorithm, r.HashAlgorithmName(),
+		r.Digest,
+	)
+}
+
+// ZONEMDResponse represents the complete response for a ZONEMD query at a zone apex.
+//
+// Fields:
+//
+//	Records: A slice of ZONEMDRecord structs. The slice is empty when the zone publishes no digest.
+//
+//	Validated: A boolean flag indicating whether the answer, positive or negative, has been
+//	           validated using DNSSEC validation procedures.
+//
+//	RRSIG: A pointer to an RRSIGRecord struct that contains the DNSSEC signature for this
+//	       ZONEMD record set. This field is nil if the record set is absent or not signed.
+//
+//	RawResponse: A string containing the raw textual response received from the DNS server.
+type ZONEMDResponse struct {
+	Records     []ZONEMDRecord
+	Validated   bool
+	RRSIG       *RRSIGRecord
+	RawResponse string
+}
+
+// Parse parses the raw output of a 'delv' ZONEMD query and creates a new ZONEMDResponse struct.
+// A zone without ZONEMD records yields an empty response rather than an error, since few zones
+// publish a digest.
+//
+// Parameters:
+//
+//	response: A string containing the raw textual response from the 'delv' command-line tool.
+//
+// Return Value:
+//
+//	*ZONEMDResponse: A pointer to a ZONEMDResponse struct with the parsed ZONEMD records, the
+//	                 validation status, the associated RRSIG record (if available) and the raw response.
+//
+//	error: An error object that indicates that the resolution failed or that a record could
+//	       not be parsed.
+func (r *ZONEMDResponse) Parse(response string) (DNSRecordResult, error) {
+	lines := strings.Split(response, "\n")
+	*r = ZONEMDResponse{RawResponse: response}
+	if isNegativeResponse(response) {
+		r.Validated = isValidatedResponse(response)
+		return r, nil
+	}
+	if strings.Contains(response, "resolution failed") {
+		return nil, fmt.Errorf("resolution failed: %s", lines[0])
+	}
+	zonemdRegex := regexp.MustCompile(`\bIN\s+ZONEMD\b`)
+	rrsigRegex := regexp.MustCompile(`\bRRSIG\s+ZONEMD\b`)
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "; fully validated") {
+			r.Validated = true
+		} else if strings.HasPrefix(line, "; unsigned answer") {
+			r.Validated = false
+		} else if zonemdRegex.MatchString(line) {
+			zonemdRecord := &ZONEMDRecord{}
+			parts := strings.Fields(line)
+			if len(parts) < 8 {
+				return nil, fmt.Errorf("invalid ZONEMD r format: %s", line)
+			}
+
+			serial, err := strconv.ParseUint(parts[4], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid serial '%s' in ZONEMD r: %v", parts[4], err)
+			}
+			zonemdRecord.Serial = uint32(serial)
+
+			scheme, err := strconv.ParseUint(parts[5], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid scheme '%s' in ZONEMD r: %v", parts[5], err)
+			}
+			zonemdRecord.Scheme = uint8(scheme)
+
+			hashAlgorithm, err := strconv.ParseUint(parts[6], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hash algorithm '%s' in ZONEMD r: %v", parts[6], err)
+			}
+			zonemdRecord.HashAlgorithm = uint8(hashAlgorithm)
+
+			zonemdRecord.Digest = strings.ToUpper(strings.Join(parts[7:], ""))
+
+			r.Records = append(r.Records, *zonemdRecord)
+		} else if rrsigRegex.MatchString(line) {
+			rrsigParser := &RRSIGRecord{}
+			rrsigRecord, err := rrsigParser.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			r.RRSIG = rrsigRecord.(*RRSIGRecord)
+		}
+	}
+	return r, nil
+}
+
+// Compare checks the equality between two instances of ZONEMDRecord.
+//
+// Parameters:
+// - b: A reference to another instance of ZONEMDRecord for comparison.
+//
+// Returns:
+//   - bool: Returns true if all properties of 'a' and 'b' are equal;
+//     otherwise, returns false.
+func (r *ZONEMDRecord) Compare(b *ZONEMDRecord) bool {
+	return r.Serial == b.Serial &&
+		r.Scheme == b.Scheme &&
+		r.HashAlgorithm == b.HashAlgorithm &&
+		r.Digest == b.Digest
+}
+
+// Compare checks the equality between two instances of ZONEMDResponse.
+//
+// Parameters:
+// - b: A reference to another instance of ZONEMDResponse for comparison.
+//
+// Returns:
+//   - bool: Returns true if the corresponding properties of 'a' and 'b' are equal,
+//     otherwise, returns false.
+func (r *ZONEMDResponse) Compare(b *ZONEMDResponse) bool {
+	if len(r.Records) != len(b.Records) {
+		return false
+	}
+	for i := range r.Records {
+		if !r.Records[i].Compare(&b.Records[i]) {
+			return false
+		}
+	}
+	return r.Validated == b.Validated &&
+		r.RRSIG.Compare(b.RRSIG) &&
+		r.RawResponse == b.RawResponse
+}
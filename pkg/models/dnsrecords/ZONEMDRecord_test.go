@@ -0,0 +1,90 @@
+package dnsrecords
+
+import (
+	"testing"
+)
+
+func TestNewZONEMDResponseOK(t *testing.T) {
+	response := `; fully validated
+example.                86400   IN      ZONEMD  2018031900 1 1 C68090D90A7AED716BC459F9340E3D7C1370D4D24B7E2FC3A1DDC0B9 A87153B9A9713B3C9AE5CC27777F98B8E730044C
+example.                86400   IN      RRSIG   ZONEMD 13 1 86400 20240111000000 20231221000000 2371 example. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	expected := &ZONEMDResponse{
+		Records: []ZONEMDRecord{
+			{Serial: 2018031900, Scheme: 1, HashAlgorithm: 1,
+				Digest: "C68090D90A7AED716BC459F9340E3D7C1370D4D24B7E2FC3A1DDC0B9A87153B9A9713B3C9AE5CC27777F98B8E730044C"},
+		},
+		Validated: true,
+		RRSIG: &RRSIGRecord{
+			TypeCovered: "ZONEMD",
+			Algorithm:   13,
+			Labels:      1,
+			OriginalTTL: 86400,
+			Expiration:  1704931200,
+			Inception:   1703116800,
+			KeyTag:      2371,
+			SignerName:  "example",
+			Signature:   "e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIqoNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=",
+		},
+		RawResponse: response,
+	}
+	r := &ZONEMDResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Failed to parse ZONEMD record: %v", err)
+	}
+	zonemdResponse, ok := result.(*ZONEMDResponse)
+	if !ok {
+		t.Fatalf("Result is not a *ZONEMDResponse")
+	}
+
+	if !zonemdResponse.Compare(expected) {
+		t.Errorf("Parsed record %+v does not match expected %+v", zonemdResponse, expected)
+	}
+	record := zonemdResponse.Records[0]
+	if record.SchemeName() != "SIMPLE" || record.HashAlgorithmName() != "SHA384" {
+		t.Errorf("Unexpected mnemonics %s %s", record.SchemeName(), record.HashAlgorithmName())
+	}
+}
+
+func TestNewZONEMDResponseNoData(t *testing.T) {
+	response := `;; resolution failed: ncache nxrrset
+; negative response, unsigned answer
+; example.com.                  3600    IN      \-ZONEMD        ;-$NXRRSET`
+	r := &ZONEMDResponse{}
+	result, err := r.Parse(response)
+	if err != nil {
+		t.Fatalf("Expected an empty ZONEMD response, got error: %v", err)
+	}
+	if zonemdResponse := result.(*ZONEMDResponse); len(zonemdResponse.Records) != 0 || zonemdResponse.Validated {
+		t.Errorf("Expected an unvalidated, empty ZONEMD response, got %+v", zonemdResponse)
+	}
+}
+
+func TestNewZONEMDResponseInvalidSerial(t *testing.T) {
+	response := `example.                86400   IN      ZONEMD  serial 1 1 C68090D9`
+	r := &ZONEMDResponse{}
+	if _, err := r.Parse(response); err == nil {
+		t.Errorf("Expected an error for an invalid serial")
+	}
+}
+
+func TestNewZONEMDResponseParseTwice(t *testing.T) {
+	signed := `; fully validated
+example.                86400   IN      ZONEMD  2018031900 1 1 C68090D90A7AED716BC459F9340E3D7C1370D4D24B7E2FC3A1DDC0B9 A87153B9A9713B3C9AE5CC27777F98B8E730044C
+example.                86400   IN      RRSIG   ZONEMD 13 1 86400 20240111000000 20231221000000 2371 example. e+ACsJVlX+uZTbt0B2dXJQmbjUkBBXwt1tb0W6KF5A5lLwKtmrpamSIq oNK3zJcwlGKRL1wkpUe4ZKakrwrumI4=`
+	noData := `;; resolution failed: ncache nxrrset
+; negative response, fully validated
+; example.org.                  3600    IN      \-ZONEMD ;-$NXRRSET`
+	r := &ZONEMDResponse{}
+	if _, err := r.Parse(signed); err != nil {
+		t.Fatalf("Failed to parse ZONEMD record: %v", err)
+	}
+	result, err := r.Parse(noData)
+	if err != nil {
+		t.Fatalf("Expected an empty ZONEMD response, got error: %v", err)
+	}
+	expected := &ZONEMDResponse{Validated: true, RawResponse: noData}
+	if zonemdResponse := result.(*ZONEMDResponse); !zonemdResponse.Compare(expected) {
+		t.Errorf("Expected nothing to carry over from the previous response, got %+v", zonemdResponse)
+	}
+}
@@ -1,5 +1,10 @@
 package dnsrecords
 
+import (
+	"strconv"
+	"strings"
+)
+
 // DNSRecordParser is an interface that defines the standard method for parsing DNS record responses.
 // Implementations of this interface are responsible for parsing raw DNS response strings
 // and converting them into structured data types.
@@ -32,3 +37,100 @@ type DNSRecordParser interface {
 // DNS record type they represent. For example, a struct representing the result of parsing
 // a DS record might include fields for key tag, algorithm, digest type, digest, and validation status.
 type DNSRecordResult interface{}
+
+// NewDNSRecordResult returns an empty result of the type the parser of the given record type
+// produces, so that stored results can be decoded back into their concrete types. It returns
+// false for record types without a parser.
+func NewDNSRecordResult(recordType string) (DNSRecordResult, bool) {
+	switch recordType {
+	case "DNSKEY":
+		return &DNSKEYResponse{}, true
+	case "DS":
+		return &DSResponse{}, true
+	case "SOA":
+		return &SOARecord{}, true
+	case "A":
+		return &AResponse{}, true
+	case "AAAA":
+		return &AAAAResponse{}, true
+	case "NSEC":
+		return &NSECRecord{}, true
+	case "NSEC3PARAM":
+		return &NSEC3PARAMRecord{}, true
+	case "CDS":
+		return &CDSResponse{}, true
+	case "CDNSKEY":
+		return &CDNSKEYResponse{}, true
+	case "NS":
+		return &NSResponse{}, true
+	case "TLSA":
+		return &TLSAResponse{}, true
+	case "CAA":
+		return &CAAResponse{}, true
+	case "MX":
+		return &MXResponse{}, true
+	case "TXT":
+		return &TXTResponse{}, true
+	case "SVCB":
+		return &SVCBResponse{RecordType: "SVCB"}, true
+	case "HTTPS":
+		return &SVCBResponse{RecordType: "HTTPS"}, true
+	case "ZONEMD":
+		return &ZONEMDResponse{}, true
+	default:
+		return nil, false
+	}
+}
+
+// NewDNSRecordParser returns a new, empty parser for the given record type. Parsers accumulate
+// the records of the response they parse, so a fresh parser must be used for every response.
+// It returns false for record types without a parser.
+func NewDNSRecordParser(recordType string) (DNSRecordParser, bool) {
+	result, ok := NewDNSRecordResult(recordType)
+	if !ok {
+		return nil, false
+	}
+	parser, ok := result.(DNSRecordParser)
+	return parser, ok
+}
+
+// recordFields splits a resource record line into its fields using the regular
+// "owner TTL class TYPE RDATA" layout. delv prints the records of a negative proof as
+// comments without TTL and class (e.g. "; owner NSEC3 RDATA"); such lines are normalized
+// with a zero TTL and the IN class so that the same field positions apply to both forms.
+func recordFields(line string) []string {
+	parts := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), ";"))
+	if len(parts) < 2 {
+		return parts
+	}
+	if _, err := strconv.ParseUint(parts[1], 10, 32); err == nil {
+		return parts
+	}
+	if parts[1] == "IN" {
+		return append([]string{parts[0], "0"}, parts[1:]...)
+	}
+	return append([]string{parts[0], "0", "IN"}, parts[1:]...)
+}
+
+// isNoDataResponse reports whether a delv response states that the name exists but holds no
+// records of the queried type. For optional record types this is a valid, empty answer.
+func isNoDataResponse(response string) bool {
+	return strings.Contains(response, "ncache nxrrset")
+}
+
+// isNegativeResponse reports whether a delv response states that the name does not exist or
+// holds no records of the queried type. For names that are probed rather than known to exist,
+// such as TLSA names or the ancestors climbed for CAA records, both are valid, empty answers.
+func isNegativeResponse(response string) bool {
+	return isNoDataResponse(response) || strings.Contains(response, "ncache nxdomain")
+}
+
+// isValidatedResponse reports whether a delv response, positive or negative, was fully validated.
+func isValidatedResponse(response string) bool {
+	for _, line := range strings.Split(response, "\n") {
+		if strings.HasPrefix(line, ";") && strings.Contains(line, "fully validated") {
+			return true
+		}
+	}
+	return false
+}
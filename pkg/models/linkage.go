@@ -0,0 +1,94 @@
+package models
+
+// Status values of a DNSKEY in a KeyLinkageReport.
+const (
+	KeyStatusActive       = "active"
+	KeyStatusStandby      = "stand-by"
+	KeyStatusPrePublished = "pre-published"
+	KeyStatusRevoked      = "revoked"
+)
+
+// KeyLinkageReport links the RRSIG records of an assessment to the DNSKEY records that produced
+// them. Each signature references its key by key tag and algorithm; the report computes the key
+// tag of every published key (RFC 4034, Appendix B) and matches the two, which exposes keys that
+// sign nothing, signatures made by keys that are no longer published, and DS records that point
+// at keys that do not sign the DNSKEY set.
+//
+// Fields:
+//
+//	Zone: The owner name of the DNSKEY set the signatures were matched against.
+//
+//	Keys: A slice of KeyUsage structs, one per published DNSKEY, describing what each key signs.
+//
+//	Signatures: A slice of SignatureLink structs, one per RRSIG found in the assessment, telling
+//	            which key produced it.
+//
+//	UnusedKeys: The key tags of the published keys that do not sign any record set.
+//
+//	MissingKeys: The key tags referenced by signatures of the zone for which no DNSKEY is published.
+//
+//	Findings: Human-readable notes describing stand-by and pre-published keys, signatures made by
+//	          absent keys and other signs of a broken rollover.
+type KeyLinkageReport struct {
+	Zone        string
+	Keys        []KeyUsage
+	Signatures  []SignatureLink
+	UnusedKeys  []uint16
+	MissingKeys []uint16
+	Findings    []string
+}
+
+// KeyUsage describes how a published DNSKEY is used.
+//
+// Fields:
+//
+//	KeyTag: The key tag computed from the RDATA of the key.
+//
+//	Flags: The flags field of the key (256 for a zone key, 257 when the SEP flag is also set).
+//
+//	Algorithm: The algorithm number of the key.
+//
+//	Role: The role the key plays according to what it signs: "KSK" when it only signs the key
+//	      sets, "ZSK" when it only signs other data, "CSK" when it signs both, and empty when the
+//	      key signs nothing.
+//
+//	Status: One of "active" (the key signs at least one record set), "stand-by" (the key signs
+//	        nothing but is referenced by a DS record, so it can take over immediately),
+//	        "pre-published" (the key signs nothing and has no DS record) or "revoked" (the REVOKE
+//	        flag of RFC 5011 is set).
+//
+//	SignedTypes: The record types whose signatures were made by the key.
+//
+//	HasDS: A boolean flag indicating whether a DS record at the parent matches the key.
+type KeyUsage struct {
+	KeyTag      uint16
+	Flags       uint16
+	Algorithm   uint8
+	Role        string
+	Status      string
+	SignedTypes []string
+	HasDS       bool
+}
+
+// SignatureLink describes the key an RRSIG record was matched to.
+//
+// Fields:
+//
+//	TypeCovered: The record type the signature covers.
+//
+//	KeyTag: The key tag the signature references.
+//
+//	Algorithm: The algorithm number the signature references.
+//
+//	SignerName: The zone that produced the signature.
+//
+//	KeyFound: A boolean flag indicating whether a published DNSKEY with the same key tag and
+//	          algorithm was found. It is always false for signatures of another zone, whose keys
+//	          are not part of the assessment.
+type SignatureLink struct {
+	TypeCovered string
+	KeyTag      uint16
+	Algorithm   uint8
+	SignerName  string
+	KeyFound    bool
+}
@@ -0,0 +1,64 @@
+package models
+
+// MailReport represents the mail security records of the scanned domain and whether each is
+// served from a signed zone. Email authentication policies protect the mail of an institution
+// only as far as their DNS answers cannot be spoofed.
+//
+// Fields:
+//
+//	Domain: The mail domain, which is the zone apex of the scanned host.
+//
+//	Exchangers: The host names of the mail exchangers, in the order of the MX records.
+//
+//	NullMX: A boolean flag indicating whether the domain publishes a null MX record (RFC 7505)
+//	        to state that it accepts no mail.
+//
+//	MXStatus: The DNSSEC status of the MX answer ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	SPF: The SPF policy published at the domain (RFC 7208).
+//
+//	DMARC: The DMARC policy published at "_dmarc.<domain>" (RFC 7489).
+//
+//	MTASTS: The MTA-STS policy indicator published at "_mta-sts.<domain>" (RFC 8461).
+//
+//	TLSRPT: The SMTP TLS reporting policy published at "_smtp._tls.<domain>" (RFC 8460).
+//
+//	Findings: Human-readable notes on missing, weak and unsigned mail policies.
+type MailReport struct {
+	Domain     string
+	Exchangers []string
+	NullMX     bool
+	MXStatus   string
+	SPF        *MailPolicy
+	DMARC      *MailPolicy
+	MTASTS     *MailPolicy
+	TLSRPT     *MailPolicy
+	Findings   []string
+}
+
+// MailPolicy represents a mail policy published in a TXT record.
+//
+// Fields:
+//
+//	Name: The name queried for the policy.
+//
+//	Record: The text of the policy record, empty when the name publishes no such policy.
+//
+//	Tags: The tag-value pairs of the record, such as "p" and "rua" for DMARC. For SPF these are
+//	      the modifiers, such as "redirect".
+//
+//	Mechanisms: The SPF mechanisms in order, with their qualifiers, such as "include:example.net"
+//	            or "-all". It is empty for other policies.
+//
+//	Validated: A boolean flag indicating whether the answer was validated with DNSSEC by the
+//	           resolver used for the scan.
+//
+//	Status: The DNSSEC status of the TXT answer ("secure", "insecure", "bogus" or "indeterminate").
+type MailPolicy struct {
+	Name       string
+	Record     string
+	Tags       map[string]string
+	Mechanisms []string
+	Validated  bool
+	Status     string
+}
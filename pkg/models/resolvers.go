@@ -0,0 +1,50 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// ResolverComparisonReport represents the validation verdicts returned by several recursive
+// resolvers for the same domain. Resolvers disagree when, for instance, one of them does not
+// support the signing algorithm of the zone or serves stale data from its cache.
+//
+// Fields:
+//
+//	Resolvers: A slice of ResolverVerdict structs: the verdict of the configured validator, the
+//	           one the assessment relies on, followed by one per configured resolver.
+//
+//	Agreement: A boolean flag indicating whether every resolver that answered reached the same
+//	           verdict for every record type.
+//
+//	Disagreements: Human-readable notes describing, per record type, which resolvers reached
+//	               which verdict when they did not agree.
+type ResolverComparisonReport struct {
+	Resolvers     []ResolverVerdict
+	Agreement     bool
+	Disagreements []string
+}
+
+// ResolverVerdict represents the validation verdict of a single recursive resolver.
+//
+// Fields:
+//
+//	Resolver: The address of the resolver.
+//
+//	Validator: A boolean flag indicating whether the verdict is the one of the configured DNS server,
+//	           validated locally by delv with the configured trust anchors, rather than the one of a
+//	           resolver compared with it.
+//
+//	Verdict: The overall verdict for the domain, the worst of the per record type verdicts:
+//	         "bogus", "indeterminate", "insecure" or "secure".
+//
+//	RecordVerdicts: The verdict for each record type queried, keyed by record type.
+//
+//	Errors: The errors returned for the queries the resolver did not answer, keyed by record type.
+//
+//	ExtendedErrors: The Extended DNS Errors (RFC 8914) returned by the resolver, keyed by record type.
+type ResolverVerdict struct {
+	Resolver       string
+	Validator      bool
+	Verdict        string
+	RecordVerdicts map[string]string
+	Errors         map[string]string
+	ExtendedErrors map[string][]dnsrecords.ExtendedDNSError
+}
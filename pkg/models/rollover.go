@@ -0,0 +1,150 @@
+package models
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"time"
+)
+
+// Kinds of key rollovers reported in a RolloverReport.
+const (
+	RolloverZSK       = "ZSK"
+	RolloverKSK       = "KSK"
+	RolloverAlgorithm = "algorithm"
+)
+
+// Phases of a key rollover (RFC 6781, Section 4.1 and RFC 7583).
+const (
+	RolloverPhasePrePublish      = "pre-publish"
+	RolloverPhaseDoubleSignature = "double-signature"
+	RolloverPhasePostPublish     = "post-publish"
+	RolloverPhaseCompleted       = "completed"
+	RolloverPhaseAbandoned       = "abandoned"
+	RolloverPhaseBroken          = "broken"
+)
+
+// KeySetSnapshot records the DNSKEY set of a zone, and how its keys were used, during a period in
+// which it did not change. Consecutive assessments that observe the same state extend the period
+// of a single snapshot, so the history of a zone is a list of its key set states.
+//
+// Fields:
+//
+//	Zone: The owner name of the DNSKEY set.
+//
+//	FirstSeen: The time of the first assessment that observed this state.
+//
+//	LastSeen: The time of the last assessment that observed this state.
+//
+//	Keys: The DNSKEY records published by the zone.
+//
+//	DNSKEYSigners: The key tags of the keys that signed the DNSKEY set.
+//
+//	ZoneSigners: The key tags of the keys that signed other record sets of the zone.
+//
+//	DSKeyTags: The key tags referenced by the DS records at the parent.
+type KeySetSnapshot struct {
+	Zone          string
+	FirstSeen     time.Time
+	LastSeen      time.Time
+	Keys          []dnsrecords.DNSKEYRecord
+	DNSKEYSigners []uint16
+	ZoneSigners   []uint16
+	DSKeyTags     []uint16
+}
+
+// SameState reports whether two snapshots observed the same keys, used in the same way.
+// The observation times are not compared.
+func (s KeySetSnapshot) SameState(other KeySetSnapshot) bool {
+	if s.Zone != other.Zone || len(s.Keys) != len(other.Keys) {
+		return false
+	}
+	for _, key := range s.Keys {
+		found := false
+		for _, otherKey := range other.Keys {
+			if key.Flags == otherKey.Flags && key.Protocol == otherKey.Protocol &&
+				key.Algorithm == otherKey.Algorithm && key.PublicKey == otherKey.PublicKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return sameKeyTags(s.DNSKEYSigners, other.DNSKEYSigners) &&
+		sameKeyTags(s.ZoneSigners, other.ZoneSigners) &&
+		sameKeyTags(s.DSKeyTags, other.DSKeyTags)
+}
+
+func sameKeyTags(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[uint16]int)
+	for _, tag := range a {
+		count[tag]++
+	}
+	for _, tag := range b {
+		count[tag]--
+		if count[tag] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RolloverEvent describes a key rollover observed across the history of a zone.
+//
+// Fields:
+//
+//	Kind: The kind of rollover: "ZSK", "KSK" or "algorithm".
+//
+//	Phase: The phase the rollover was in at the latest observation: "pre-publish" (the new key
+//	       is published but does not sign yet), "double-signature" (old and new keys both sign),
+//	       "post-publish" (only the new key signs but the old key is still published),
+//	       "completed", "abandoned" (the new key was withdrawn before signing) or "broken" (the
+//	       new key signs the DNSKEY set but no DS record references it any more).
+//
+//	OldKeys: The key tags of the keys being replaced.
+//
+//	NewKeys: The key tags of the keys replacing them.
+//
+//	Started: The time the new keys were first observed.
+//
+//	PhaseSince: The time the rollover was first observed in its current phase.
+//
+//	Completed: The time the rollover was first observed completed. Zero while it is in progress.
+//
+//	Stuck: A boolean flag indicating whether the rollover has stayed in an intermediate phase
+//	       for longer than the configured limit.
+type RolloverEvent struct {
+	Kind       string
+	Phase      string
+	OldKeys    []uint16
+	NewKeys    []uint16
+	Started    time.Time
+	PhaseSince time.Time
+	Completed  time.Time
+	Stuck      bool
+}
+
+// RolloverReport summarizes the key rollovers of a zone, detected by comparing the key set
+// observed by an assessment with the key sets observed by earlier assessments.
+//
+// Fields:
+//
+//	Zone: The owner name of the DNSKEY set.
+//
+//	Observations: The number of distinct key set states compared, including the current one.
+//
+//	Since: The time of the earliest observation.
+//
+//	Events: The rollovers detected, in the order they started.
+//
+//	Findings: Human-readable notes on stuck and broken rollovers.
+type RolloverReport struct {
+	Zone         string
+	Observations int
+	Since        time.Time
+	Events       []RolloverEvent
+	Findings     []string
+}
@@ -0,0 +1,39 @@
+package models
+
+// SecurityScore represents the numeric evaluation of a DNSSEC deployment.
+// It condenses the records collected during an assessment into a single 0–100 score
+// and a letter grade, while keeping the per-criterion breakdown that produced them
+// so that reports can explain why an institution was ranked where it was.
+//
+// Fields:
+//
+//	Score: The weighted average of all criterion scores, in the range 0–100.
+//
+//	Grade: A letter grade derived from Score ("A" to "F").
+//
+//	Breakdown: A slice of CriterionScore structs, one for each evaluated criterion,
+//	           in a stable order.
+type SecurityScore struct {
+	Score     float64
+	Grade     string
+	Breakdown []CriterionScore
+}
+
+// CriterionScore represents the evaluation of a single scoring criterion, such as
+// algorithm strength or signature hygiene.
+//
+// Fields:
+//
+//	Criterion: The identifier of the criterion (e.g., "deployment", "algorithm_strength").
+//
+//	Weight: The relative weight of the criterion in the final score, as configured.
+//
+//	Score: The score obtained for this criterion, in the range 0–100.
+//
+//	Findings: Human-readable notes explaining the deductions applied to this criterion.
+type CriterionScore struct {
+	Criterion string
+	Weight    float64
+	Score     float64
+	Findings  []string
+}
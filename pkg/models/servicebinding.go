@@ -0,0 +1,30 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// ServiceBindingReport represents the HTTPS records (RFC 9460) of the scanned host. Their
+// protocols, Encrypted Client Hello configurations and address hints are used before TLS
+// authenticates the connection, so they can only be trusted when the answer is secure.
+//
+// Fields:
+//
+//	Host: The scanned host name.
+//
+//	Records: The HTTPS records of the host. The slice is empty when it publishes none.
+//
+//	ECH: A boolean flag indicating whether a record carries an Encrypted Client Hello configuration.
+//
+//	Validated: A boolean flag indicating whether the answer was validated with DNSSEC by the
+//	           resolver used for the scan.
+//
+//	Status: The DNSSEC status of the HTTPS answer ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	Findings: Human-readable notes on HTTPS records that are not secure.
+type ServiceBindingReport struct {
+	Host      string
+	Records   []dnsrecords.SVCBRecord
+	ECH       bool
+	Validated bool
+	Status    string
+	Findings  []string
+}
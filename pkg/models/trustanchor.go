@@ -0,0 +1,67 @@
+package models
+
+import (
+	"github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+	"time"
+)
+
+// TrustAnchor represents a configured DNSSEC trust anchor, given either as a DS record or as a
+// DNSKEY record for the apex of a zone.
+//
+// Fields:
+//
+//	Zone: The name of the zone the anchor applies to ("." for the root zone).
+//
+//	DS: A pointer to a DSRecord struct for anchors given as a key digest, nil otherwise.
+//
+//	DNSKEY: A pointer to a DNSKEYRecord struct for anchors given as a public key, nil otherwise.
+//
+//	ValidFrom: The time from which the anchor may be used. Zero when the source does not restrict it.
+//
+//	ValidUntil: The time after which the anchor must no longer be used. Zero when the source does
+//	            not restrict it.
+type TrustAnchor struct {
+	Zone       string
+	DS         *dnsrecords.DSRecord
+	DNSKEY     *dnsrecords.DNSKEYRecord
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+// IsValidAt reports whether the validity period of the anchor includes the given time.
+func (a TrustAnchor) IsValidAt(t time.Time) bool {
+	return (a.ValidFrom.IsZero() || !t.Before(a.ValidFrom)) && (a.ValidUntil.IsZero() || t.Before(a.ValidUntil))
+}
+
+// TrustAnchorReport describes the trust anchor the validation of an assessment depended on.
+// It makes validation auditable, in particular during root KSK rollovers.
+//
+// Fields:
+//
+//	Source: The file the trust anchors were loaded from, or "built-in" when the anchors shipped
+//	        with delv were used.
+//
+//	Zone: The zone of the closest configured trust anchor enclosing the domain.
+//
+//	Anchors: The configured trust anchors for that zone that were valid at the time of the assessment.
+//
+//	UsedAnchors: The anchors that match any key signing the DNSKEY set of that zone, that is, the
+//	             anchors validation actually started from.
+//
+//	SigningKeyTags: The key tags of every key that signed the DNSKEY set of the anchor zone, in
+//	                ascending order. During a KSK rollover the set is signed by both keys.
+//
+//	NegativeAnchor: The negative trust anchor covering the domain, if any. Validation is disabled
+//	                for domains covered by a negative trust anchor.
+//
+//	Findings: Human-readable notes, including the RFC 5011 events observed for the anchor zone,
+//	          such as revoked keys or published keys that are not configured as anchors.
+type TrustAnchorReport struct {
+	Source         string
+	Zone           string
+	Anchors        []TrustAnchor
+	UsedAnchors    []TrustAnchor
+	SigningKeyTags []uint16
+	NegativeAnchor string
+	Findings       []string
+}
@@ -0,0 +1,49 @@
+package models
+
+import "github.com/jacksonbarreto/WebGateScanner-DNSSECAnalyzer/pkg/models/dnsrecords"
+
+// ZONEMDReport represents the ZONEMD records (RFC 8976) published at the zone apex. A zone digest
+// lets recipients of a full copy of the zone verify it; signed with DNSSEC it also protects the
+// copy against tampering.
+//
+// Fields:
+//
+//	Zone: The zone apex.
+//
+//	Records: The ZONEMD records of the apex. The slice is empty when the zone publishes no digest.
+//
+//	Status: The DNSSEC status of the ZONEMD answer ("secure", "insecure", "bogus" or "indeterminate").
+//
+//	Verification: The result of recomputing the digest from a copy of the zone. This field is nil
+//	              when verification is disabled or the zone publishes no digest.
+//
+//	Findings: Human-readable notes on unusable, unsigned and mismatching digests.
+type ZONEMDReport struct {
+	Zone         string
+	Records      []dnsrecords.ZONEMDRecord
+	Status       string
+	Verification *ZONEMDVerification
+	Findings     []string
+}
+
+// ZONEMDVerification represents the comparison of the ZONEMD records of a copy of a zone with
+// the digest recomputed from its data.
+//
+// Fields:
+//
+//	Source: Where the copy of the zone came from, such as a zone file or a transfer server.
+//
+//	Serial: The SOA serial of the copy.
+//
+//	Computed: The upper-case hexadecimal digest recomputed from the copy, empty when none could be.
+//
+//	Matched: A boolean flag indicating whether a ZONEMD record of the copy matches its data.
+//
+//	Error: The reason the verification failed, empty when it succeeded.
+type ZONEMDVerification struct {
+	Source   string
+	Serial   uint32
+	Computed string
+	Matched  bool
+	Error    string
+}
@@ -0,0 +1,36 @@
+package models
+
+// ZoneWalkReport represents the assessment of whether a zone signed with plain NSEC can be
+// enumerated by following its NSEC chain. The walk is bounded, so the report states whether
+// the chain was followed to its end; the discovered names themselves are not retained.
+//
+// Fields:
+//
+//	Performed: A boolean flag indicating whether the NSEC chain was followed.
+//
+//	Steps: The number of NSEC queries issued during the walk.
+//
+//	DiscoveredNames: The number of distinct owner names found in the zone, excluding the apex.
+//
+//	Complete: A boolean flag indicating whether the chain was followed back to the apex,
+//	          meaning every name of the zone was discovered.
+//
+//	Enumerable: A boolean flag indicating whether the NSEC chain exposes names of the zone.
+//
+//	MinimalNSEC: A boolean flag indicating whether the zone synthesizes minimally covering NSEC
+//	             records ("black lies" or RFC 4470 "white lies"), which prevent enumeration.
+//
+//	CompactDenial: A boolean flag indicating whether compact denial of existence (the NXNAME
+//	               pseudo-type) is in use.
+//
+//	StopReason: A human-readable explanation of why the walk stopped or was not performed.
+type ZoneWalkReport struct {
+	Performed       bool
+	Steps           int
+	DiscoveredNames int
+	Complete        bool
+	Enumerable      bool
+	MinimalNSEC     bool
+	CompactDenial   bool
+	StopReason      string
+}